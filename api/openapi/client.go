@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 )
@@ -89,26 +90,92 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetApiV1Audit request
+	GetApiV1Audit(ctx context.Context, params *GetApiV1AuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1Inbox request
+	GetApiV1Inbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiV1Request request with any body
+	PostApiV1RequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiV1Request(ctx context.Context, body PostApiV1RequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiV1RequestId request
+	DeleteApiV1RequestId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1RequestId request
+	GetApiV1RequestId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1Secret request
+	GetApiV1Secret(ctx context.Context, params *GetApiV1SecretParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostApiV1Secret request with any body
-	PostApiV1SecretWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	PostApiV1SecretWithBody(ctx context.Context, params *PostApiV1SecretParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	PostApiV1Secret(ctx context.Context, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	PostApiV1Secret(ctx context.Context, params *PostApiV1SecretParams, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteApiV1SecretId request
 	DeleteApiV1SecretId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetApiV1SecretId request
-	GetApiV1SecretId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetApiV1SecretId(ctx context.Context, id string, params *GetApiV1SecretIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchApiV1SecretId request with any body
+	PatchApiV1SecretIdWithBody(ctx context.Context, id string, params *PatchApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchApiV1SecretId(ctx context.Context, id string, params *PatchApiV1SecretIdParams, body PatchApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiV1SecretId request with any body
+	PutApiV1SecretIdWithBody(ctx context.Context, id string, params *PutApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiV1SecretId(ctx context.Context, id string, params *PutApiV1SecretIdParams, body PutApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1SecretIdDownload request
+	GetApiV1SecretIdDownload(ctx context.Context, id string, params *GetApiV1SecretIdDownloadParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1SecretIdStatus request
+	GetApiV1SecretIdStatus(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1SecretIdVersions request
+	GetApiV1SecretIdVersions(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiV1SecretIdVersionsVersion request
+	GetApiV1SecretIdVersionsVersion(ctx context.Context, id string, version int64, params *GetApiV1SecretIdVersionsVersionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiV1SecretIdVersionsVersionRollback request
+	PostApiV1SecretIdVersionsVersionRollback(ctx context.Context, id string, version int64, params *PostApiV1SecretIdVersionsVersionRollbackParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiV1Split request with any body
+	PostApiV1SplitWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiV1Split(ctx context.Context, body PostApiV1SplitJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiV1SplitCombine request with any body
+	PostApiV1SplitCombineWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiV1SplitCombine(ctx context.Context, body PostApiV1SplitCombineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetHealthcheck request
 	GetHealthcheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetPing request
 	GetPing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRToken request
+	GetRToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRToken request with any body
+	PostRTokenWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRToken(ctx context.Context, token string, body PostRTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSToken request
+	GetSToken(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) PostApiV1SecretWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostApiV1SecretRequestWithBody(c.Server, contentType, body)
+func (c *Client) GetApiV1Audit(ctx context.Context, params *GetApiV1AuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1AuditRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -119,8 +186,8 @@ func (c *Client) PostApiV1SecretWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostApiV1Secret(ctx context.Context, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostApiV1SecretRequest(c.Server, body)
+func (c *Client) GetApiV1Inbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1InboxRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -131,8 +198,8 @@ func (c *Client) PostApiV1Secret(ctx context.Context, body PostApiV1SecretJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteApiV1SecretId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteApiV1SecretIdRequest(c.Server, id)
+func (c *Client) PostApiV1RequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1RequestRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -143,8 +210,8 @@ func (c *Client) DeleteApiV1SecretId(ctx context.Context, id string, reqEditors
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetApiV1SecretId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetApiV1SecretIdRequest(c.Server, id)
+func (c *Client) PostApiV1Request(ctx context.Context, body PostApiV1RequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1RequestRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -155,8 +222,8 @@ func (c *Client) GetApiV1SecretId(ctx context.Context, id string, reqEditors ...
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetHealthcheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetHealthcheckRequest(c.Server)
+func (c *Client) DeleteApiV1RequestId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiV1RequestIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -167,8 +234,8 @@ func (c *Client) GetHealthcheck(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetPing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetPingRequest(c.Server)
+func (c *Client) GetApiV1RequestId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1RequestIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -179,396 +246,3576 @@ func (c *Client) GetPing(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	return c.Client.Do(req)
 }
 
-// NewPostApiV1SecretRequest calls the generic PostApiV1Secret builder with application/json body
-func NewPostApiV1SecretRequest(server string, body PostApiV1SecretJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetApiV1Secret(ctx context.Context, params *GetApiV1SecretParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostApiV1SecretRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostApiV1SecretRequestWithBody generates requests for PostApiV1Secret with any type of body
-func NewPostApiV1SecretRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostApiV1SecretWithBody(ctx context.Context, params *PostApiV1SecretParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SecretRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v1/secret/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostApiV1Secret(ctx context.Context, params *PostApiV1SecretParams, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SecretRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewDeleteApiV1SecretIdRequest generates requests for DeleteApiV1SecretId
-func NewDeleteApiV1SecretIdRequest(server string, id string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) DeleteApiV1SecretId(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiV1SecretIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetApiV1SecretId(ctx context.Context, id string, params *GetApiV1SecretIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretIdRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PatchApiV1SecretIdWithBody(ctx context.Context, id string, params *PatchApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchApiV1SecretIdRequestWithBody(c.Server, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+func (c *Client) PatchApiV1SecretId(ctx context.Context, id string, params *PatchApiV1SecretIdParams, body PatchApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchApiV1SecretIdRequest(c.Server, id, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetApiV1SecretIdRequest generates requests for GetApiV1SecretId
-func NewGetApiV1SecretIdRequest(server string, id string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PutApiV1SecretIdWithBody(ctx context.Context, id string, params *PutApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiV1SecretIdRequestWithBody(c.Server, id, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PutApiV1SecretId(ctx context.Context, id string, params *PutApiV1SecretIdParams, body PutApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiV1SecretIdRequest(c.Server, id, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetApiV1SecretIdDownload(ctx context.Context, id string, params *GetApiV1SecretIdDownloadParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretIdDownloadRequest(c.Server, id, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetApiV1SecretIdStatus(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretIdStatusRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetHealthcheckRequest generates requests for GetHealthcheck
-func NewGetHealthcheckRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetApiV1SecretIdVersions(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretIdVersionsRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/healthcheck")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetApiV1SecretIdVersionsVersion(ctx context.Context, id string, version int64, params *GetApiV1SecretIdVersionsVersionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiV1SecretIdVersionsVersionRequest(c.Server, id, version, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PostApiV1SecretIdVersionsVersionRollback(ctx context.Context, id string, version int64, params *PostApiV1SecretIdVersionsVersionRollbackParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SecretIdVersionsVersionRollbackRequest(c.Server, id, version, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetPingRequest generates requests for GetPing
-func NewGetPingRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostApiV1SplitWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SplitRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/ping")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostApiV1Split(ctx context.Context, body PostApiV1SplitJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SplitRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PostApiV1SplitCombineWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SplitCombineRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) PostApiV1SplitCombine(ctx context.Context, body PostApiV1SplitCombineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiV1SplitCombineRequest(c.Server, body)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
+	return c.Client.Do(req)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+func (c *Client) GetHealthcheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthcheckRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) GetPing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPingRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) GetRToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRTokenRequest(c.Server, token)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
+func (c *Client) PostRTokenWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRTokenRequestWithBody(c.Server, token, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRToken(ctx context.Context, token string, body PostRTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRTokenRequest(c.Server, token, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSToken(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSTokenRequest(c.Server, token, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetApiV1AuditRequest generates requests for GetApiV1Audit
+func NewGetApiV1AuditRequest(server string, params *GetApiV1AuditParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/audit/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.SecretId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "secretId", runtime.ParamLocationQuery, *params.SecretId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Subject != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "subject", runtime.ParamLocationQuery, *params.Subject); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Since != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Until != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1InboxRequest generates requests for GetApiV1Inbox
+func NewGetApiV1InboxRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/inbox/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiV1RequestRequest calls the generic PostApiV1Request builder with application/json body
+func NewPostApiV1RequestRequest(server string, body PostApiV1RequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiV1RequestRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiV1RequestRequestWithBody generates requests for PostApiV1Request with any type of body
+func NewPostApiV1RequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/request/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiV1RequestIdRequest generates requests for DeleteApiV1RequestId
+func NewDeleteApiV1RequestIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/request/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1RequestIdRequest generates requests for GetApiV1RequestId
+func NewGetApiV1RequestIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/request/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretRequest generates requests for GetApiV1Secret
+func NewGetApiV1SecretRequest(server string, params *GetApiV1SecretParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Owner != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "owner", runtime.ParamLocationQuery, *params.Owner); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.CreatedAfter != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.CreatedBefore != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.MetaKey != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "metaKey", runtime.ParamLocationQuery, *params.MetaKey); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Cursor != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiV1SecretRequest calls the generic PostApiV1Secret builder with application/json body
+func NewPostApiV1SecretRequest(server string, params *PostApiV1SecretParams, body PostApiV1SecretJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiV1SecretRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostApiV1SecretRequestWithBody generates requests for PostApiV1Secret with any type of body
+func NewPostApiV1SecretRequestWithBody(server string, params *PostApiV1SecretParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Ttl != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, *params.Ttl); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ExpireAt != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "expireAt", runtime.ParamLocationQuery, *params.ExpireAt); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.NotBefore != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "notBefore", runtime.ParamLocationQuery, *params.NotBefore); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.MaxViews != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "maxViews", runtime.ParamLocationQuery, *params.MaxViews); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Recipients != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "recipients", runtime.ParamLocationQuery, *params.Recipients); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Groups != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "groups", runtime.ParamLocationQuery, *params.Groups); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.ContentType != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "contentType", runtime.ParamLocationQuery, *params.ContentType); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Filename != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filename", runtime.ParamLocationQuery, *params.Filename); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.CallbackUrl != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "callbackUrl", runtime.ParamLocationQuery, *params.CallbackUrl); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Opaque != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "opaque", runtime.ParamLocationQuery, *params.Opaque); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params.XPassphrase != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Passphrase", runtime.ParamLocationHeader, *params.XPassphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Passphrase", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiV1SecretIdRequest generates requests for DeleteApiV1SecretId
+func NewDeleteApiV1SecretIdRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretIdRequest generates requests for GetApiV1SecretId
+func NewGetApiV1SecretIdRequest(server string, id string, params *GetApiV1SecretIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Burn != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "burn", runtime.ParamLocationQuery, *params.Burn); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.XPassphrase != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Passphrase", runtime.ParamLocationHeader, *params.XPassphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Passphrase", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewPatchApiV1SecretIdRequest calls the generic PatchApiV1SecretId builder with application/json body
+func NewPatchApiV1SecretIdRequest(server string, id string, params *PatchApiV1SecretIdParams, body PatchApiV1SecretIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchApiV1SecretIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchApiV1SecretIdRequestWithBody generates requests for PatchApiV1SecretId with any type of body
+func NewPatchApiV1SecretIdRequestWithBody(server string, id string, params *PatchApiV1SecretIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params.IfMatch != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("If-Match", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewPutApiV1SecretIdRequest calls the generic PutApiV1SecretId builder with application/json body
+func NewPutApiV1SecretIdRequest(server string, id string, params *PutApiV1SecretIdParams, body PutApiV1SecretIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiV1SecretIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPutApiV1SecretIdRequestWithBody generates requests for PutApiV1SecretId with any type of body
+func NewPutApiV1SecretIdRequestWithBody(server string, id string, params *PutApiV1SecretIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params.IfMatch != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("If-Match", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretIdDownloadRequest generates requests for GetApiV1SecretIdDownload
+func NewGetApiV1SecretIdDownloadRequest(server string, id string, params *GetApiV1SecretIdDownloadParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s/download", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Burn != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "burn", runtime.ParamLocationQuery, *params.Burn); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.XPassphrase != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Passphrase", runtime.ParamLocationHeader, *params.XPassphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Passphrase", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretIdStatusRequest generates requests for GetApiV1SecretIdStatus
+func NewGetApiV1SecretIdStatusRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretIdVersionsRequest generates requests for GetApiV1SecretIdVersions
+func NewGetApiV1SecretIdVersionsRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s/versions/", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiV1SecretIdVersionsVersionRequest generates requests for GetApiV1SecretIdVersionsVersion
+func NewGetApiV1SecretIdVersionsVersionRequest(server string, id string, version int64, params *GetApiV1SecretIdVersionsVersionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "version", runtime.ParamLocationPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s/versions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.XPassphrase != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Passphrase", runtime.ParamLocationHeader, *params.XPassphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Passphrase", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewPostApiV1SecretIdVersionsVersionRollbackRequest generates requests for PostApiV1SecretIdVersionsVersionRollback
+func NewPostApiV1SecretIdVersionsVersionRollbackRequest(server string, id string, version int64, params *PostApiV1SecretIdVersionsVersionRollbackParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "version", runtime.ParamLocationPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/secret/%s/versions/%s/rollback", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.IfMatch != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("If-Match", headerParam0)
+	}
+
+	return req, nil
+}
+
+// NewPostApiV1SplitRequest calls the generic PostApiV1Split builder with application/json body
+func NewPostApiV1SplitRequest(server string, body PostApiV1SplitJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiV1SplitRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiV1SplitRequestWithBody generates requests for PostApiV1Split with any type of body
+func NewPostApiV1SplitRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/split/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiV1SplitCombineRequest calls the generic PostApiV1SplitCombine builder with application/json body
+func NewPostApiV1SplitCombineRequest(server string, body PostApiV1SplitCombineJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiV1SplitCombineRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiV1SplitCombineRequestWithBody generates requests for PostApiV1SplitCombine with any type of body
+func NewPostApiV1SplitCombineRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/split/combine")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetHealthcheckRequest generates requests for GetHealthcheck
+func NewGetHealthcheckRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthcheck")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPingRequest generates requests for GetPing
+func NewGetPingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ping")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRTokenRequest generates requests for GetRToken
+func NewGetRTokenRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/r/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostRTokenRequest calls the generic PostRToken builder with application/json body
+func NewPostRTokenRequest(server string, token string, body PostRTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRTokenRequestWithBody(server, token, "application/json", bodyReader)
+}
+
+// NewPostRTokenRequestWithBody generates requests for PostRToken with any type of body
+func NewPostRTokenRequestWithBody(server string, token string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/r/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSTokenRequest generates requests for GetSToken
+func NewGetSTokenRequest(server string, token string, params *GetSTokenParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/s/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Burn != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "burn", runtime.ParamLocationQuery, *params.Burn); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.XPassphrase != nil {
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Passphrase", runtime.ParamLocationHeader, *params.XPassphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Passphrase", headerParam0)
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetApiV1Audit request
+	GetApiV1AuditWithResponse(ctx context.Context, params *GetApiV1AuditParams, reqEditors ...RequestEditorFn) (*GetApiV1AuditResponse, error)
+
+	// GetApiV1Inbox request
+	GetApiV1InboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiV1InboxResponse, error)
+
+	// PostApiV1Request request with any body
+	PostApiV1RequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1RequestResponse, error)
+
+	PostApiV1RequestWithResponse(ctx context.Context, body PostApiV1RequestJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1RequestResponse, error)
+
+	// DeleteApiV1RequestId request
+	DeleteApiV1RequestIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1RequestIdResponse, error)
+
+	// GetApiV1RequestId request
+	GetApiV1RequestIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1RequestIdResponse, error)
+
+	// GetApiV1Secret request
+	GetApiV1SecretWithResponse(ctx context.Context, params *GetApiV1SecretParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretResponse, error)
+
 	// PostApiV1Secret request with any body
-	PostApiV1SecretWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error)
+	PostApiV1SecretWithBodyWithResponse(ctx context.Context, params *PostApiV1SecretParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error)
+
+	PostApiV1SecretWithResponse(ctx context.Context, params *PostApiV1SecretParams, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error)
+
+	// DeleteApiV1SecretId request
+	DeleteApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1SecretIdResponse, error)
+
+	// GetApiV1SecretId request
+	GetApiV1SecretIdWithResponse(ctx context.Context, id string, params *GetApiV1SecretIdParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdResponse, error)
+
+	// PatchApiV1SecretId request with any body
+	PatchApiV1SecretIdWithBodyWithResponse(ctx context.Context, id string, params *PatchApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchApiV1SecretIdResponse, error)
+
+	PatchApiV1SecretIdWithResponse(ctx context.Context, id string, params *PatchApiV1SecretIdParams, body PatchApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchApiV1SecretIdResponse, error)
+
+	// PutApiV1SecretId request with any body
+	PutApiV1SecretIdWithBodyWithResponse(ctx context.Context, id string, params *PutApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiV1SecretIdResponse, error)
+
+	PutApiV1SecretIdWithResponse(ctx context.Context, id string, params *PutApiV1SecretIdParams, body PutApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiV1SecretIdResponse, error)
+
+	// GetApiV1SecretIdDownload request
+	GetApiV1SecretIdDownloadWithResponse(ctx context.Context, id string, params *GetApiV1SecretIdDownloadParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdDownloadResponse, error)
+
+	// GetApiV1SecretIdStatus request
+	GetApiV1SecretIdStatusWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdStatusResponse, error)
+
+	// GetApiV1SecretIdVersions request
+	GetApiV1SecretIdVersionsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdVersionsResponse, error)
+
+	// GetApiV1SecretIdVersionsVersion request
+	GetApiV1SecretIdVersionsVersionWithResponse(ctx context.Context, id string, version int64, params *GetApiV1SecretIdVersionsVersionParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdVersionsVersionResponse, error)
+
+	// PostApiV1SecretIdVersionsVersionRollback request
+	PostApiV1SecretIdVersionsVersionRollbackWithResponse(ctx context.Context, id string, version int64, params *PostApiV1SecretIdVersionsVersionRollbackParams, reqEditors ...RequestEditorFn) (*PostApiV1SecretIdVersionsVersionRollbackResponse, error)
+
+	// PostApiV1Split request with any body
+	PostApiV1SplitWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SplitResponse, error)
+
+	PostApiV1SplitWithResponse(ctx context.Context, body PostApiV1SplitJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SplitResponse, error)
+
+	// PostApiV1SplitCombine request with any body
+	PostApiV1SplitCombineWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SplitCombineResponse, error)
+
+	PostApiV1SplitCombineWithResponse(ctx context.Context, body PostApiV1SplitCombineJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SplitCombineResponse, error)
+
+	// GetHealthcheck request
+	GetHealthcheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthcheckResponse, error)
+
+	// GetPing request
+	GetPingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPingResponse, error)
+
+	// GetRToken request
+	GetRTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetRTokenResponse, error)
+
+	// PostRToken request with any body
+	PostRTokenWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRTokenResponse, error)
+
+	PostRTokenWithResponse(ctx context.Context, token string, body PostRTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRTokenResponse, error)
+
+	// GetSToken request
+	GetSTokenWithResponse(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*GetSTokenResponse, error)
+}
+
+type GetApiV1AuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Events *[]struct {
+			// Action What happened - create, read, update, delete, expire or denied
+			Action    *string    `json:"action,omitempty"`
+			CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+			// Detail Details of event, like reason of denial
+			Detail   *string `json:"detail,omitempty"`
+			Id       *string `json:"id,omitempty"`
+			RemoteIp *string `json:"remoteIp,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+			Subject  *string `json:"subject,omitempty"`
+			TraceId  *string `json:"traceId,omitempty"`
+
+			// Transport Transport of request - http, grpc or prune
+			Transport *string `json:"transport,omitempty"`
+			UserAgent *string `json:"userAgent,omitempty"`
+		} `json:"events,omitempty"`
+
+		// Next Cursor of next page, empty if there are no more pages
+		Next *string `json:"next,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1AuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1AuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1InboxResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		CreatedAt  *string                 `json:"createdAt,omitempty"`
+		ExpireAt   *string                 `json:"expireAt,omitempty"`
+		Fields     *map[string]interface{} `json:"fields,omitempty"`
+		Groups     *[]string               `json:"groups,omitempty"`
+		Id         *string                 `json:"id,omitempty"`
+		MaxViews   *int64                  `json:"maxViews,omitempty"`
+		Owner      *string                 `json:"owner,omitempty"`
+		Recipients *[]string               `json:"recipients,omitempty"`
+		Views      *int64                  `json:"views,omitempty"`
+		ViewsLeft  *int64                  `json:"viewsLeft,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1InboxResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1InboxResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiV1RequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+		// FulfilToken Token of one-time link to send secret, it is returned only once
+		FulfilToken *string `json:"fulfilToken,omitempty"`
+
+		// FulfilUrl Path of one-time link
+		FulfilUrl *string `json:"fulfilUrl,omitempty"`
+		Id        *string `json:"id,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiV1RequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiV1RequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiV1RequestIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiV1RequestIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiV1RequestIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1RequestIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		CreatedAt   *time.Time `json:"createdAt,omitempty"`
+		Description *string    `json:"description,omitempty"`
+		ExpireAt    *time.Time `json:"expireAt,omitempty"`
+		FulfilledAt *time.Time `json:"fulfilledAt,omitempty"`
+		Id          *string    `json:"id,omitempty"`
+		Requester   *string    `json:"requester,omitempty"`
+
+		// SecretId Identifier of secret sent, only requester can read it, empty until secret is sent
+		SecretId *string `json:"secretId,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1RequestIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1RequestIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Next Cursor of next page, empty if there are no more pages
+		Next    *string `json:"next,omitempty"`
+		Secrets *[]struct {
+			ContentType *string                 `json:"contentType,omitempty"`
+			CreatedAt   *string                 `json:"createdAt,omitempty"`
+			ExpireAt    *string                 `json:"expireAt,omitempty"`
+			Fields      *map[string]interface{} `json:"fields,omitempty"`
+			Filename    *string                 `json:"filename,omitempty"`
+			Groups      *[]string               `json:"groups,omitempty"`
+			Id          *string                 `json:"id,omitempty"`
+			MaxViews    *int64                  `json:"maxViews,omitempty"`
+			Owner       *string                 `json:"owner,omitempty"`
+			Recipients  *[]string               `json:"recipients,omitempty"`
+			Views       *int64                  `json:"views,omitempty"`
+			ViewsLeft   *int64                  `json:"viewsLeft,omitempty"`
+		} `json:"secrets,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiV1SecretResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		Id *string `json:"id,omitempty"`
+
+		// ShareToken Token of share link to read secret without JWT token, it is returned only once
+		ShareToken *string `json:"shareToken,omitempty"`
+
+		// ShareUrl Path of share link
+		ShareUrl *string `json:"shareUrl,omitempty"`
+	}
+	JSON422 *struct {
+		Error      *string `json:"error,omitempty"`
+		Violations *[]struct {
+			// Message Description of violation
+			Message *string `json:"message,omitempty"`
+
+			// Rule Name of violated rule
+			Rule *string `json:"rule,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiV1SecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiV1SecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiV1SecretIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON410      *struct {
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+		Error     *string    `json:"error,omitempty"`
+
+		// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+		Reason   *string `json:"reason,omitempty"`
+		SecretId *string `json:"secretId,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiV1SecretIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiV1SecretIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+		Body *string `json:"body,omitempty"`
+
+		// BodyEncoding base64, if body is encoded in base64
+		BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+		ContentType  *string                 `json:"contentType,omitempty"`
+		CreatedAt    *string                 `json:"createdAt,omitempty"`
+		ExpireAt     *string                 `json:"expireAt,omitempty"`
+		Fields       *map[string]interface{} `json:"fields,omitempty"`
+		Filename     *string                 `json:"filename,omitempty"`
+		Groups       *[]string               `json:"groups,omitempty"`
+		Id           *string                 `json:"id,omitempty"`
+		MaxViews     *int64                  `json:"maxViews,omitempty"`
+
+		// NotBefore Time since which secret can be read
+		NotBefore *string `json:"notBefore,omitempty"`
+
+		// Opaque Body is encrypted by client, server has no key to read it
+		Opaque *bool `json:"opaque,omitempty"`
+
+		// Owner Subject of JWT token of secret creator
+		Owner      *string   `json:"owner,omitempty"`
+		Recipients *[]string `json:"recipients,omitempty"`
+
+		// Version Version of secret, it is increased on every update
+		Version *int64 `json:"version,omitempty"`
+		Views   *int64 `json:"views,omitempty"`
+
+		// ViewsLeft How many times secret can be read again, -1 means unlimited
+		ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+	}
+	JSON410 *struct {
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+		Error     *string    `json:"error,omitempty"`
+
+		// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+		Reason   *string `json:"reason,omitempty"`
+		SecretId *string `json:"secretId,omitempty"`
+	}
+	JSON423 *struct {
+		Error     *string    `json:"error,omitempty"`
+		NotBefore *time.Time `json:"notBefore,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchApiV1SecretIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *struct {
+		Error      *string `json:"error,omitempty"`
+		Violations *[]struct {
+			// Message Description of violation
+			Message *string `json:"message,omitempty"`
+
+			// Rule Name of violated rule
+			Rule *string `json:"rule,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchApiV1SecretIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchApiV1SecretIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiV1SecretIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *struct {
+		Error      *string `json:"error,omitempty"`
+		Violations *[]struct {
+			// Message Description of violation
+			Message *string `json:"message,omitempty"`
+
+			// Rule Name of violated rule
+			Rule *string `json:"rule,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiV1SecretIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiV1SecretIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretIdDownloadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON410      *struct {
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+		Error     *string    `json:"error,omitempty"`
+
+		// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+		Reason   *string `json:"reason,omitempty"`
+		SecretId *string `json:"secretId,omitempty"`
+	}
+	JSON423 *struct {
+		Error     *string    `json:"error,omitempty"`
+		NotBefore *time.Time `json:"notBefore,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretIdDownloadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretIdDownloadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretIdStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Accesses Latest reads of secret, oldest first
+		Accesses *[]struct {
+			AccessedAt *time.Time `json:"accessedAt,omitempty"`
+			Subject    *string    `json:"subject,omitempty"`
+		} `json:"accesses,omitempty"`
+		CreatedAt *time.Time `json:"createdAt,omitempty"`
+		ExpireAt  *time.Time `json:"expireAt,omitempty"`
+		Id        *string    `json:"id,omitempty"`
+
+		// MaxViews How many times secret can be read, 0 means unlimited
+		MaxViews *int64 `json:"maxViews,omitempty"`
+
+		// Opened Secret was read at least once
+		Opened *bool `json:"opened,omitempty"`
+
+		// RemainingSeconds How many seconds secret has left to live
+		RemainingSeconds *int64 `json:"remainingSeconds,omitempty"`
+		Views            *int64 `json:"views,omitempty"`
+
+		// ViewsLeft How many times secret can be read yet, -1 means unlimited
+		ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+	}
+	JSON410 *struct {
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+		Error     *string    `json:"error,omitempty"`
+
+		// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+		Reason   *string `json:"reason,omitempty"`
+		SecretId *string `json:"secretId,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretIdStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretIdStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretIdVersionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		Body         *string                 `json:"body,omitempty"`
+		BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+		ContentType  *string                 `json:"contentType,omitempty"`
+		ExpireAt     *string                 `json:"expireAt,omitempty"`
+		Fields       *map[string]interface{} `json:"fields,omitempty"`
+		Filename     *string                 `json:"filename,omitempty"`
+
+		// ReplacedAt When this version was replaced by the next one
+		ReplacedAt *string `json:"replacedAt,omitempty"`
+		SecretId   *string `json:"secretId,omitempty"`
+		Version    *int64  `json:"version,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretIdVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretIdVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiV1SecretIdVersionsVersionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Body         *string                 `json:"body,omitempty"`
+		BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+		ContentType  *string                 `json:"contentType,omitempty"`
+		ExpireAt     *string                 `json:"expireAt,omitempty"`
+		Fields       *map[string]interface{} `json:"fields,omitempty"`
+		Filename     *string                 `json:"filename,omitempty"`
+
+		// ReplacedAt When this version was replaced by the next one
+		ReplacedAt *string `json:"replacedAt,omitempty"`
+		SecretId   *string `json:"secretId,omitempty"`
+		Version    *int64  `json:"version,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiV1SecretIdVersionsVersionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiV1SecretIdVersionsVersionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiV1SecretIdVersionsVersionRollbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiV1SecretIdVersionsVersionRollbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiV1SecretIdVersionsVersionRollbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiV1SplitResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// Id Identifier of split, it is stored in Split-ID metadata of every share
+		Id     *string `json:"id,omitempty"`
+		Shares *[]struct {
+			Holder *string `json:"holder,omitempty"`
+
+			// SecretId Identifier of secret with share, only its holder can read it
+			SecretId *string `json:"secretId,omitempty"`
+		} `json:"shares,omitempty"`
+		Threshold *int `json:"threshold,omitempty"`
+	}
+	JSON422 *struct {
+		Error      *string `json:"error,omitempty"`
+		Violations *[]struct {
+			// Message Description of violation
+			Message *string `json:"message,omitempty"`
+
+			// Rule Name of violated rule
+			Rule *string `json:"rule,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiV1SplitResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiV1SplitResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiV1SplitCombineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+		Body *string `json:"body,omitempty"`
+
+		// BodyEncoding base64, if body is encoded in base64
+		BodyEncoding *string `json:"bodyEncoding,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiV1SplitCombineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiV1SplitCombineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthcheckResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthcheckResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthcheckResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Description *string    `json:"description,omitempty"`
+		ExpireAt    *time.Time `json:"expireAt,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		FulfilledAt *time.Time `json:"fulfilledAt,omitempty"`
+	}
+	JSON422 *struct {
+		Error      *string `json:"error,omitempty"`
+		Violations *[]struct {
+			// Message Description of violation
+			Message *string `json:"message,omitempty"`
+
+			// Rule Name of violated rule
+			Rule *string `json:"rule,omitempty"`
+		} `json:"violations,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+		Body *string `json:"body,omitempty"`
+
+		// BodyEncoding base64, if body is encoded in base64
+		BodyEncoding *string    `json:"bodyEncoding,omitempty"`
+		ContentType  *string    `json:"contentType,omitempty"`
+		ExpireAt     *time.Time `json:"expireAt,omitempty"`
+		Filename     *string    `json:"filename,omitempty"`
+
+		// Opaque Body is encrypted by client, the key is passed to reader out of band
+		Opaque *bool `json:"opaque,omitempty"`
+
+		// ViewsLeft How many times secret can be read again, -1 means unlimited
+		ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+	}
+	JSON410 *struct {
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+		Error     *string    `json:"error,omitempty"`
+
+		// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+		Reason   *string `json:"reason,omitempty"`
+		SecretId *string `json:"secretId,omitempty"`
+	}
+	JSON423 *struct {
+		Error     *string    `json:"error,omitempty"`
+		NotBefore *time.Time `json:"notBefore,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetApiV1AuditWithResponse request returning *GetApiV1AuditResponse
+func (c *ClientWithResponses) GetApiV1AuditWithResponse(ctx context.Context, params *GetApiV1AuditParams, reqEditors ...RequestEditorFn) (*GetApiV1AuditResponse, error) {
+	rsp, err := c.GetApiV1Audit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1AuditResponse(rsp)
+}
+
+// GetApiV1InboxWithResponse request returning *GetApiV1InboxResponse
+func (c *ClientWithResponses) GetApiV1InboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiV1InboxResponse, error) {
+	rsp, err := c.GetApiV1Inbox(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1InboxResponse(rsp)
+}
+
+// PostApiV1RequestWithBodyWithResponse request with arbitrary body returning *PostApiV1RequestResponse
+func (c *ClientWithResponses) PostApiV1RequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1RequestResponse, error) {
+	rsp, err := c.PostApiV1RequestWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1RequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiV1RequestWithResponse(ctx context.Context, body PostApiV1RequestJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1RequestResponse, error) {
+	rsp, err := c.PostApiV1Request(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1RequestResponse(rsp)
+}
+
+// DeleteApiV1RequestIdWithResponse request returning *DeleteApiV1RequestIdResponse
+func (c *ClientWithResponses) DeleteApiV1RequestIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1RequestIdResponse, error) {
+	rsp, err := c.DeleteApiV1RequestId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiV1RequestIdResponse(rsp)
+}
+
+// GetApiV1RequestIdWithResponse request returning *GetApiV1RequestIdResponse
+func (c *ClientWithResponses) GetApiV1RequestIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1RequestIdResponse, error) {
+	rsp, err := c.GetApiV1RequestId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1RequestIdResponse(rsp)
+}
+
+// GetApiV1SecretWithResponse request returning *GetApiV1SecretResponse
+func (c *ClientWithResponses) GetApiV1SecretWithResponse(ctx context.Context, params *GetApiV1SecretParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretResponse, error) {
+	rsp, err := c.GetApiV1Secret(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretResponse(rsp)
+}
+
+// PostApiV1SecretWithBodyWithResponse request with arbitrary body returning *PostApiV1SecretResponse
+func (c *ClientWithResponses) PostApiV1SecretWithBodyWithResponse(ctx context.Context, params *PostApiV1SecretParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error) {
+	rsp, err := c.PostApiV1SecretWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SecretResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiV1SecretWithResponse(ctx context.Context, params *PostApiV1SecretParams, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error) {
+	rsp, err := c.PostApiV1Secret(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SecretResponse(rsp)
+}
+
+// DeleteApiV1SecretIdWithResponse request returning *DeleteApiV1SecretIdResponse
+func (c *ClientWithResponses) DeleteApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1SecretIdResponse, error) {
+	rsp, err := c.DeleteApiV1SecretId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiV1SecretIdResponse(rsp)
+}
+
+// GetApiV1SecretIdWithResponse request returning *GetApiV1SecretIdResponse
+func (c *ClientWithResponses) GetApiV1SecretIdWithResponse(ctx context.Context, id string, params *GetApiV1SecretIdParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdResponse, error) {
+	rsp, err := c.GetApiV1SecretId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretIdResponse(rsp)
+}
+
+// PatchApiV1SecretIdWithBodyWithResponse request with arbitrary body returning *PatchApiV1SecretIdResponse
+func (c *ClientWithResponses) PatchApiV1SecretIdWithBodyWithResponse(ctx context.Context, id string, params *PatchApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchApiV1SecretIdResponse, error) {
+	rsp, err := c.PatchApiV1SecretIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchApiV1SecretIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchApiV1SecretIdWithResponse(ctx context.Context, id string, params *PatchApiV1SecretIdParams, body PatchApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchApiV1SecretIdResponse, error) {
+	rsp, err := c.PatchApiV1SecretId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchApiV1SecretIdResponse(rsp)
+}
+
+// PutApiV1SecretIdWithBodyWithResponse request with arbitrary body returning *PutApiV1SecretIdResponse
+func (c *ClientWithResponses) PutApiV1SecretIdWithBodyWithResponse(ctx context.Context, id string, params *PutApiV1SecretIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiV1SecretIdResponse, error) {
+	rsp, err := c.PutApiV1SecretIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiV1SecretIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiV1SecretIdWithResponse(ctx context.Context, id string, params *PutApiV1SecretIdParams, body PutApiV1SecretIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiV1SecretIdResponse, error) {
+	rsp, err := c.PutApiV1SecretId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiV1SecretIdResponse(rsp)
+}
+
+// GetApiV1SecretIdDownloadWithResponse request returning *GetApiV1SecretIdDownloadResponse
+func (c *ClientWithResponses) GetApiV1SecretIdDownloadWithResponse(ctx context.Context, id string, params *GetApiV1SecretIdDownloadParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdDownloadResponse, error) {
+	rsp, err := c.GetApiV1SecretIdDownload(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretIdDownloadResponse(rsp)
+}
+
+// GetApiV1SecretIdStatusWithResponse request returning *GetApiV1SecretIdStatusResponse
+func (c *ClientWithResponses) GetApiV1SecretIdStatusWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdStatusResponse, error) {
+	rsp, err := c.GetApiV1SecretIdStatus(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretIdStatusResponse(rsp)
+}
+
+// GetApiV1SecretIdVersionsWithResponse request returning *GetApiV1SecretIdVersionsResponse
+func (c *ClientWithResponses) GetApiV1SecretIdVersionsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdVersionsResponse, error) {
+	rsp, err := c.GetApiV1SecretIdVersions(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretIdVersionsResponse(rsp)
+}
+
+// GetApiV1SecretIdVersionsVersionWithResponse request returning *GetApiV1SecretIdVersionsVersionResponse
+func (c *ClientWithResponses) GetApiV1SecretIdVersionsVersionWithResponse(ctx context.Context, id string, version int64, params *GetApiV1SecretIdVersionsVersionParams, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdVersionsVersionResponse, error) {
+	rsp, err := c.GetApiV1SecretIdVersionsVersion(ctx, id, version, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiV1SecretIdVersionsVersionResponse(rsp)
+}
+
+// PostApiV1SecretIdVersionsVersionRollbackWithResponse request returning *PostApiV1SecretIdVersionsVersionRollbackResponse
+func (c *ClientWithResponses) PostApiV1SecretIdVersionsVersionRollbackWithResponse(ctx context.Context, id string, version int64, params *PostApiV1SecretIdVersionsVersionRollbackParams, reqEditors ...RequestEditorFn) (*PostApiV1SecretIdVersionsVersionRollbackResponse, error) {
+	rsp, err := c.PostApiV1SecretIdVersionsVersionRollback(ctx, id, version, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SecretIdVersionsVersionRollbackResponse(rsp)
+}
+
+// PostApiV1SplitWithBodyWithResponse request with arbitrary body returning *PostApiV1SplitResponse
+func (c *ClientWithResponses) PostApiV1SplitWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SplitResponse, error) {
+	rsp, err := c.PostApiV1SplitWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SplitResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiV1SplitWithResponse(ctx context.Context, body PostApiV1SplitJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SplitResponse, error) {
+	rsp, err := c.PostApiV1Split(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SplitResponse(rsp)
+}
+
+// PostApiV1SplitCombineWithBodyWithResponse request with arbitrary body returning *PostApiV1SplitCombineResponse
+func (c *ClientWithResponses) PostApiV1SplitCombineWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SplitCombineResponse, error) {
+	rsp, err := c.PostApiV1SplitCombineWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SplitCombineResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiV1SplitCombineWithResponse(ctx context.Context, body PostApiV1SplitCombineJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SplitCombineResponse, error) {
+	rsp, err := c.PostApiV1SplitCombine(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiV1SplitCombineResponse(rsp)
+}
+
+// GetHealthcheckWithResponse request returning *GetHealthcheckResponse
+func (c *ClientWithResponses) GetHealthcheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthcheckResponse, error) {
+	rsp, err := c.GetHealthcheck(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthcheckResponse(rsp)
+}
+
+// GetPingWithResponse request returning *GetPingResponse
+func (c *ClientWithResponses) GetPingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPingResponse, error) {
+	rsp, err := c.GetPing(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPingResponse(rsp)
+}
+
+// GetRTokenWithResponse request returning *GetRTokenResponse
+func (c *ClientWithResponses) GetRTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetRTokenResponse, error) {
+	rsp, err := c.GetRToken(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRTokenResponse(rsp)
+}
+
+// PostRTokenWithBodyWithResponse request with arbitrary body returning *PostRTokenResponse
+func (c *ClientWithResponses) PostRTokenWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRTokenResponse, error) {
+	rsp, err := c.PostRTokenWithBody(ctx, token, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRTokenWithResponse(ctx context.Context, token string, body PostRTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRTokenResponse, error) {
+	rsp, err := c.PostRToken(ctx, token, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRTokenResponse(rsp)
+}
+
+// GetSTokenWithResponse request returning *GetSTokenResponse
+func (c *ClientWithResponses) GetSTokenWithResponse(ctx context.Context, token string, params *GetSTokenParams, reqEditors ...RequestEditorFn) (*GetSTokenResponse, error) {
+	rsp, err := c.GetSToken(ctx, token, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSTokenResponse(rsp)
+}
+
+// ParseGetApiV1AuditResponse parses an HTTP response from a GetApiV1AuditWithResponse call
+func ParseGetApiV1AuditResponse(rsp *http.Response) (*GetApiV1AuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1AuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Events *[]struct {
+				// Action What happened - create, read, update, delete, expire or denied
+				Action    *string    `json:"action,omitempty"`
+				CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+				// Detail Details of event, like reason of denial
+				Detail   *string `json:"detail,omitempty"`
+				Id       *string `json:"id,omitempty"`
+				RemoteIp *string `json:"remoteIp,omitempty"`
+				SecretId *string `json:"secretId,omitempty"`
+				Subject  *string `json:"subject,omitempty"`
+				TraceId  *string `json:"traceId,omitempty"`
+
+				// Transport Transport of request - http, grpc or prune
+				Transport *string `json:"transport,omitempty"`
+				UserAgent *string `json:"userAgent,omitempty"`
+			} `json:"events,omitempty"`
+
+			// Next Cursor of next page, empty if there are no more pages
+			Next *string `json:"next,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiV1InboxResponse parses an HTTP response from a GetApiV1InboxWithResponse call
+func ParseGetApiV1InboxResponse(rsp *http.Response) (*GetApiV1InboxResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1InboxResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			CreatedAt  *string                 `json:"createdAt,omitempty"`
+			ExpireAt   *string                 `json:"expireAt,omitempty"`
+			Fields     *map[string]interface{} `json:"fields,omitempty"`
+			Groups     *[]string               `json:"groups,omitempty"`
+			Id         *string                 `json:"id,omitempty"`
+			MaxViews   *int64                  `json:"maxViews,omitempty"`
+			Owner      *string                 `json:"owner,omitempty"`
+			Recipients *[]string               `json:"recipients,omitempty"`
+			Views      *int64                  `json:"views,omitempty"`
+			ViewsLeft  *int64                  `json:"viewsLeft,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiV1RequestResponse parses an HTTP response from a PostApiV1RequestWithResponse call
+func ParsePostApiV1RequestResponse(rsp *http.Response) (*PostApiV1RequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiV1RequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+			// FulfilToken Token of one-time link to send secret, it is returned only once
+			FulfilToken *string `json:"fulfilToken,omitempty"`
+
+			// FulfilUrl Path of one-time link
+			FulfilUrl *string `json:"fulfilUrl,omitempty"`
+			Id        *string `json:"id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiV1RequestIdResponse parses an HTTP response from a DeleteApiV1RequestIdWithResponse call
+func ParseDeleteApiV1RequestIdResponse(rsp *http.Response) (*DeleteApiV1RequestIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiV1RequestIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetApiV1RequestIdResponse parses an HTTP response from a GetApiV1RequestIdWithResponse call
+func ParseGetApiV1RequestIdResponse(rsp *http.Response) (*GetApiV1RequestIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	PostApiV1SecretWithResponse(ctx context.Context, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error)
+	response := &GetApiV1RequestIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
-	// DeleteApiV1SecretId request
-	DeleteApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1SecretIdResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			CreatedAt   *time.Time `json:"createdAt,omitempty"`
+			Description *string    `json:"description,omitempty"`
+			ExpireAt    *time.Time `json:"expireAt,omitempty"`
+			FulfilledAt *time.Time `json:"fulfilledAt,omitempty"`
+			Id          *string    `json:"id,omitempty"`
+			Requester   *string    `json:"requester,omitempty"`
+
+			// SecretId Identifier of secret sent, only requester can read it, empty until secret is sent
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	// GetApiV1SecretId request
-	GetApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdResponse, error)
+	}
 
-	// GetHealthcheck request
-	GetHealthcheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthcheckResponse, error)
+	return response, nil
+}
 
-	// GetPing request
-	GetPingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPingResponse, error)
+// ParseGetApiV1SecretResponse parses an HTTP response from a GetApiV1SecretWithResponse call
+func ParseGetApiV1SecretResponse(rsp *http.Response) (*GetApiV1SecretResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1SecretResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Next Cursor of next page, empty if there are no more pages
+			Next    *string `json:"next,omitempty"`
+			Secrets *[]struct {
+				ContentType *string                 `json:"contentType,omitempty"`
+				CreatedAt   *string                 `json:"createdAt,omitempty"`
+				ExpireAt    *string                 `json:"expireAt,omitempty"`
+				Fields      *map[string]interface{} `json:"fields,omitempty"`
+				Filename    *string                 `json:"filename,omitempty"`
+				Groups      *[]string               `json:"groups,omitempty"`
+				Id          *string                 `json:"id,omitempty"`
+				MaxViews    *int64                  `json:"maxViews,omitempty"`
+				Owner       *string                 `json:"owner,omitempty"`
+				Recipients  *[]string               `json:"recipients,omitempty"`
+				Views       *int64                  `json:"views,omitempty"`
+				ViewsLeft   *int64                  `json:"viewsLeft,omitempty"`
+			} `json:"secrets,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-type PostApiV1SecretResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+// ParsePostApiV1SecretResponse parses an HTTP response from a PostApiV1SecretWithResponse call
+func ParsePostApiV1SecretResponse(rsp *http.Response) (*PostApiV1SecretResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiV1SecretResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			Id *string `json:"id,omitempty"`
+
+			// ShareToken Token of share link to read secret without JWT token, it is returned only once
+			ShareToken *string `json:"shareToken,omitempty"`
+
+			// ShareUrl Path of share link
+			ShareUrl *string `json:"shareUrl,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Error      *string `json:"error,omitempty"`
+			Violations *[]struct {
+				// Message Description of violation
+				Message *string `json:"message,omitempty"`
+
+				// Rule Name of violated rule
+				Rule *string `json:"rule,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiV1SecretIdResponse parses an HTTP response from a DeleteApiV1SecretIdWithResponse call
+func ParseDeleteApiV1SecretIdResponse(rsp *http.Response) (*DeleteApiV1SecretIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiV1SecretIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest struct {
+			DeletedAt *time.Time `json:"deletedAt,omitempty"`
+			Error     *string    `json:"error,omitempty"`
+
+			// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+			Reason   *string `json:"reason,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiV1SecretIdResponse parses an HTTP response from a GetApiV1SecretIdWithResponse call
+func ParseGetApiV1SecretIdResponse(rsp *http.Response) (*GetApiV1SecretIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1SecretIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+			Body *string `json:"body,omitempty"`
+
+			// BodyEncoding base64, if body is encoded in base64
+			BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+			ContentType  *string                 `json:"contentType,omitempty"`
+			CreatedAt    *string                 `json:"createdAt,omitempty"`
+			ExpireAt     *string                 `json:"expireAt,omitempty"`
+			Fields       *map[string]interface{} `json:"fields,omitempty"`
+			Filename     *string                 `json:"filename,omitempty"`
+			Groups       *[]string               `json:"groups,omitempty"`
+			Id           *string                 `json:"id,omitempty"`
+			MaxViews     *int64                  `json:"maxViews,omitempty"`
+
+			// NotBefore Time since which secret can be read
+			NotBefore *string `json:"notBefore,omitempty"`
+
+			// Opaque Body is encrypted by client, server has no key to read it
+			Opaque *bool `json:"opaque,omitempty"`
+
+			// Owner Subject of JWT token of secret creator
+			Owner      *string   `json:"owner,omitempty"`
+			Recipients *[]string `json:"recipients,omitempty"`
+
+			// Version Version of secret, it is increased on every update
+			Version *int64 `json:"version,omitempty"`
+			Views   *int64 `json:"views,omitempty"`
+
+			// ViewsLeft How many times secret can be read again, -1 means unlimited
+			ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest struct {
+			DeletedAt *time.Time `json:"deletedAt,omitempty"`
+			Error     *string    `json:"error,omitempty"`
+
+			// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+			Reason   *string `json:"reason,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 423:
+		var dest struct {
+			Error     *string    `json:"error,omitempty"`
+			NotBefore *time.Time `json:"notBefore,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON423 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchApiV1SecretIdResponse parses an HTTP response from a PatchApiV1SecretIdWithResponse call
+func ParsePatchApiV1SecretIdResponse(rsp *http.Response) (*PatchApiV1SecretIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchApiV1SecretIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Error      *string `json:"error,omitempty"`
+			Violations *[]struct {
+				// Message Description of violation
+				Message *string `json:"message,omitempty"`
+
+				// Rule Name of violated rule
+				Rule *string `json:"rule,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiV1SecretIdResponse parses an HTTP response from a PutApiV1SecretIdWithResponse call
+func ParsePutApiV1SecretIdResponse(rsp *http.Response) (*PutApiV1SecretIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiV1SecretIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Error      *string `json:"error,omitempty"`
+			Violations *[]struct {
+				// Message Description of violation
+				Message *string `json:"message,omitempty"`
+
+				// Rule Name of violated rule
+				Rule *string `json:"rule,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiV1SecretIdDownloadResponse parses an HTTP response from a GetApiV1SecretIdDownloadWithResponse call
+func ParseGetApiV1SecretIdDownloadResponse(rsp *http.Response) (*GetApiV1SecretIdDownloadResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1SecretIdDownloadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest struct {
+			DeletedAt *time.Time `json:"deletedAt,omitempty"`
+			Error     *string    `json:"error,omitempty"`
+
+			// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+			Reason   *string `json:"reason,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 423:
+		var dest struct {
+			Error     *string    `json:"error,omitempty"`
+			NotBefore *time.Time `json:"notBefore,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON423 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PostApiV1SecretResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// ParseGetApiV1SecretIdStatusResponse parses an HTTP response from a GetApiV1SecretIdStatusWithResponse call
+func ParseGetApiV1SecretIdStatusResponse(rsp *http.Response) (*GetApiV1SecretIdStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiV1SecretIdStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Accesses Latest reads of secret, oldest first
+			Accesses *[]struct {
+				AccessedAt *time.Time `json:"accessedAt,omitempty"`
+				Subject    *string    `json:"subject,omitempty"`
+			} `json:"accesses,omitempty"`
+			CreatedAt *time.Time `json:"createdAt,omitempty"`
+			ExpireAt  *time.Time `json:"expireAt,omitempty"`
+			Id        *string    `json:"id,omitempty"`
+
+			// MaxViews How many times secret can be read, 0 means unlimited
+			MaxViews *int64 `json:"maxViews,omitempty"`
+
+			// Opened Secret was read at least once
+			Opened *bool `json:"opened,omitempty"`
+
+			// RemainingSeconds How many seconds secret has left to live
+			RemainingSeconds *int64 `json:"remainingSeconds,omitempty"`
+			Views            *int64 `json:"views,omitempty"`
+
+			// ViewsLeft How many times secret can be read yet, -1 means unlimited
+			ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest struct {
+			DeletedAt *time.Time `json:"deletedAt,omitempty"`
+			Error     *string    `json:"error,omitempty"`
+
+			// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+			Reason   *string `json:"reason,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostApiV1SecretResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type DeleteApiV1SecretIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteApiV1SecretIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetApiV1SecretIdVersionsResponse parses an HTTP response from a GetApiV1SecretIdVersionsWithResponse call
+func ParseGetApiV1SecretIdVersionsResponse(rsp *http.Response) (*GetApiV1SecretIdVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
 	}
-	return http.StatusText(0)
-}
-
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteApiV1SecretIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetApiV1SecretIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		Body      *string                 `json:"body,omitempty"`
-		CreatedAt *string                 `json:"createdAt,omitempty"`
-		ExpireAt  *string                 `json:"expireAt,omitempty"`
-		Fields    *map[string]interface{} `json:"fields,omitempty"`
-		Id        *string                 `json:"id,omitempty"`
+	response := &GetApiV1SecretIdVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-}
 
-// Status returns HTTPResponse.Status
-func (r GetApiV1SecretIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			Body         *string                 `json:"body,omitempty"`
+			BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+			ContentType  *string                 `json:"contentType,omitempty"`
+			ExpireAt     *string                 `json:"expireAt,omitempty"`
+			Fields       *map[string]interface{} `json:"fields,omitempty"`
+			Filename     *string                 `json:"filename,omitempty"`
+
+			// ReplacedAt When this version was replaced by the next one
+			ReplacedAt *string `json:"replacedAt,omitempty"`
+			SecretId   *string `json:"secretId,omitempty"`
+			Version    *int64  `json:"version,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetApiV1SecretIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type GetHealthcheckResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetHealthcheckResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetApiV1SecretIdVersionsVersionResponse parses an HTTP response from a GetApiV1SecretIdVersionsVersionWithResponse call
+func ParseGetApiV1SecretIdVersionsVersionResponse(rsp *http.Response) (*GetApiV1SecretIdVersionsVersionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthcheckResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetApiV1SecretIdVersionsVersionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetPingResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Body         *string                 `json:"body,omitempty"`
+			BodyEncoding *string                 `json:"bodyEncoding,omitempty"`
+			ContentType  *string                 `json:"contentType,omitempty"`
+			ExpireAt     *string                 `json:"expireAt,omitempty"`
+			Fields       *map[string]interface{} `json:"fields,omitempty"`
+			Filename     *string                 `json:"filename,omitempty"`
+
+			// ReplacedAt When this version was replaced by the next one
+			ReplacedAt *string `json:"replacedAt,omitempty"`
+			SecretId   *string `json:"secretId,omitempty"`
+			Version    *int64  `json:"version,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetPingResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetPingResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// PostApiV1SecretWithBodyWithResponse request with arbitrary body returning *PostApiV1SecretResponse
-func (c *ClientWithResponses) PostApiV1SecretWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error) {
-	rsp, err := c.PostApiV1SecretWithBody(ctx, contentType, body, reqEditors...)
+// ParsePostApiV1SecretIdVersionsVersionRollbackResponse parses an HTTP response from a PostApiV1SecretIdVersionsVersionRollbackWithResponse call
+func ParsePostApiV1SecretIdVersionsVersionRollbackResponse(rsp *http.Response) (*PostApiV1SecretIdVersionsVersionRollbackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostApiV1SecretResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostApiV1SecretWithResponse(ctx context.Context, body PostApiV1SecretJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiV1SecretResponse, error) {
-	rsp, err := c.PostApiV1Secret(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PostApiV1SecretIdVersionsVersionRollbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostApiV1SecretResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteApiV1SecretIdWithResponse request returning *DeleteApiV1SecretIdResponse
-func (c *ClientWithResponses) DeleteApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteApiV1SecretIdResponse, error) {
-	rsp, err := c.DeleteApiV1SecretId(ctx, id, reqEditors...)
+// ParsePostApiV1SplitResponse parses an HTTP response from a PostApiV1SplitWithResponse call
+func ParsePostApiV1SplitResponse(rsp *http.Response) (*PostApiV1SplitResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteApiV1SecretIdResponse(rsp)
-}
 
-// GetApiV1SecretIdWithResponse request returning *GetApiV1SecretIdResponse
-func (c *ClientWithResponses) GetApiV1SecretIdWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetApiV1SecretIdResponse, error) {
-	rsp, err := c.GetApiV1SecretId(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PostApiV1SplitResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetApiV1SecretIdResponse(rsp)
-}
 
-// GetHealthcheckWithResponse request returning *GetHealthcheckResponse
-func (c *ClientWithResponses) GetHealthcheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthcheckResponse, error) {
-	rsp, err := c.GetHealthcheck(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			// Id Identifier of split, it is stored in Split-ID metadata of every share
+			Id     *string `json:"id,omitempty"`
+			Shares *[]struct {
+				Holder *string `json:"holder,omitempty"`
+
+				// SecretId Identifier of secret with share, only its holder can read it
+				SecretId *string `json:"secretId,omitempty"`
+			} `json:"shares,omitempty"`
+			Threshold *int `json:"threshold,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Error      *string `json:"error,omitempty"`
+			Violations *[]struct {
+				// Message Description of violation
+				Message *string `json:"message,omitempty"`
+
+				// Rule Name of violated rule
+				Rule *string `json:"rule,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	}
-	return ParseGetHealthcheckResponse(rsp)
+
+	return response, nil
 }
 
-// GetPingWithResponse request returning *GetPingResponse
-func (c *ClientWithResponses) GetPingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPingResponse, error) {
-	rsp, err := c.GetPing(ctx, reqEditors...)
+// ParsePostApiV1SplitCombineResponse parses an HTTP response from a PostApiV1SplitCombineWithResponse call
+func ParsePostApiV1SplitCombineResponse(rsp *http.Response) (*PostApiV1SplitCombineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	if rsp.Body != nil {
+		defer rsp.Body.Close()
+	}
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetPingResponse(rsp)
+
+	response := &PostApiV1SplitCombineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+			Body *string `json:"body,omitempty"`
+
+			// BodyEncoding base64, if body is encoded in base64
+			BodyEncoding *string `json:"bodyEncoding,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParsePostApiV1SecretResponse parses an HTTP response from a PostApiV1SecretWithResponse call
-func ParsePostApiV1SecretResponse(rsp *http.Response) (*PostApiV1SecretResponse, error) {
+// ParseGetHealthcheckResponse parses an HTTP response from a GetHealthcheckWithResponse call
+func ParseGetHealthcheckResponse(rsp *http.Response) (*GetHealthcheckResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if rsp.Body != nil {
 		defer rsp.Body.Close()
@@ -577,7 +3824,7 @@ func ParsePostApiV1SecretResponse(rsp *http.Response) (*PostApiV1SecretResponse,
 		return nil, err
 	}
 
-	response := &PostApiV1SecretResponse{
+	response := &GetHealthcheckResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -585,8 +3832,8 @@ func ParsePostApiV1SecretResponse(rsp *http.Response) (*PostApiV1SecretResponse,
 	return response, nil
 }
 
-// ParseDeleteApiV1SecretIdResponse parses an HTTP response from a DeleteApiV1SecretIdWithResponse call
-func ParseDeleteApiV1SecretIdResponse(rsp *http.Response) (*DeleteApiV1SecretIdResponse, error) {
+// ParseGetPingResponse parses an HTTP response from a GetPingWithResponse call
+func ParseGetPingResponse(rsp *http.Response) (*GetPingResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if rsp.Body != nil {
 		defer rsp.Body.Close()
@@ -595,7 +3842,7 @@ func ParseDeleteApiV1SecretIdResponse(rsp *http.Response) (*DeleteApiV1SecretIdR
 		return nil, err
 	}
 
-	response := &DeleteApiV1SecretIdResponse{
+	response := &GetPingResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -603,8 +3850,8 @@ func ParseDeleteApiV1SecretIdResponse(rsp *http.Response) (*DeleteApiV1SecretIdR
 	return response, nil
 }
 
-// ParseGetApiV1SecretIdResponse parses an HTTP response from a GetApiV1SecretIdWithResponse call
-func ParseGetApiV1SecretIdResponse(rsp *http.Response) (*GetApiV1SecretIdResponse, error) {
+// ParseGetRTokenResponse parses an HTTP response from a GetRTokenWithResponse call
+func ParseGetRTokenResponse(rsp *http.Response) (*GetRTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if rsp.Body != nil {
 		defer rsp.Body.Close()
@@ -613,7 +3860,7 @@ func ParseGetApiV1SecretIdResponse(rsp *http.Response) (*GetApiV1SecretIdRespons
 		return nil, err
 	}
 
-	response := &GetApiV1SecretIdResponse{
+	response := &GetRTokenResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -621,11 +3868,8 @@ func ParseGetApiV1SecretIdResponse(rsp *http.Response) (*GetApiV1SecretIdRespons
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest struct {
-			Body      *string                 `json:"body,omitempty"`
-			CreatedAt *string                 `json:"createdAt,omitempty"`
-			ExpireAt  *string                 `json:"expireAt,omitempty"`
-			Fields    *map[string]interface{} `json:"fields,omitempty"`
-			Id        *string                 `json:"id,omitempty"`
+			Description *string    `json:"description,omitempty"`
+			ExpireAt    *time.Time `json:"expireAt,omitempty"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
@@ -637,8 +3881,8 @@ func ParseGetApiV1SecretIdResponse(rsp *http.Response) (*GetApiV1SecretIdRespons
 	return response, nil
 }
 
-// ParseGetHealthcheckResponse parses an HTTP response from a GetHealthcheckWithResponse call
-func ParseGetHealthcheckResponse(rsp *http.Response) (*GetHealthcheckResponse, error) {
+// ParsePostRTokenResponse parses an HTTP response from a PostRTokenWithResponse call
+func ParsePostRTokenResponse(rsp *http.Response) (*PostRTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if rsp.Body != nil {
 		defer rsp.Body.Close()
@@ -647,16 +3891,44 @@ func ParseGetHealthcheckResponse(rsp *http.Response) (*GetHealthcheckResponse, e
 		return nil, err
 	}
 
-	response := &GetHealthcheckResponse{
+	response := &PostRTokenResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			FulfilledAt *time.Time `json:"fulfilledAt,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest struct {
+			Error      *string `json:"error,omitempty"`
+			Violations *[]struct {
+				// Message Description of violation
+				Message *string `json:"message,omitempty"`
+
+				// Rule Name of violated rule
+				Rule *string `json:"rule,omitempty"`
+			} `json:"violations,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
 	return response, nil
 }
 
-// ParseGetPingResponse parses an HTTP response from a GetPingWithResponse call
-func ParseGetPingResponse(rsp *http.Response) (*GetPingResponse, error) {
+// ParseGetSTokenResponse parses an HTTP response from a GetSTokenWithResponse call
+func ParseGetSTokenResponse(rsp *http.Response) (*GetSTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if rsp.Body != nil {
 		defer rsp.Body.Close()
@@ -665,10 +3937,59 @@ func ParseGetPingResponse(rsp *http.Response) (*GetPingResponse, error) {
 		return nil, err
 	}
 
-	response := &GetPingResponse{
+	response := &GetSTokenResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Body Body of secret, text if it is valid UTF-8, base64 otherwise
+			Body *string `json:"body,omitempty"`
+
+			// BodyEncoding base64, if body is encoded in base64
+			BodyEncoding *string    `json:"bodyEncoding,omitempty"`
+			ContentType  *string    `json:"contentType,omitempty"`
+			ExpireAt     *time.Time `json:"expireAt,omitempty"`
+			Filename     *string    `json:"filename,omitempty"`
+
+			// Opaque Body is encrypted by client, the key is passed to reader out of band
+			Opaque *bool `json:"opaque,omitempty"`
+
+			// ViewsLeft How many times secret can be read again, -1 means unlimited
+			ViewsLeft *int64 `json:"viewsLeft,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest struct {
+			DeletedAt *time.Time `json:"deletedAt,omitempty"`
+			Error     *string    `json:"error,omitempty"`
+
+			// Reason Why secret is gone - read, deleted, expired or destroyed after wrong passphrases
+			Reason   *string `json:"reason,omitempty"`
+			SecretId *string `json:"secretId,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 423:
+		var dest struct {
+			Error     *string    `json:"error,omitempty"`
+			NotBefore *time.Time `json:"notBefore,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON423 = &dest
+
+	}
+
 	return response, nil
 }
@@ -3,15 +3,381 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.12.2 DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
 const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// GetApiV1AuditParams defines parameters for GetApiV1Audit.
+type GetApiV1AuditParams struct {
+	// SecretId Secret, events of which are listed
+	SecretId *string `form:"secretId,omitempty" json:"secretId,omitempty"`
+
+	// Subject Subject, actions of which are listed
+	Subject *string `form:"subject,omitempty" json:"subject,omitempty"`
+
+	// Since Events happened at this moment or later
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Events happened before this moment
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Cursor Cursor from next field of previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit How many events are on page, 20 by default
+	Limit *int64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostApiV1RequestJSONBody defines parameters for PostApiV1Request.
+type PostApiV1RequestJSONBody struct {
+	// Description What secret is needed, it is shown to the one who sends secret
+	Description string `json:"description"`
+
+	// ExpireAt Desired expiration time of request, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Ttl Desired lifetime of request in seconds, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// GetApiV1SecretParams defines parameters for GetApiV1Secret.
+type GetApiV1SecretParams struct {
+	// Owner Creator of secrets, only subject of JWT token is allowed
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// CreatedAfter Secrets are created at this moment or later
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Secrets are created before this moment
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+
+	// MetaKey Secrets have metadata field with this name
+	MetaKey *string `form:"metaKey,omitempty" json:"metaKey,omitempty"`
+
+	// Cursor Cursor from next field of previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit How many secrets are on page, 20 by default
+	Limit *int64 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // PostApiV1SecretJSONBody defines parameters for PostApiV1Secret.
 type PostApiV1SecretJSONBody struct {
-	Body *string                 `json:"body,omitempty"`
-	Meta *map[string]interface{} `json:"meta,omitempty"`
+	Body *string `json:"body,omitempty"`
+
+	// CallbackUrl URL notified when secret is read, deleted or expires unread. Notifications are CloudEvents JSON signed by HMAC-SHA256 in X-Purser-Signature header, host must resolve to public addresses
+	CallbackUrl *string `json:"callbackUrl,omitempty"`
+
+	// ContentType MIME type of secret body, detected from body if missing
+	ContentType *string `json:"contentType,omitempty"`
+
+	// ExpireAt Desired expiration time of secret, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Filename File name of secret body for download
+	Filename *string `json:"filename,omitempty"`
+
+	// Groups Groups from groups claim of JWT tokens, whose members can read secret
+	Groups *[]string `json:"groups,omitempty"`
+
+	// MaxViews How many times secret can be read before it is destroyed, 0 means unlimited
+	MaxViews *int64                  `json:"maxViews,omitempty"`
+	Meta     *map[string]interface{} `json:"meta,omitempty"`
+
+	// NotBefore Time since which secret can be read, its lifetime starts from it
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+
+	// Opaque Body is encrypted by client and is stored as is. Server does not enrich, validate or trace it, the key is passed to readers out of band
+	Opaque *bool `json:"opaque,omitempty"`
+
+	// Passphrase Passphrase required to read secret
+	Passphrase *string `json:"passphrase,omitempty"`
+
+	// Recipients Subjects of JWT tokens, who can read secret besides its creator
+	Recipients *[]string `json:"recipients,omitempty"`
+
+	// Ttl Desired lifetime of secret in seconds, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// PostApiV1SecretMultipartBody defines parameters for PostApiV1Secret.
+type PostApiV1SecretMultipartBody struct {
+	CallbackUrl *string    `json:"callbackUrl,omitempty"`
+	ContentType *string    `json:"contentType,omitempty"`
+	ExpireAt    *time.Time `json:"expireAt,omitempty"`
+
+	// File Secret body, its MIME type and file name are taken from upload
+	File       openapi_types.File `json:"file"`
+	Filename   *string            `json:"filename,omitempty"`
+	Groups     *[]string          `json:"groups,omitempty"`
+	MaxViews   *int64             `json:"maxViews,omitempty"`
+	NotBefore  *time.Time         `json:"notBefore,omitempty"`
+	Opaque     *bool              `json:"opaque,omitempty"`
+	Passphrase *string            `json:"passphrase,omitempty"`
+	Recipients *[]string          `json:"recipients,omitempty"`
+	Ttl        *int64             `json:"ttl,omitempty"`
 }
 
+// PostApiV1SecretParams defines parameters for PostApiV1Secret.
+type PostApiV1SecretParams struct {
+	// Ttl Desired lifetime of secret in seconds for octet stream upload
+	Ttl *int64 `form:"ttl,omitempty" json:"ttl,omitempty"`
+
+	// ExpireAt Desired expiration time of secret for octet stream upload
+	ExpireAt *time.Time `form:"expireAt,omitempty" json:"expireAt,omitempty"`
+
+	// NotBefore Time since which secret can be read for octet stream upload
+	NotBefore *time.Time `form:"notBefore,omitempty" json:"notBefore,omitempty"`
+
+	// MaxViews How many times secret can be read for octet stream upload
+	MaxViews *int64 `form:"maxViews,omitempty" json:"maxViews,omitempty"`
+
+	// Recipients Subjects of JWT tokens, who can read secret, for octet stream upload
+	Recipients *[]string `form:"recipients,omitempty" json:"recipients,omitempty"`
+
+	// Groups Groups, whose members can read secret, for octet stream upload
+	Groups *[]string `form:"groups,omitempty" json:"groups,omitempty"`
+
+	// ContentType MIME type of secret body for octet stream upload
+	ContentType *string `form:"contentType,omitempty" json:"contentType,omitempty"`
+
+	// Filename File name of secret body for octet stream upload
+	Filename *string `form:"filename,omitempty" json:"filename,omitempty"`
+
+	// CallbackUrl URL notified when secret is read, deleted or expires unread, for octet stream upload
+	CallbackUrl *string `form:"callbackUrl,omitempty" json:"callbackUrl,omitempty"`
+
+	// Opaque Body is encrypted by client and is stored as is, for octet stream upload
+	Opaque *bool `form:"opaque,omitempty" json:"opaque,omitempty"`
+
+	// XPassphrase Passphrase required to read secret for multipart and octet stream upload
+	XPassphrase *string `json:"X-Passphrase,omitempty"`
+}
+
+// GetApiV1SecretIdParams defines parameters for GetApiV1SecretId.
+type GetApiV1SecretIdParams struct {
+	// Burn Burn secret after reading, only one of concurrent readers receives it
+	Burn *bool `form:"burn,omitempty" json:"burn,omitempty"`
+
+	// XPassphrase Passphrase, if secret is protected by it
+	XPassphrase *string `json:"X-Passphrase,omitempty"`
+}
+
+// PatchApiV1SecretIdJSONBody defines parameters for PatchApiV1SecretId.
+type PatchApiV1SecretIdJSONBody struct {
+	Body *string `json:"body,omitempty"`
+
+	// ContentType MIME type of new body, detected from body if missing
+	ContentType *string `json:"contentType,omitempty"`
+
+	// ExpireAt New expiration time of secret, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Filename File name of new body
+	Filename *string                 `json:"filename,omitempty"`
+	Meta     *map[string]interface{} `json:"meta,omitempty"`
+
+	// Ttl New lifetime of secret in seconds starting from now, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// PatchApiV1SecretIdParams defines parameters for PatchApiV1SecretId.
+type PatchApiV1SecretIdParams struct {
+	// IfMatch Version of secret from ETag header, secret is updated only if its version is the same
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutApiV1SecretIdJSONBody defines parameters for PutApiV1SecretId.
+type PutApiV1SecretIdJSONBody struct {
+	Body *string `json:"body,omitempty"`
+
+	// ContentType MIME type of new body, detected from body if missing
+	ContentType *string `json:"contentType,omitempty"`
+
+	// ExpireAt New expiration time of secret, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Filename File name of new body
+	Filename *string                 `json:"filename,omitempty"`
+	Meta     *map[string]interface{} `json:"meta,omitempty"`
+
+	// Ttl New lifetime of secret in seconds starting from now, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// PutApiV1SecretIdParams defines parameters for PutApiV1SecretId.
+type PutApiV1SecretIdParams struct {
+	// IfMatch Version of secret from ETag header, secret is updated only if its version is the same
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetApiV1SecretIdDownloadParams defines parameters for GetApiV1SecretIdDownload.
+type GetApiV1SecretIdDownloadParams struct {
+	// Burn Burn secret after reading, only one of concurrent readers receives it
+	Burn *bool `form:"burn,omitempty" json:"burn,omitempty"`
+
+	// XPassphrase Passphrase, if secret is protected by it
+	XPassphrase *string `json:"X-Passphrase,omitempty"`
+}
+
+// GetApiV1SecretIdVersionsVersionParams defines parameters for GetApiV1SecretIdVersionsVersion.
+type GetApiV1SecretIdVersionsVersionParams struct {
+	// XPassphrase Passphrase, if secret is protected by it
+	XPassphrase *string `json:"X-Passphrase,omitempty"`
+}
+
+// PostApiV1SecretIdVersionsVersionRollbackParams defines parameters for PostApiV1SecretIdVersionsVersionRollback.
+type PostApiV1SecretIdVersionsVersionRollbackParams struct {
+	// IfMatch Current version of secret from ETag header
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostApiV1SplitJSONBody defines parameters for PostApiV1Split.
+type PostApiV1SplitJSONBody struct {
+	Body *string `json:"body,omitempty"`
+
+	// CallbackUrl URL notified when secret is read, deleted or expires unread. Notifications are CloudEvents JSON signed by HMAC-SHA256 in X-Purser-Signature header, host must resolve to public addresses
+	CallbackUrl *string `json:"callbackUrl,omitempty"`
+
+	// ContentType MIME type of secret body, detected from body if missing
+	ContentType *string `json:"contentType,omitempty"`
+
+	// ExpireAt Desired expiration time of secret, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Filename File name of secret body for download
+	Filename *string `json:"filename,omitempty"`
+
+	// Groups Groups from groups claim of JWT tokens, whose members can read secret
+	Groups *[]string `json:"groups,omitempty"`
+
+	// Holders Subjects of JWT tokens of share holders, one share for each
+	Holders []string `json:"holders"`
+
+	// MaxViews How many times secret can be read before it is destroyed, 0 means unlimited
+	MaxViews *int64                  `json:"maxViews,omitempty"`
+	Meta     *map[string]interface{} `json:"meta,omitempty"`
+
+	// NotBefore Time since which secret can be read, its lifetime starts from it
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+
+	// Opaque Body is encrypted by client and is stored as is. Server does not enrich, validate or trace it, the key is passed to readers out of band
+	Opaque *bool `json:"opaque,omitempty"`
+
+	// Passphrase Passphrase required to read secret
+	Passphrase *string `json:"passphrase,omitempty"`
+
+	// Recipients Subjects of JWT tokens, who can read secret besides its creator
+	Recipients *[]string `json:"recipients,omitempty"`
+
+	// Threshold How many shares are required to reconstruct secret
+	Threshold int `json:"threshold"`
+
+	// Ttl Desired lifetime of secret in seconds, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// PostApiV1SplitCombineJSONBody defines parameters for PostApiV1SplitCombine.
+type PostApiV1SplitCombineJSONBody struct {
+	// Shares Bodies of secrets with shares as they were read
+	Shares []string `json:"shares"`
+}
+
+// PostRTokenJSONBody defines parameters for PostRToken.
+type PostRTokenJSONBody struct {
+	Body *string `json:"body,omitempty"`
+
+	// CallbackUrl URL notified when secret is read, deleted or expires unread. Notifications are CloudEvents JSON signed by HMAC-SHA256 in X-Purser-Signature header, host must resolve to public addresses
+	CallbackUrl *string `json:"callbackUrl,omitempty"`
+
+	// ContentType MIME type of secret body, detected from body if missing
+	ContentType *string `json:"contentType,omitempty"`
+
+	// ExpireAt Desired expiration time of secret, takes precedence over ttl
+	ExpireAt *time.Time `json:"expireAt,omitempty"`
+
+	// Filename File name of secret body for download
+	Filename *string `json:"filename,omitempty"`
+
+	// Groups Groups from groups claim of JWT tokens, whose members can read secret
+	Groups *[]string `json:"groups,omitempty"`
+
+	// MaxViews How many times secret can be read before it is destroyed, 0 means unlimited
+	MaxViews *int64                  `json:"maxViews,omitempty"`
+	Meta     *map[string]interface{} `json:"meta,omitempty"`
+
+	// NotBefore Time since which secret can be read, its lifetime starts from it
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+
+	// Opaque Body is encrypted by client and is stored as is. Server does not enrich, validate or trace it, the key is passed to readers out of band
+	Opaque *bool `json:"opaque,omitempty"`
+
+	// Passphrase Passphrase required to read secret
+	Passphrase *string `json:"passphrase,omitempty"`
+
+	// Recipients Subjects of JWT tokens, who can read secret besides its creator
+	Recipients *[]string `json:"recipients,omitempty"`
+
+	// Ttl Desired lifetime of secret in seconds, clamped by server policy
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// PostRTokenMultipartBody defines parameters for PostRToken.
+type PostRTokenMultipartBody struct {
+	CallbackUrl *string    `json:"callbackUrl,omitempty"`
+	ContentType *string    `json:"contentType,omitempty"`
+	ExpireAt    *time.Time `json:"expireAt,omitempty"`
+
+	// File Secret body, its MIME type and file name are taken from upload
+	File       openapi_types.File `json:"file"`
+	Filename   *string            `json:"filename,omitempty"`
+	Groups     *[]string          `json:"groups,omitempty"`
+	MaxViews   *int64             `json:"maxViews,omitempty"`
+	NotBefore  *time.Time         `json:"notBefore,omitempty"`
+	Opaque     *bool              `json:"opaque,omitempty"`
+	Passphrase *string            `json:"passphrase,omitempty"`
+	Recipients *[]string          `json:"recipients,omitempty"`
+	Ttl        *int64             `json:"ttl,omitempty"`
+}
+
+// GetSTokenParams defines parameters for GetSToken.
+type GetSTokenParams struct {
+	// Burn Burn secret after reading, only one of concurrent readers receives it
+	Burn *bool `form:"burn,omitempty" json:"burn,omitempty"`
+
+	// XPassphrase Passphrase, if secret is protected by it
+	XPassphrase *string `json:"X-Passphrase,omitempty"`
+}
+
+// PostApiV1RequestJSONRequestBody defines body for PostApiV1Request for application/json ContentType.
+type PostApiV1RequestJSONRequestBody PostApiV1RequestJSONBody
+
 // PostApiV1SecretJSONRequestBody defines body for PostApiV1Secret for application/json ContentType.
 type PostApiV1SecretJSONRequestBody PostApiV1SecretJSONBody
+
+// PostApiV1SecretMultipartRequestBody defines body for PostApiV1Secret for multipart/form-data ContentType.
+type PostApiV1SecretMultipartRequestBody PostApiV1SecretMultipartBody
+
+// PatchApiV1SecretIdJSONRequestBody defines body for PatchApiV1SecretId for application/json ContentType.
+type PatchApiV1SecretIdJSONRequestBody PatchApiV1SecretIdJSONBody
+
+// PutApiV1SecretIdJSONRequestBody defines body for PutApiV1SecretId for application/json ContentType.
+type PutApiV1SecretIdJSONRequestBody PutApiV1SecretIdJSONBody
+
+// PostApiV1SplitJSONRequestBody defines body for PostApiV1Split for application/json ContentType.
+type PostApiV1SplitJSONRequestBody PostApiV1SplitJSONBody
+
+// PostApiV1SplitCombineJSONRequestBody defines body for PostApiV1SplitCombine for application/json ContentType.
+type PostApiV1SplitCombineJSONRequestBody PostApiV1SplitCombineJSONBody
+
+// PostRTokenJSONRequestBody defines body for PostRToken for application/json ContentType.
+type PostRTokenJSONRequestBody PostRTokenJSONBody
+
+// PostRTokenMultipartRequestBody defines body for PostRToken for multipart/form-data ContentType.
+type PostRTokenMultipartRequestBody PostRTokenMultipartBody
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/vodolaz095/purser/internal/transport/grpc/proto"
+	"github.com/vodolaz095/purser/pkg/zeroknowledge"
 )
 
 // tokenAuth реализует интерфейс https://pkg.go.dev/google.golang.org/grpc/credentials#PerRPCCredentials
@@ -36,7 +38,10 @@ func (t tokenAuth) RequireTransportSecurity() bool {
 }
 
 func main() {
-	var address, token, body, id, del string
+	var address, token, body, file, out, id, del, passphrase, recipients, groups, share, key string
+	var ttl time.Duration
+	var burn, zk bool
+	var views int64
 	var useTLS bool
 	var insecureSkipVerify bool
 	var res *proto.Secret
@@ -52,8 +57,19 @@ func main() {
 	flag.StringVar(&address, "addr", "127.0.0.1:3001", "purser gRPC connection string")
 	flag.StringVar(&token, "token", "", "jwt token to use")
 	flag.StringVar(&body, "body", "", "secret body, if left empty, STDIN is read")
+	flag.StringVar(&file, "file", "", "file to be uploaded as secret body instead of -body")
+	flag.StringVar(&out, "out", "", "file to save body of secret to, when reading it")
 	flag.StringVar(&id, "id", "", "id of secret, if left empty, new secret is created")
 	flag.StringVar(&del, "del", "", "id of secret to be deleted")
+	flag.StringVar(&share, "share", "", "token of share link to read secret without jwt token")
+	flag.DurationVar(&ttl, "ttl", 0, "secret lifetime, if left empty, server default is used")
+	flag.BoolVar(&burn, "burn", false, "burn secret after reading")
+	flag.StringVar(&passphrase, "passphrase", "", "passphrase to protect new secret or to read existing one")
+	flag.StringVar(&recipients, "to", "", "comma separated subjects, who can read new secret besides its creator")
+	flag.StringVar(&groups, "groups", "", "comma separated groups, whose members can read new secret")
+	flag.Int64Var(&views, "views", 0, "how many times secret can be read, if left empty, views are unlimited")
+	flag.BoolVar(&zk, "zk", false, "encrypt new secret body locally, so server stores only ciphertext")
+	flag.StringVar(&key, "key", "", "key to decrypt secret body encrypted with -zk, for -share it can be passed after # in token")
 	flag.BoolVar(&useTLS, "tls", false, "use tls")
 	flag.BoolVar(&insecureSkipVerify, "insecure", false, "allow invalid TLS certificates")
 	flag.Parse()
@@ -120,28 +136,126 @@ func main() {
 			Msgf("Секрет %s удалён", del)
 		return
 	}
+	if share != "" {
+		log.Debug().Msg("Загружаем секрет по ссылке")
+		shareToken, linkKey := zeroknowledge.ParseLink(share)
+		if key == "" {
+			key = linkKey
+		}
+		res, err = client.RedeemShareToken(mainCtx, &proto.ShareTokenRequest{
+			Token:      shareToken,
+			Burn:       burn,
+			Passphrase: passphrase,
+		})
+		if err != nil {
+			log.Error().Err(err).
+				Msgf("Ошибка получения секрета по ссылке : %s", err)
+			return
+		}
+		if !openBody(res, key) {
+			return
+		}
+		log.Info().Msgf("Секрет получен по ссылке: %s", res.String())
+		return
+	}
 	if id != "" {
 		log.Debug().Msgf("Загружаем секрет %s", del)
-		res, err = client.GetSecretByID(mainCtx, &proto.SecretByIDRequest{Id: id})
+		res, err = client.GetSecretByID(mainCtx, &proto.SecretByIDRequest{
+			Id:         id,
+			Burn:       burn,
+			Passphrase: passphrase,
+		})
 		if err != nil {
 			log.Error().Err(err).
 				Msgf("Ошибка получения секрета %s : %s", del, err)
 			return
 		}
+		if !openBody(res, key) {
+			return
+		}
+		if out != "" {
+			err = os.WriteFile(out, res.GetBody(), 0600)
+			if err != nil {
+				log.Error().Err(err).
+					Msgf("Ошибка записи секрета %s в файл %s : %s", id, out, err)
+				return
+			}
+			res.Body = nil
+			log.Info().Msgf("Секрет %s сохранён в файл %s", id, out)
+		}
 		log.Info().Msgf("Секрет %s получен: %s", id, res.String())
 		return
 	}
 
+	data := []byte(body)
+	var filename string
+	if file != "" {
+		data, err = os.ReadFile(file)
+		if err != nil {
+			log.Error().Err(err).
+				Msgf("Ошибка чтения файла %s : %s", file, err)
+			return
+		}
+		filename = filepath.Base(file)
+	}
+	if zk {
+		// тело шифруется на месте, имя файла на сервер не передаётся
+		data, key, err = zeroknowledge.Encrypt(data)
+		if err != nil {
+			log.Error().Err(err).
+				Msgf("Ошибка шифрования секрета : %s", err)
+			return
+		}
+		filename = ""
+	}
 	res, err = client.CreateSecret(mainCtx, &proto.NewSecretRequest{
-		Body: body,
+		Body:     data,
+		Filename: filename,
 		Meta: []*proto.Meta{
 			{Key: "User-Agent", Value: "purser-grpc-cli"},
 		},
+		Ttl:        int64(ttl.Seconds()),
+		MaxViews:   views,
+		Passphrase: passphrase,
+		Recipients: splitList(recipients),
+		Groups:     splitList(groups),
+		Opaque:     zk,
 	})
 	if err != nil {
 		log.Error().Err(err).
 			Msgf("Ошибка создания секрета %s", err)
 		return
 	}
+	if zk {
+		log.Info().Msgf("Секрет %s зашифрован ключом %s, токен ссылки вместе с ключом: %s",
+			res.GetId(), key, zeroknowledge.Link(res.GetShareToken(), key))
+	}
 	log.Info().Msgf("Секрет %s создан: %s", id, res.String())
 }
+
+// openBody расшифровывает тело секрета, зашифрованное клиентом, ключом key
+// и возвращает ложь, если расшифровать не удалось
+func openBody(res *proto.Secret, key string) bool {
+	if !res.GetOpaque() {
+		return true
+	}
+	if key == "" {
+		log.Error().Msg("Тело секрета зашифровано, нужен ключ -key")
+		return false
+	}
+	opened, err := zeroknowledge.Decrypt(res.GetBody(), key)
+	if err != nil {
+		log.Error().Err(err).
+			Msgf("Ошибка расшифровки секрета : %s", err)
+		return false
+	}
+	res.Body = opened
+	return true
+}
+
+// splitList разбивает список, разделённый запятыми, пропуская пустые элементы
+func splitList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ','
+	})
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,10 +17,14 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/vodolaz095/purser/api/openapi"
+	"github.com/vodolaz095/purser/pkg/zeroknowledge"
 )
 
 func main() {
-	var address, token, body, id, del string
+	var address, token, body, file, out, id, del, passphrase, recipients, groups, share, key string
+	var ttl time.Duration
+	var burn, zk bool
+	var views int64
 	var resp *http.Response
 	mainCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -30,8 +38,19 @@ func main() {
 	flag.StringVar(&address, "addr", "http://127.0.0.1:3000", "purser http connection string")
 	flag.StringVar(&token, "token", "", "jwt token to use")
 	flag.StringVar(&body, "body", "", "secret body")
+	flag.StringVar(&file, "file", "", "file to be uploaded as secret body instead of -body")
+	flag.StringVar(&out, "out", "", "file to save body of secret to, when reading it")
 	flag.StringVar(&id, "id", "", "id of secret, if left empty, new secret is created")
 	flag.StringVar(&del, "del", "", "id of secret to be deleted")
+	flag.StringVar(&share, "share", "", "token of share link to read secret without jwt token")
+	flag.DurationVar(&ttl, "ttl", 0, "secret lifetime, if left empty, server default is used")
+	flag.BoolVar(&burn, "burn", false, "burn secret after reading")
+	flag.StringVar(&passphrase, "passphrase", "", "passphrase to protect new secret or to read existing one")
+	flag.StringVar(&recipients, "to", "", "comma separated subjects, who can read new secret besides its creator")
+	flag.StringVar(&groups, "groups", "", "comma separated groups, whose members can read new secret")
+	flag.Int64Var(&views, "views", 0, "how many times secret can be read, if left empty, views are unlimited")
+	flag.BoolVar(&zk, "zk", false, "encrypt new secret body locally, so server stores only ciphertext")
+	flag.StringVar(&key, "key", "", "key to decrypt secret body encrypted with -zk, for -share it can be passed after # in link")
 	flag.Parse()
 
 	client, err := openapi.New(address, token)
@@ -39,10 +58,65 @@ func main() {
 		log.Fatal().Err(err).Msgf("Ошибка соединения с API через %s: %s", address, err)
 	}
 
-	if body != "" {
-		resp, err = client.PostApiV1Secret(mainCtx, openapi.PostApiV1SecretJSONRequestBody{
+	if body != "" || file != "" {
+		params := openapi.PostApiV1SecretJSONRequestBody{
 			Body: &body,
-		})
+		}
+		uploadParams := openapi.PostApiV1SecretParams{}
+		if ttl > 0 {
+			seconds := int64(ttl.Seconds())
+			params.Ttl = &seconds
+			uploadParams.Ttl = &seconds
+		}
+		if views > 0 {
+			params.MaxViews = &views
+			uploadParams.MaxViews = &views
+		}
+		if passphrase != "" {
+			params.Passphrase = &passphrase
+			uploadParams.XPassphrase = &passphrase
+		}
+		if recipients != "" {
+			list := splitList(recipients)
+			params.Recipients = &list
+			uploadParams.Recipients = &list
+		}
+		if groups != "" {
+			list := splitList(groups)
+			params.Groups = &list
+			uploadParams.Groups = &list
+		}
+		if zk {
+			// тело шифруется на месте и загружается как есть, имя файла и тип содержимого на сервер не передаются
+			data := []byte(body)
+			if file != "" {
+				data, err = os.ReadFile(file)
+				if err != nil {
+					log.Fatal().Err(err).Msgf("Ошибка чтения файла %s: %s", file, err)
+				}
+			}
+			sealed, sealedKey, sErr := zeroknowledge.Encrypt(data)
+			if sErr != nil {
+				log.Fatal().Err(sErr).Msgf("Ошибка шифрования секрета: %s", sErr)
+			}
+			key = sealedKey
+			opaque := true
+			uploadParams.Opaque = &opaque
+			resp, err = client.PostApiV1SecretWithBody(mainCtx, &uploadParams, "application/octet-stream", bytes.NewReader(sealed))
+		} else if file != "" {
+			// файл загружается как есть, тип содержимого определяется по его началу
+			data, rErr := os.ReadFile(file)
+			if rErr != nil {
+				log.Fatal().Err(rErr).Msgf("Ошибка чтения файла %s: %s", file, rErr)
+			}
+			filename := filepath.Base(file)
+			detected := http.DetectContentType(data)
+			uploadParams.Filename = &filename
+			uploadParams.ContentType = &detected
+			resp, err = client.PostApiV1SecretWithBody(mainCtx, &uploadParams, "application/octet-stream", bytes.NewReader(data))
+		} else {
+			resp, err = client.PostApiV1Secret(mainCtx, &openapi.PostApiV1SecretParams{}, params)
+		}
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Ошибка создания секрета: %s", err)
 		}
@@ -51,11 +125,87 @@ func main() {
 		}
 
 		log.Info().Msgf("%v", resp.Header)
+		created, pErr := openapi.ParsePostApiV1SecretResponse(resp)
+		if pErr != nil {
+			log.Fatal().Err(pErr).Msgf("Ошибка разбора ответа: %s", pErr)
+		}
 		id = strings.TrimPrefix(resp.Header.Get("Location"), "/api/v1/secret/")
-		log.Info().Msgf("Секрет %s создан", id)
+		if created.JSON201 != nil && created.JSON201.ShareUrl != nil && zk {
+			log.Info().Msgf("Секрет %s зашифрован ключом %s, ссылка на него вместе с ключом: %s", id, key,
+				zeroknowledge.Link(address+*created.JSON201.ShareUrl, key))
+		} else if created.JSON201 != nil && created.JSON201.ShareUrl != nil {
+			log.Info().Msgf("Секрет %s создан, ссылка на него: %s%s", id, address, *created.JSON201.ShareUrl)
+		} else {
+			log.Info().Msgf("Секрет %s создан", id)
+		}
 	}
-	if id != "" {
-		resp, err = client.GetApiV1SecretId(mainCtx, id)
+	if share != "" {
+		shareParams := openapi.GetSTokenParams{Burn: &burn}
+		if passphrase != "" {
+			shareParams.XPassphrase = &passphrase
+		}
+		shareToken, linkKey := zeroknowledge.ParseLink(share)
+		if key == "" {
+			key = linkKey
+		}
+		resp, err = client.GetSToken(mainCtx, shareToken, &shareParams)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Ошибка получения секрета по ссылке: %s", err)
+		}
+		shared, pErr := openapi.ParseGetSTokenResponse(resp)
+		if pErr != nil {
+			log.Fatal().Err(pErr).Msgf("Ошибка получения секрета по ссылке: %s", pErr)
+		}
+		if shared.StatusCode() != http.StatusOK {
+			log.Fatal().Msgf("Неожиданный статус ответа %s", shared.Status())
+		}
+		if shared.JSON200.Opaque != nil && *shared.JSON200.Opaque {
+			opened := openBody(*shared.JSON200.Body, shared.JSON200.BodyEncoding, key)
+			shared.JSON200.Body = &opened
+		}
+		log.Info().
+			Str("body", *shared.JSON200.Body).
+			Str("content_type", *shared.JSON200.ContentType).
+			Int64("views_left", *shared.JSON200.ViewsLeft).
+			Msg("Секрет получен по ссылке")
+	}
+	if id != "" && out != "" {
+		downloadParams := openapi.GetApiV1SecretIdDownloadParams{Burn: &burn}
+		if passphrase != "" {
+			downloadParams.XPassphrase = &passphrase
+		}
+		resp, err = client.GetApiV1SecretIdDownload(mainCtx, id, &downloadParams)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Ошибка получения секрета %s: %s", id, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Fatal().Msgf("Неожиданный статус ответа %s", resp.Status)
+		}
+		data, rErr := io.ReadAll(resp.Body)
+		if rErr != nil {
+			log.Fatal().Err(rErr).Msgf("Ошибка получения секрета %s: %s", id, rErr)
+		}
+		if key != "" {
+			data, rErr = zeroknowledge.Decrypt(data, key)
+			if rErr != nil {
+				log.Fatal().Err(rErr).Msgf("Ошибка расшифровки секрета %s: %s", id, rErr)
+			}
+		}
+		err = os.WriteFile(out, data, 0600)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Ошибка записи секрета %s в файл %s: %s", id, out, err)
+		}
+		log.Info().
+			Str("content_type", resp.Header.Get("Content-Type")).
+			Str("content_disposition", resp.Header.Get("Content-Disposition")).
+			Msgf("Секрет %s сохранён в файл %s", id, out)
+	} else if id != "" {
+		getParams := openapi.GetApiV1SecretIdParams{Burn: &burn}
+		if passphrase != "" {
+			getParams.XPassphrase = &passphrase
+		}
+		resp, err = client.GetApiV1SecretId(mainCtx, id, &getParams)
 		if err != nil {
 			log.Fatal().Err(err).Msgf("Ошибка получения секрета %s: %s", id, err)
 		}
@@ -66,11 +216,17 @@ func main() {
 		if secret.StatusCode() != http.StatusOK {
 			log.Fatal().Msgf("Неожиданный статус ответа %s", secret.Status())
 		}
+		if secret.JSON200.Opaque != nil && *secret.JSON200.Opaque {
+			opened := openBody(*secret.JSON200.Body, secret.JSON200.BodyEncoding, key)
+			secret.JSON200.Body = &opened
+		}
 		log.Info().
 			Str("body", *secret.JSON200.Body).
+			Str("content_type", *secret.JSON200.ContentType).
 			Str("created_at", *secret.JSON200.CreatedAt).
 			Str("expires_at", *secret.JSON200.ExpireAt).
 			Str("expires_at", fmt.Sprint(*secret.JSON200.Fields)).
+			Int64("views_left", *secret.JSON200.ViewsLeft).
 			Msgf("Секрет %s получен", id)
 	}
 	if del != "" {
@@ -84,3 +240,31 @@ func main() {
 		log.Info().Msgf("Секрет %s удалён", id)
 	}
 }
+
+// splitList разбивает список, разделённый запятыми, пропуская пустые элементы
+func splitList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ','
+	})
+}
+
+// openBody расшифровывает тело секрета, зашифрованное клиентом, ключом key. Шифротекст двоичный,
+// поэтому в JSON ответе он закодирован в base64
+func openBody(body string, encoding *string, key string) string {
+	if key == "" {
+		log.Fatal().Msg("Тело секрета зашифровано, нужен ключ -key")
+	}
+	sealed := []byte(body)
+	if encoding != nil && *encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Ошибка разбора тела секрета: %s", err)
+		}
+		sealed = decoded
+	}
+	opened, err := zeroknowledge.Decrypt(sealed, key)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Ошибка расшифровки секрета: %s", err)
+	}
+	return string(opened)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Hostname задает имя сервера, на котором работает приложение
@@ -43,6 +44,96 @@ var Driver = "memory"
 // DatabaseConnectionString - задаёт строку соединения с базой данных
 var DatabaseConnectionString string
 
+// SecretTTL задаёт срок жизни секрета, если создатель его не указал
+var SecretTTL = 3 * time.Hour
+
+// SecretMinTTL задаёт минимальный срок жизни секрета, который может выбрать создатель
+var SecretMinTTL = time.Minute
+
+// SecretMaxTTL задаёт максимальный срок жизни секрета, который может выбрать создатель
+var SecretMaxTTL = 7 * 24 * time.Hour
+
+// MaxPassphraseAttempts задаёт, после скольких неверных попыток ввода кодовой фразы секрет уничтожается
+var MaxPassphraseAttempts int64 = 3
+
+// KeepVersions задаёт, сколько прежних версий секрета хранится в истории при его изменении
+var KeepVersions int64 = 5
+
+// KeepAccesses задаёт, сколько последних прочтений секрета хранится в его журнале прочтений
+var KeepAccesses int64 = 100
+
+// MaxBodyBytes задаёт максимальный размер тела секрета в байтах, отрицательное значение снимает ограничение
+var MaxBodyBytes int64 = 1 << 20
+
+// MaxMetaKeys задаёт максимальное число полей метаданных секрета, отрицательное значение снимает ограничение
+var MaxMetaKeys int64 = 32
+
+// MaxMetaKeyLength задаёт максимальную длину названия поля метаданных в байтах, отрицательное значение снимает ограничение
+var MaxMetaKeyLength int64 = 128
+
+// MaxMetaValueLength задаёт максимальную длину значения поля метаданных в байтах, отрицательное значение снимает ограничение
+var MaxMetaValueLength int64 = 4096
+
+// MaxSecretsPerSubject задаёт, сколько действующих секретов может быть у одного создателя, отрицательное значение снимает ограничение
+var MaxSecretsPerSubject int64 = 1000
+
+// ContentPolicy задаёт правила политики содержимого секретов и действия при их нарушении,
+// например, "private_key=reject,card_number=flag,client=reject". Если не задана, секреты не проверяются
+var ContentPolicy string
+
+// DeniedClients задаёт регулярное выражение для значений заголовка User-Agent клиентов,
+// которым правило client политики содержимого не позволяет создавать секреты
+var DeniedClients string
+
+// AllowOpaqueSecrets разрешает секреты, зашифрованные клиентом, тела которых сервер не может проверить политикой содержимого
+var AllowOpaqueSecrets = true
+
+// EnrichmentRulesFile задаёт путь к JSON файлу с правилами обогащения метаданных секретов, если не задан,
+// используются правила по умолчанию. Файл перечитывается по сигналу SIGHUP
+var EnrichmentRulesFile string
+
+// RedactionPolicy задаёт, в каком виде поля метаданных секретов попадают в трассировку и журналы,
+// например, "User-Agent=allow,Subject=hash,*=mask". Если не задана, записываются только поля, которые заполняет сам сервис
+var RedactionPolicy string
+
+// AdminGroup задаёт группу из JWT токена, субъектам из которой доступен журнал аудита
+var AdminGroup = "admin"
+
+// WebhookSecret задаёт ключ, которым подписываются уведомления о событиях секретов, если не задан, уведомления отключены
+var WebhookSecret string
+
+// WebhookMaxAttempts задаёт, сколько раз пытаться доставить уведомление, прежде чем сохранить его как недоставленное
+var WebhookMaxAttempts int64 = 5
+
+// WebhookBackoff задаёт паузу перед второй попыткой доставки уведомления, дальше пауза удваивается
+var WebhookBackoff = time.Second
+
+// WebhookWorkers задаёт, сколько уведомлений доставляется одновременно
+var WebhookWorkers int64 = 8
+
+// SubjectWebhooks задаёт адреса для уведомлений о секретах создателей, не указавших адрес при создании секрета,
+// например, "alice=https://example.org/hook,bob=https://example.com/purser"
+var SubjectWebhooks string
+
+// WebhookAllowedNetworks задаёт сети с не публичными адресами, в которые всё же можно доставлять уведомления,
+// например, "10.0.0.0/8,fd00::/8". Если не задано, уведомления доставляются только на публичные адреса
+var WebhookAllowedNetworks string
+
+// TombstoneRetention задаёт, сколько хранится запись о том, что секрет прочитан, удалён или устарел,
+// чтобы отличать его от несуществующего. Нулевое значение отключает записи
+var TombstoneRetention = 7 * 24 * time.Hour
+
+// EncryptionKeyFile задаёт путь к файлу с мастер-ключом для шифрования секретов в хранилище,
+// если не задан, секреты хранятся открытыми
+var EncryptionKeyFile string
+
+// RewrapBatchSize задаёт, сколько секретов за раз перешифровывается текущим мастер-ключом при запуске приложения,
+// 0 отключает перешифровку
+var RewrapBatchSize int64 = 100
+
+// RewrapCursor задаёт курсор, с которого продолжается прерванная перешифровка секретов
+var RewrapCursor string
+
 // LogOutput задаёт куда выводить логи
 var LogOutput = string(LogOutputConsole)
 
@@ -97,6 +188,37 @@ func init() {
 	loadFromEnvironment(&Driver, "DRIVER")
 	loadFromEnvironment(&DatabaseConnectionString, "DB_URL")
 
+	loadDurationFromEnvironment(&SecretTTL, "SECRET_TTL")
+	loadDurationFromEnvironment(&SecretMinTTL, "SECRET_MIN_TTL")
+	loadDurationFromEnvironment(&SecretMaxTTL, "SECRET_MAX_TTL")
+	if SecretMinTTL > SecretMaxTTL {
+		log.Fatalf("SECRET_MIN_TTL=%s is greater than SECRET_MAX_TTL=%s", SecretMinTTL, SecretMaxTTL)
+	}
+	loadInt64FromEnvironment(&MaxPassphraseAttempts, "MAX_PASSPHRASE_ATTEMPTS")
+	loadInt64FromEnvironment(&KeepVersions, "KEEP_VERSIONS")
+	loadInt64FromEnvironment(&KeepAccesses, "KEEP_ACCESSES")
+	loadInt64FromEnvironment(&MaxBodyBytes, "MAX_BODY_BYTES")
+	loadInt64FromEnvironment(&MaxMetaKeys, "MAX_META_KEYS")
+	loadInt64FromEnvironment(&MaxMetaKeyLength, "MAX_META_KEY_LENGTH")
+	loadInt64FromEnvironment(&MaxMetaValueLength, "MAX_META_VALUE_LENGTH")
+	loadInt64FromEnvironment(&MaxSecretsPerSubject, "MAX_SECRETS_PER_SUBJECT")
+	loadFromEnvironment(&ContentPolicy, "CONTENT_POLICY")
+	loadFromEnvironment(&DeniedClients, "DENIED_CLIENTS")
+	loadBoolFromEnvironment(&AllowOpaqueSecrets, "ALLOW_OPAQUE_SECRETS")
+	loadFromEnvironment(&EnrichmentRulesFile, "ENRICHMENT_RULES_FILE")
+	loadFromEnvironment(&RedactionPolicy, "REDACTION_POLICY")
+	loadFromEnvironment(&AdminGroup, "ADMIN_GROUP")
+	loadFromEnvironment(&WebhookSecret, "WEBHOOK_SECRET")
+	loadInt64FromEnvironment(&WebhookMaxAttempts, "WEBHOOK_MAX_ATTEMPTS")
+	loadDurationFromEnvironment(&WebhookBackoff, "WEBHOOK_BACKOFF")
+	loadInt64FromEnvironment(&WebhookWorkers, "WEBHOOK_WORKERS")
+	loadFromEnvironment(&SubjectWebhooks, "SUBJECT_WEBHOOKS")
+	loadFromEnvironment(&WebhookAllowedNetworks, "WEBHOOK_ALLOWED_NETWORKS")
+	loadDurationFromEnvironment(&TombstoneRetention, "TOMBSTONE_RETENTION")
+	loadFromEnvironment(&EncryptionKeyFile, "ENCRYPTION_KEY_FILE")
+	loadInt64FromEnvironment(&RewrapBatchSize, "REWRAP_BATCH_SIZE")
+	loadFromEnvironment(&RewrapCursor, "REWRAP_CURSOR")
+
 	loadFromEnvironment(&LogOutput, "LOG_OUTPUT")
 	loadFromEnvironment(&LogLevel, "LOG_LEVEL")
 
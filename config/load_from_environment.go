@@ -1,9 +1,44 @@
 package config
 
-import "os"
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
 
 func loadFromEnvironment(v *string, key string) {
 	if fromEnv := os.Getenv(key); fromEnv != "" {
 		*v = fromEnv
 	}
 }
+
+func loadDurationFromEnvironment(v *time.Duration, key string) {
+	if fromEnv := os.Getenv(key); fromEnv != "" {
+		parsed, err := time.ParseDuration(fromEnv)
+		if err != nil {
+			log.Fatalf("error parsing %s=%s as duration: %s", key, fromEnv, err)
+		}
+		*v = parsed
+	}
+}
+
+func loadInt64FromEnvironment(v *int64, key string) {
+	if fromEnv := os.Getenv(key); fromEnv != "" {
+		parsed, err := strconv.ParseInt(fromEnv, 10, 64)
+		if err != nil {
+			log.Fatalf("error parsing %s=%s as integer: %s", key, fromEnv, err)
+		}
+		*v = parsed
+	}
+}
+
+func loadBoolFromEnvironment(v *bool, key string) {
+	if fromEnv := os.Getenv(key); fromEnv != "" {
+		parsed, err := strconv.ParseBool(fromEnv)
+		if err != nil {
+			log.Fatalf("error parsing %s=%s as boolean: %s", key, fromEnv, err)
+		}
+		*v = parsed
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// AuditFilter задаёт фильтры и страницу для AuditRepo.Query.
+// События упорядочены по времени, а при его совпадении - по идентификатору
+type AuditFilter struct {
+	// SecretID - секрет, с которым случились события, пустой - любой
+	SecretID string
+	// Subject - субъект, действия которого записаны, пустой - любой
+	Subject string
+	// Since - события случились не раньше этого момента
+	Since time.Time
+	// Until - события случились раньше этого момента
+	Until time.Time
+	// Cursor - курсор, полученный с предыдущей страницей, пустой - с начала. Формат курсора зависит от репозитория,
+	// испорченный курсор приводит к ошибке model.ErrInvalidCursor
+	Cursor string
+	// Limit - сколько событий на странице
+	Limit int64
+}
+
+// Match проверяет, что событие подходит под фильтры, не учитывая курсор
+func (f AuditFilter) Match(event model.AuditEvent) bool {
+	if f.SecretID != "" && event.SecretID != f.SecretID {
+		return false
+	}
+	if f.Subject != "" && event.Subject != f.Subject {
+		return false
+	}
+	if !f.Since.IsZero() && event.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.CreatedAt.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// EncodeAuditCursor делает курсор, указывающий на событие, после которого начинается следующая страница.
+// Разбирается он, как и курсор секретов, DecodeCursor
+func EncodeAuditCursor(event model.AuditEvent) string {
+	return encodeCursor(event.CreatedAt, event.ID)
+}
+
+// IsEventAfterCursor проверяет, что событие находится в журнале после события с временем createdAt и идентификатором id
+func IsEventAfterCursor(event model.AuditEvent, createdAt time.Time, id string) bool {
+	return isAfter(event.CreatedAt, event.ID, createdAt, id)
+}
+
+// AuditRepo задаёт интерфейс, которому должен соответствовать репозиторий журнала аудита
+type AuditRepo interface {
+	BaseRepo
+	// Record сохраняет событие, идентификатор назначается репозиторием
+	Record(ctx context.Context, event model.AuditEvent) (model.AuditEvent, error)
+	// Query постранично возвращает события, подходящие под фильтры. Пустой next означает, что страниц больше нет
+	Query(ctx context.Context, filter AuditFilter) (events []model.AuditEvent, next string, err error)
+}
@@ -0,0 +1,310 @@
+package encrypted
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/envelope"
+)
+
+// Repository реализует интерфейс SecretRepo, шифруя секреты перед сохранением в Repo и расшифровывая после чтения
+type Repository struct {
+	// Repo - хранилище, в котором лежат зашифрованные секреты
+	Repo repository.SecretRepo
+	// KMS - система управления ключами, которой оборачиваются ключи данных
+	KMS envelope.KMS
+}
+
+// Ping проверяет соединение с базой данных
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.Repo.Ping(ctx)
+}
+
+// Init настраивает соединение с базой данных
+func (r *Repository) Init(ctx context.Context) error {
+	return r.Repo.Init(ctx)
+}
+
+// Close закрывает соединение с базой данных
+func (r *Repository) Close(ctx context.Context) error {
+	return r.Repo.Close(ctx)
+}
+
+// Create шифрует новый model.Secret и сохраняет его
+func (r *Repository) Create(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	sealed, err := r.seal(ctx, secret)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	created, err := r.Repo.Create(ctx, sealed)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret.ID = created.ID
+	secret.KeyID = created.KeyID
+	secret.WrappedKey = created.WrappedKey
+	return secret, nil
+}
+
+// CreateWithinQuota шифрует новый model.Secret и сохраняет его, если у создателя меньше maxSecrets действующих секретов
+func (r *Repository) CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	sealed, err := r.seal(ctx, secret)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	created, err := r.Repo.CreateWithinQuota(ctx, sealed, maxSecrets)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret.ID = created.ID
+	secret.KeyID = created.KeyID
+	secret.WrappedKey = created.WrappedKey
+	return secret, nil
+}
+
+// FindByID ищет model.Secret по идентификатору, засчитывает его прочтение и расшифровывает
+func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, error) {
+	secret, err := r.Repo.FindByID(ctx, id)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return r.open(ctx, secret)
+}
+
+// Peek ищет model.Secret по идентификатору, не засчитывая его прочтение, и расшифровывает
+func (r *Repository) Peek(ctx context.Context, id string) (model.Secret, error) {
+	secret, err := r.Repo.Peek(ctx, id)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return r.open(ctx, secret)
+}
+
+// FindIDByShareToken возвращает идентификатор секрета по хэшу токена ссылки, хэш не шифруется
+func (r *Repository) FindIDByShareToken(ctx context.Context, tokenHash string) (string, error) {
+	return r.Repo.FindIDByShareToken(ctx, tokenHash)
+}
+
+// RegisterFailedAttempt засчитывает неверную попытку ввода кодовой фразы
+func (r *Repository) RegisterFailedAttempt(ctx context.Context, id string, maxAttempts int64) (int64, error) {
+	return r.Repo.RegisterFailedAttempt(ctx, id, maxAttempts)
+}
+
+// FindAndDeleteByID ищет model.Secret по идентификатору, удаляет его и расшифровывает
+func (r *Repository) FindAndDeleteByID(ctx context.Context, id string) (model.Secret, error) {
+	secret, err := r.Repo.FindAndDeleteByID(ctx, id)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return r.open(ctx, secret)
+}
+
+// FindByRecipient возвращает расшифрованные секреты, адресованные субъекту или его группам
+func (r *Repository) FindByRecipient(ctx context.Context, subject string, groups []string) ([]model.Secret, error) {
+	secrets, err := r.Repo.FindByRecipient(ctx, subject, groups)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		secrets[i], err = r.open(ctx, secrets[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secrets, nil
+}
+
+// List возвращает страницу секретов с расшифрованными метаданными
+func (r *Repository) List(ctx context.Context, filter repository.ListFilter) ([]model.Secret, string, error) {
+	secrets, next, err := r.Repo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range secrets {
+		secrets[i], err = r.open(ctx, secrets[i])
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return secrets, next, nil
+}
+
+// CountByOwner возвращает число не устаревших секретов создателя owner
+func (r *Repository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	return r.Repo.CountByOwner(ctx, owner)
+}
+
+// Update шифрует новое тело и метаданные секрета новым ключом данных и сохраняет их
+func (r *Repository) Update(ctx context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error) {
+	sealed, err := r.seal(ctx, secret)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	updated, err := r.Repo.Update(ctx, sealed, version, keepVersions)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return r.open(ctx, updated)
+}
+
+// ListVersions возвращает прежние версии секрета с расшифрованными метаданными
+func (r *Repository) ListVersions(ctx context.Context, id string) ([]model.SecretVersion, error) {
+	versions, err := r.Repo.ListVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		versions[i], err = r.openVersion(ctx, versions[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return versions, nil
+}
+
+// GetVersion возвращает расшифрованную прежнюю версию секрета
+func (r *Repository) GetVersion(ctx context.Context, id string, version int64) (model.SecretVersion, error) {
+	archived, err := r.Repo.GetVersion(ctx, id, version)
+	if err != nil {
+		return model.SecretVersion{}, err
+	}
+	return r.openVersion(ctx, archived)
+}
+
+// RecordAccess добавляет прочтение в журнал прочтений секрета, журнал не шифруется
+func (r *Repository) RecordAccess(ctx context.Context, id string, access model.SecretAccess, keepAccesses int64) error {
+	return r.Repo.RecordAccess(ctx, id, access, keepAccesses)
+}
+
+// ListAccesses возвращает журнал прочтений секрета
+func (r *Repository) ListAccesses(ctx context.Context, id string) ([]model.SecretAccess, error) {
+	return r.Repo.ListAccesses(ctx, id)
+}
+
+// DeleteByID удаляет секрет по идентификатору
+func (r *Repository) DeleteByID(ctx context.Context, id string) error {
+	return r.Repo.DeleteByID(ctx, id)
+}
+
+// Prune удаляет старые секреты
+func (r *Repository) Prune(ctx context.Context) ([]string, error) {
+	return r.Repo.Prune(ctx)
+}
+
+// ListWrappedKeys возвращает обёрнутые ключи данных секретов, не расшифровывая их
+func (r *Repository) ListWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.Secret, string, error) {
+	return r.Repo.ListWrappedKeys(ctx, exceptKeyID, cursor, limit)
+}
+
+// UpdateWrappedKey заменяет обёрнутый ключ данных секрета
+func (r *Repository) UpdateWrappedKey(ctx context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error {
+	return r.Repo.UpdateWrappedKey(ctx, id, oldKeyID, newKeyID, wrapped)
+}
+
+// ListVersionWrappedKeys возвращает обёрнутые ключи данных прежних версий секретов, не расшифровывая их
+func (r *Repository) ListVersionWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.SecretVersion, string, error) {
+	return r.Repo.ListVersionWrappedKeys(ctx, exceptKeyID, cursor, limit)
+}
+
+// UpdateVersionWrappedKey заменяет обёрнутый ключ данных прежней версии секрета
+func (r *Repository) UpdateVersionWrappedKey(ctx context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error {
+	return r.Repo.UpdateVersionWrappedKey(ctx, id, version, oldKeyID, newKeyID, wrapped)
+}
+
+// seal шифрует тело и значения метаданных секрета новым ключом данных
+func (r *Repository) seal(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	dataKey, err := envelope.NewDataKey()
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret.KeyID, secret.WrappedKey, err = r.KMS.Wrap(ctx, dataKey)
+	if err != nil {
+		return model.Secret{}, fmt.Errorf("error wrapping data key: %w", err)
+	}
+	// зашифрованное тело хранится в base64, как и до появления двоичных секретов
+	sealedBody, err := sealField(dataKey, "body", string(secret.Body))
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret.Body = []byte(sealedBody)
+	meta := make(map[string]string, len(secret.Meta))
+	for k := range secret.Meta {
+		meta[k], err = sealField(dataKey, "meta:"+k, secret.Meta[k])
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	secret.Meta = meta
+	return secret, nil
+}
+
+// open расшифровывает тело и значения метаданных секрета, открытые секреты возвращаются как есть
+func (r *Repository) open(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	if !secret.Encrypted() {
+		return secret, nil
+	}
+	dataKey, err := r.KMS.Unwrap(ctx, secret.KeyID, secret.WrappedKey)
+	if err != nil {
+		return model.Secret{}, fmt.Errorf("error unwrapping data key of secret %s: %w", secret.ID, err)
+	}
+	// в списках секреты возвращаются без тел
+	if len(secret.Body) > 0 {
+		body, oErr := openField(dataKey, "body", string(secret.Body))
+		if oErr != nil {
+			return model.Secret{}, fmt.Errorf("error decrypting secret %s: %w", secret.ID, oErr)
+		}
+		secret.Body = []byte(body)
+	}
+	meta := make(map[string]string, len(secret.Meta))
+	for k := range secret.Meta {
+		meta[k], err = openField(dataKey, "meta:"+k, secret.Meta[k])
+		if err != nil {
+			return model.Secret{}, fmt.Errorf("error decrypting secret %s: %w", secret.ID, err)
+		}
+	}
+	secret.Meta = meta
+	return secret, nil
+}
+
+// openVersion расшифровывает прежнюю версию секрета её собственным ключом данных
+func (r *Repository) openVersion(ctx context.Context, version model.SecretVersion) (model.SecretVersion, error) {
+	opened, err := r.open(ctx, model.Secret{
+		ID:         version.SecretID,
+		Body:       version.Body,
+		Meta:       version.Meta,
+		KeyID:      version.KeyID,
+		WrappedKey: version.WrappedKey,
+	})
+	if err != nil {
+		return model.SecretVersion{}, err
+	}
+	version.Body = opened.Body
+	version.Meta = opened.Meta
+	return version, nil
+}
+
+// sealField шифрует значение поля, название поля используется как дополнительные данные,
+// чтобы зашифрованные значения нельзя было переставить между полями
+func sealField(dataKey []byte, name, value string) (string, error) {
+	sealed, err := envelope.Seal(dataKey, []byte(value), []byte(name))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// openField расшифровывает значение поля, зашифрованное sealField
+func openField(dataKey []byte, name, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	opened, err := envelope.Open(dataKey, sealed, []byte(name))
+	if err != nil {
+		return "", err
+	}
+	return string(opened), nil
+}
@@ -0,0 +1,93 @@
+package encrypted
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/internal/repotest"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/envelope"
+)
+
+func newKMS(t *testing.T) *envelope.LocalKMS {
+	masterKey, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	kms, err := envelope.NewLocalKMS(masterKey)
+	if err != nil {
+		t.Fatalf("error creating kms: %s", err)
+	}
+	return kms
+}
+
+func TestRepo(t *testing.T) {
+	repotest.ValidateRepo(t, "encrypted", &Repository{
+		Repo: &memory.Repository{},
+		KMS:  newKMS(t),
+	})
+}
+
+func TestRepository_StoresCiphertext(t *testing.T) {
+	ctx := context.TODO()
+	inner := &memory.Repository{}
+	kms := newKMS(t)
+	repo := Repository{Repo: inner, KMS: kms}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	now := time.Now()
+	secret, err := repo.Create(ctx, model.Secret{
+		Body:      []byte("top secret body"),
+		Meta:      map[string]string{"note": "top secret meta"},
+		CreatedAt: now,
+		ExpireAt:  now.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	assert.Equal(t, "top secret body", string(secret.Body))
+	assert.Equal(t, kms.KeyID(), secret.KeyID)
+
+	stored, err := inner.Peek(ctx, secret.ID)
+	if err != nil {
+		t.Fatalf("error peeking stored secret: %s", err)
+	}
+	assert.True(t, stored.Encrypted(), "secret is not marked as encrypted")
+	assert.Equal(t, kms.KeyID(), stored.KeyID)
+	assert.NotContains(t, string(stored.Body), "top secret")
+	assert.NotContains(t, stored.Meta["note"], "top secret")
+
+	found, err := repo.FindByID(ctx, secret.ID)
+	if err != nil {
+		t.Fatalf("error finding secret: %s", err)
+	}
+	assert.Equal(t, "top secret body", string(found.Body))
+	assert.Equal(t, "top secret meta", found.Meta["note"])
+
+	// секреты, сохранённые до включения шифрования, читаются как есть
+	err = inner.PutSecret(ctx, model.Secret{
+		ID:        "legacy",
+		Body:      []byte("plaintext body"),
+		CreatedAt: now,
+		ExpireAt:  now.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error putting legacy secret: %s", err)
+	}
+	legacy, err := repo.FindByID(ctx, "legacy")
+	if err != nil {
+		t.Fatalf("error finding legacy secret: %s", err)
+	}
+	assert.Equal(t, "plaintext body", string(legacy.Body))
+
+	// секрет, зашифрованный неизвестным мастер-ключом, не расшифровывается
+	otherRepo := Repository{Repo: inner, KMS: newKMS(t)}
+	_, err = otherRepo.Peek(ctx, secret.ID)
+	assert.Error(t, err, "secret decrypted with wrong master key")
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// ListFilter задаёт фильтры и страницу для SecretRepo.List.
+// Секреты упорядочены по времени создания, а при его совпадении - по идентификатору
+type ListFilter struct {
+	// Owner - создатель секретов, пустой - любой
+	Owner string
+	// CreatedAfter - секреты созданы не раньше этого момента
+	CreatedAfter time.Time
+	// CreatedBefore - секреты созданы раньше этого момента
+	CreatedBefore time.Time
+	// MetaKey - у секретов есть поле метаданных с таким названием
+	MetaKey string
+	// Cursor - курсор, полученный с предыдущей страницей, пустой - с начала
+	Cursor string
+	// Limit - сколько секретов на странице
+	Limit int64
+}
+
+// Match проверяет, что секрет подходит под фильтры, не учитывая курсор
+func (f ListFilter) Match(secret model.Secret) bool {
+	if f.Owner != "" && secret.Owner != f.Owner {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && secret.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !secret.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.MetaKey != "" {
+		_, found := secret.Meta[f.MetaKey]
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeCursor делает курсор, указывающий на секрет, после которого начинается следующая страница
+func EncodeCursor(secret model.Secret) string {
+	return encodeCursor(secret.CreatedAt, secret.ID)
+}
+
+// encodeCursor делает курсор из времени создания и идентификатора
+func encodeCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id),
+	)
+}
+
+// DecodeCursor разбирает курсор, сделанный EncodeCursor, пустой курсор разбирается в нулевые значения
+func DecodeCursor(cursor string) (createdAt time.Time, id string, err error) {
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %s", model.ErrInvalidCursor, err)
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", model.ErrInvalidCursor
+	}
+	createdAt, err = time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %s", model.ErrInvalidCursor, err)
+	}
+	return createdAt, parts[1], nil
+}
+
+// IsAfterCursor проверяет, что секрет находится в списке после секрета с временем создания createdAt и идентификатором id
+func IsAfterCursor(secret model.Secret, createdAt time.Time, id string) bool {
+	return isAfter(secret.CreatedAt, secret.ID, createdAt, id)
+}
+
+// isAfter сравнивает пары из времени создания и идентификатора
+func isAfter(itemCreatedAt time.Time, itemID string, createdAt time.Time, id string) bool {
+	if itemCreatedAt.Equal(createdAt) {
+		return itemID > id
+	}
+	return itemCreatedAt.After(createdAt)
+}
+
+// EncodeVersionCursor делает курсор, указывающий на прежнюю версию секрета, после которой начинается следующая страница
+func EncodeVersionCursor(version model.SecretVersion) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(version.SecretID + "|" + strconv.FormatInt(version.Version, 10)),
+	)
+}
+
+// DecodeVersionCursor разбирает курсор, сделанный EncodeVersionCursor, пустой курсор разбирается в нулевые значения
+func DecodeVersionCursor(cursor string) (id string, version int64, err error) {
+	if cursor == "" {
+		return "", 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", model.ErrInvalidCursor, err)
+	}
+	separator := strings.LastIndex(string(raw), "|")
+	if separator < 1 {
+		return "", 0, model.ErrInvalidCursor
+	}
+	version, err = strconv.ParseInt(string(raw[separator+1:]), 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", model.ErrInvalidCursor, err)
+	}
+	return string(raw[:separator]), version, nil
+}
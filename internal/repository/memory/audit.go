@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// Record сохраняет событие журнала аудита
+func (r *Repository) Record(_ context.Context, event model.AuditEvent) (model.AuditEvent, error) {
+	r.Lock()
+	defer r.Unlock()
+	event.ID = misc.UUID()
+	r.audit = append(r.audit, event)
+	return event, nil
+}
+
+// Query постранично возвращает события журнала аудита, подходящие под фильтры
+func (r *Repository) Query(_ context.Context, filter repository.AuditFilter) ([]model.AuditEvent, string, error) {
+	createdAt, id, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	ret := make([]model.AuditEvent, 0)
+	for i := range r.audit {
+		if !filter.Match(r.audit[i]) {
+			continue
+		}
+		if filter.Cursor != "" && !repository.IsEventAfterCursor(r.audit[i], createdAt, id) {
+			continue
+		}
+		ret = append(ret, r.audit[i])
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return repository.IsEventAfterCursor(ret[j], ret[i].CreatedAt, ret[i].ID)
+	})
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeAuditCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
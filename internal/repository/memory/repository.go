@@ -3,23 +3,46 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/model"
 	"github.com/vodolaz095/purser/pkg/misc"
 )
 
-// Repository реализует интерфейс SecretRepo и
+// Repository реализует интерфейсы SecretRepo, AuditRepo, WebhookRepo, TombstoneRepo и RequestRepo
 type Repository struct {
 	sync.RWMutex
-	data   map[string]model.Secret
-	Broken bool
+	data map[string]model.Secret
+	// versions хранит прежние версии секретов, начиная с самой старой
+	versions map[string][]model.SecretVersion
+	// accesses хранит журналы прочтений секретов, начиная с самого раннего прочтения
+	accesses map[string][]model.SecretAccess
+	// audit хранит журнал аудита в порядке записи событий
+	audit []model.AuditEvent
+	// subscriptions хранит подписки на события секретов по их идентификаторам
+	subscriptions map[string]model.WebhookSubscription
+	// deadLetters хранит недоставленные уведомления в порядке записи
+	deadLetters []model.WebhookDelivery
+	// tombstones хранит записи об исчезнувших секретах по их идентификаторам
+	tombstones map[string]model.Tombstone
+	// requests хранит запросы секретов по их идентификаторам
+	requests map[string]model.SecretRequest
+	Broken   bool
 }
 
 // Init настраивает соединение с базой данных
 func (r *Repository) Init(_ context.Context) error {
 	r.data = make(map[string]model.Secret, 0)
+	r.versions = make(map[string][]model.SecretVersion, 0)
+	r.accesses = make(map[string][]model.SecretAccess, 0)
+	r.audit = make([]model.AuditEvent, 0)
+	r.subscriptions = make(map[string]model.WebhookSubscription, 0)
+	r.deadLetters = make([]model.WebhookDelivery, 0)
+	r.tombstones = make(map[string]model.Tombstone, 0)
+	r.requests = make(map[string]model.SecretRequest, 0)
 	return nil
 }
 
@@ -35,46 +58,290 @@ func (r *Repository) Ping(_ context.Context) error {
 func (r *Repository) Close(_ context.Context) error {
 	r.Lock()
 	r.data = nil
+	r.versions = nil
+	r.accesses = nil
+	r.audit = nil
+	r.subscriptions = nil
+	r.deadLetters = nil
+	r.tombstones = nil
+	r.requests = nil
 	r.Unlock()
 	return nil
 }
 
 // Create создаёт новый model.Secret
-func (r *Repository) Create(_ context.Context, body string, meta map[string]string) (model.Secret, error) {
+func (r *Repository) Create(_ context.Context, secret model.Secret) (model.Secret, error) {
 	r.Lock()
 	defer r.Unlock()
-	secret := model.Secret{
-		ID:        misc.UUID(),
-		Body:      body,
-		Meta:      meta,
-		CreatedAt: time.Now(),
-		ExpireAt:  time.Now().Add(model.TTL),
+	secret.ID = misc.UUID()
+	r.data[secret.ID] = secret
+	return secret, nil
+}
+
+// CreateWithinQuota создаёт новый model.Secret, если у создателя меньше maxSecrets действующих секретов.
+// Подсчёт и сохранение идут под одной блокировкой
+func (r *Repository) CreateWithinQuota(_ context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.countByOwner(secret.Owner) >= maxSecrets {
+		return model.Secret{}, model.ErrQuotaExceeded
 	}
+	secret.ID = misc.UUID()
 	r.data[secret.ID] = secret
 	return secret, nil
 }
 
-// FindByID ищет model.Secret по идентификатору
+// FindByID ищет model.Secret по идентификатору и засчитывает его прочтение
 func (r *Repository) FindByID(_ context.Context, id string) (model.Secret, error) {
-	r.RLock()
-	defer r.RUnlock()
+	r.Lock()
+	defer r.Unlock()
 	secret, found := r.data[id]
 	if found {
 		if secret.Expired() {
 			return model.Secret{}, model.ErrSecretNotFound
 		}
+		if !secret.Active(time.Now()) {
+			return model.Secret{}, &model.NotYetAvailableError{NotBefore: secret.NotBefore}
+		}
+		secret.Views++
+		if secret.ViewsLeft() == 0 {
+			r.remove(id)
+		} else {
+			r.data[id] = secret
+		}
 		return secret, nil
 	}
 	return model.Secret{}, model.ErrSecretNotFound
 }
 
+// Peek ищет model.Secret по идентификатору, не засчитывая его прочтение
+func (r *Repository) Peek(_ context.Context, id string) (model.Secret, error) {
+	r.RLock()
+	defer r.RUnlock()
+	secret, found := r.data[id]
+	if !found || secret.Expired() {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+// FindIDByShareToken перебирает секреты в поисках секрета с хэшем токена ссылки tokenHash
+func (r *Repository) FindIDByShareToken(_ context.Context, tokenHash string) (string, error) {
+	r.RLock()
+	defer r.RUnlock()
+	for id := range r.data {
+		if r.data[id].ShareTokenHash == tokenHash && !r.data[id].Expired() {
+			return id, nil
+		}
+	}
+	return "", model.ErrSecretNotFound
+}
+
+// RegisterFailedAttempt засчитывает неверную попытку ввода кодовой фразы, удаляя секрет, если попыток не осталось
+func (r *Repository) RegisterFailedAttempt(_ context.Context, id string, maxAttempts int64) (int64, error) {
+	r.Lock()
+	defer r.Unlock()
+	secret, found := r.data[id]
+	if !found || secret.Expired() {
+		return 0, model.ErrSecretNotFound
+	}
+	secret.FailedAttempts++
+	if secret.FailedAttempts >= maxAttempts {
+		r.remove(id)
+		return 0, nil
+	}
+	r.data[id] = secret
+	return maxAttempts - secret.FailedAttempts, nil
+}
+
+// FindAndDeleteByID ищет model.Secret по идентификатору и удаляет его под блокировкой на запись
+func (r *Repository) FindAndDeleteByID(_ context.Context, id string) (model.Secret, error) {
+	r.Lock()
+	defer r.Unlock()
+	secret, found := r.data[id]
+	if !found {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	if !secret.Expired() && !secret.Active(time.Now()) {
+		return model.Secret{}, &model.NotYetAvailableError{NotBefore: secret.NotBefore}
+	}
+	r.remove(id)
+	if secret.Expired() {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	secret.Views++
+	return secret, nil
+}
+
+// FindByRecipient возвращает секреты, адресованные субъекту или его группам, в порядке создания
+func (r *Repository) FindByRecipient(_ context.Context, subject string, groups []string) ([]model.Secret, error) {
+	r.RLock()
+	defer r.RUnlock()
+	identity := model.Identity{Subject: subject, Groups: groups}
+	ret := make([]model.Secret, 0)
+	for k := range r.data {
+		if r.data[k].AddressedTo(identity) && !r.data[k].Expired() {
+			ret = append(ret, r.data[k])
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+	return ret, nil
+}
+
+// List возвращает страницу секретов, подходящих под фильтры, без тел
+func (r *Repository) List(_ context.Context, filter repository.ListFilter) ([]model.Secret, string, error) {
+	createdAt, id, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	ret := make([]model.Secret, 0)
+	for k := range r.data {
+		secret := r.data[k]
+		if secret.Expired() || !filter.Match(secret) {
+			continue
+		}
+		if filter.Cursor != "" && !repository.IsAfterCursor(secret, createdAt, id) {
+			continue
+		}
+		secret.Body = nil
+		ret = append(ret, secret)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return repository.IsAfterCursor(ret[j], ret[i].CreatedAt, ret[i].ID)
+	})
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// CountByOwner возвращает число не устаревших секретов создателя owner
+func (r *Repository) CountByOwner(_ context.Context, owner string) (int64, error) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.countByOwner(owner), nil
+}
+
+// countByOwner считает не устаревшие секреты создателя owner, вызывающий держит блокировку
+func (r *Repository) countByOwner(owner string) int64 {
+	var count int64
+	for k := range r.data {
+		if r.data[k].Owner == owner && !r.data[k].Expired() {
+			count++
+		}
+	}
+	return count
+}
+
+// Update заменяет тело, метаданные, момент устаревания и ключ данных секрета, если его версия равна version,
+// сохраняя заменённую версию в истории
+func (r *Repository) Update(_ context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error) {
+	r.Lock()
+	defer r.Unlock()
+	stored, found := r.data[secret.ID]
+	if !found || stored.Expired() {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	if stored.Version != version {
+		return model.Secret{}, model.ErrVersionConflict
+	}
+	if keepVersions > 0 {
+		if r.versions == nil {
+			r.versions = make(map[string][]model.SecretVersion, 0)
+		}
+		history := append(r.versions[secret.ID], stored.Archive(time.Now()))
+		if int64(len(history)) > keepVersions {
+			history = history[int64(len(history))-keepVersions:]
+		}
+		r.versions[secret.ID] = history
+	}
+	stored.Body = secret.Body
+	stored.Meta = secret.Meta
+	stored.ContentType = secret.ContentType
+	stored.Filename = secret.Filename
+	stored.ExpireAt = secret.ExpireAt
+	stored.KeyID = secret.KeyID
+	stored.WrappedKey = secret.WrappedKey
+	stored.Version = version + 1
+	r.data[secret.ID] = stored
+	return stored, nil
+}
+
+// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой
+func (r *Repository) ListVersions(_ context.Context, id string) ([]model.SecretVersion, error) {
+	r.RLock()
+	defer r.RUnlock()
+	history := r.versions[id]
+	ret := make([]model.SecretVersion, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		version := history[i]
+		version.Body = nil
+		ret = append(ret, version)
+	}
+	return ret, nil
+}
+
+// GetVersion возвращает прежнюю версию секрета
+func (r *Repository) GetVersion(_ context.Context, id string, version int64) (model.SecretVersion, error) {
+	r.RLock()
+	defer r.RUnlock()
+	history := r.versions[id]
+	for i := range history {
+		if history[i].Version == version {
+			return history[i], nil
+		}
+	}
+	return model.SecretVersion{}, model.ErrSecretNotFound
+}
+
+// RecordAccess добавляет прочтение в журнал прочтений секрета
+func (r *Repository) RecordAccess(_ context.Context, id string, access model.SecretAccess, keepAccesses int64) error {
+	r.Lock()
+	defer r.Unlock()
+	secret, found := r.data[id]
+	if !found || secret.Expired() {
+		return model.ErrSecretNotFound
+	}
+	if r.accesses == nil {
+		r.accesses = make(map[string][]model.SecretAccess, 0)
+	}
+	history := append(r.accesses[id], access)
+	if int64(len(history)) > keepAccesses {
+		history = history[int64(len(history))-keepAccesses:]
+	}
+	r.accesses[id] = history
+	return nil
+}
+
+// ListAccesses возвращает журнал прочтений секрета, начиная с самого раннего прочтения
+func (r *Repository) ListAccesses(_ context.Context, id string) ([]model.SecretAccess, error) {
+	r.RLock()
+	defer r.RUnlock()
+	ret := make([]model.SecretAccess, len(r.accesses[id]))
+	copy(ret, r.accesses[id])
+	return ret, nil
+}
+
+// remove удаляет секрет вместе с его прежними версиями и журналом прочтений, вызывается под блокировкой
+func (r *Repository) remove(id string) {
+	delete(r.data, id)
+	delete(r.versions, id)
+	delete(r.accesses, id)
+}
+
 // DeleteByID удаляет секрет по идентификатору
 func (r *Repository) DeleteByID(_ context.Context, id string) error {
 	r.Lock()
 	defer r.Unlock()
 	_, found := r.data[id]
 	if found {
-		delete(r.data, id)
+		r.remove(id)
 		return nil
 	}
 	return model.ErrSecretNotFound
@@ -93,7 +360,7 @@ func (r *Repository) PutSecret(_ context.Context, secret model.Secret) error {
 }
 
 // Prune удаляет старые секреты
-func (r *Repository) Prune(_ context.Context) error {
+func (r *Repository) Prune(_ context.Context) ([]string, error) {
 	r.Lock()
 	defer r.Unlock()
 	keysToDelete := make([]string, 0, len(r.data)) // just in case all records expired
@@ -103,7 +370,106 @@ func (r *Repository) Prune(_ context.Context) error {
 		}
 	}
 	for k := range keysToDelete {
-		delete(r.data, keysToDelete[k])
+		r.remove(keysToDelete[k])
+	}
+	return keysToDelete, nil
+}
+
+// ListWrappedKeys возвращает обёрнутые ключи данных секретов, упорядоченных по идентификатору
+func (r *Repository) ListWrappedKeys(_ context.Context, exceptKeyID, cursor string, limit int64) ([]model.Secret, string, error) {
+	r.RLock()
+	defer r.RUnlock()
+	ids := make([]string, 0, len(r.data))
+	for k := range r.data {
+		if k > cursor && r.data[k].Encrypted() && r.data[k].KeyID != exceptKeyID && !r.data[k].Expired() {
+			ids = append(ids, k)
+		}
+	}
+	sort.Strings(ids)
+	next := ""
+	if int64(len(ids)) > limit {
+		ids = ids[:limit]
+		next = ids[limit-1]
 	}
+	ret := make([]model.Secret, 0, len(ids))
+	for i := range ids {
+		ret = append(ret, model.Secret{
+			ID:         ids[i],
+			KeyID:      r.data[ids[i]].KeyID,
+			WrappedKey: r.data[ids[i]].WrappedKey,
+		})
+	}
+	return ret, next, nil
+}
+
+// UpdateWrappedKey заменяет обёрнутый ключ данных секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateWrappedKey(_ context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error {
+	r.Lock()
+	defer r.Unlock()
+	secret, found := r.data[id]
+	if !found || secret.KeyID != oldKeyID {
+		return model.ErrSecretNotFound
+	}
+	secret.KeyID = newKeyID
+	secret.WrappedKey = wrapped
+	r.data[id] = secret
 	return nil
 }
+
+// ListVersionWrappedKeys возвращает обёрнутые ключи данных прежних версий секретов,
+// упорядоченных по идентификатору секрета и номеру версии
+func (r *Repository) ListVersionWrappedKeys(_ context.Context, exceptKeyID, cursor string, limit int64) ([]model.SecretVersion, string, error) {
+	afterID, afterVersion, err := repository.DecodeVersionCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	ret := make([]model.SecretVersion, 0)
+	for id, history := range r.versions {
+		if id < afterID || r.data[id].Expired() {
+			continue
+		}
+		for i := range history {
+			if id == afterID && history[i].Version <= afterVersion {
+				continue
+			}
+			if history[i].KeyID == "" || history[i].KeyID == exceptKeyID {
+				continue
+			}
+			ret = append(ret, model.SecretVersion{
+				SecretID:   id,
+				Version:    history[i].Version,
+				KeyID:      history[i].KeyID,
+				WrappedKey: history[i].WrappedKey,
+			})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].SecretID == ret[j].SecretID {
+			return ret[i].Version < ret[j].Version
+		}
+		return ret[i].SecretID < ret[j].SecretID
+	})
+	next := ""
+	if int64(len(ret)) > limit {
+		ret = ret[:limit]
+		next = repository.EncodeVersionCursor(ret[limit-1])
+	}
+	return ret, next, nil
+}
+
+// UpdateVersionWrappedKey заменяет обёрнутый ключ данных прежней версии секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateVersionWrappedKey(_ context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error {
+	r.Lock()
+	defer r.Unlock()
+	history := r.versions[id]
+	for i := range history {
+		if history[i].Version == version && history[i].KeyID == oldKeyID {
+			history[i].KeyID = newKeyID
+			history[i].WrappedKey = wrapped
+			return nil
+		}
+	}
+	return model.ErrSecretNotFound
+}
@@ -32,7 +32,7 @@ func TestRepository_PutSecret(t *testing.T) {
 	var err error
 	err = mr.PutSecret(context.Background(), model.Secret{
 		ID:   "a",
-		Body: "aa",
+		Body: []byte("aa"),
 		Meta: map[string]string{
 			"aaa": "aaaa",
 		},
@@ -47,7 +47,7 @@ func TestRepository_PutSecret(t *testing.T) {
 		t.Errorf("ошибка поиска секрета: %s", err)
 	}
 	assert.Equal(t, "a", found.ID, "wrong id")
-	assert.Equal(t, "aa", found.Body, "wrong body")
+	assert.Equal(t, "aa", string(found.Body), "wrong body")
 	assert.Equal(t, 1, len(found.Meta), "wrong meta length")
 	assert.Equal(t, "aaaa", found.Meta["aaa"], "wrong meta")
 	t.Logf("Secret will expire in %s", found.ExpireAt.Sub(time.Now()).String())
@@ -65,7 +65,7 @@ func TestRepository_PutSecret(t *testing.T) {
 }
 
 func TestRepository_Prune(t *testing.T) {
-	err := mr.Prune(context.Background())
+	_, err := mr.Prune(context.Background())
 	if err != nil {
 		t.Errorf("ошибка очистки: %s", err)
 	}
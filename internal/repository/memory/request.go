@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// CreateRequest сохраняет новый запрос секрета
+func (r *Repository) CreateRequest(_ context.Context, request model.SecretRequest) (model.SecretRequest, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.requests == nil {
+		r.requests = make(map[string]model.SecretRequest, 0)
+	}
+	request.ID = misc.UUID()
+	request.Token = ""
+	r.requests[request.ID] = request
+	return request, nil
+}
+
+// FindRequest ищет запрос секрета по идентификатору
+func (r *Repository) FindRequest(_ context.Context, id string) (model.SecretRequest, error) {
+	r.RLock()
+	defer r.RUnlock()
+	request, found := r.requests[id]
+	if !found || request.Expired() {
+		return model.SecretRequest{}, model.ErrRequestNotFound
+	}
+	return request, nil
+}
+
+// FindRequestByToken ищет запрос секрета по хэшу токена ссылки
+func (r *Repository) FindRequestByToken(_ context.Context, tokenHash string) (model.SecretRequest, error) {
+	r.RLock()
+	defer r.RUnlock()
+	for k := range r.requests {
+		if r.requests[k].TokenHash == tokenHash && !r.requests[k].Expired() {
+			return r.requests[k], nil
+		}
+	}
+	return model.SecretRequest{}, model.ErrRequestNotFound
+}
+
+// FulfilRequest отмечает, что по запросу прислали секрет, под блокировкой на запись
+func (r *Repository) FulfilRequest(_ context.Context, id, secretID string, fulfilledAt time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+	request, found := r.requests[id]
+	if !found || request.Expired() {
+		return model.ErrRequestNotFound
+	}
+	if request.Fulfilled() {
+		return model.ErrRequestFulfilled
+	}
+	request.SecretID = secretID
+	request.FulfilledAt = fulfilledAt
+	r.requests[id] = request
+	return nil
+}
+
+// DeleteRequest удаляет запрос секрета
+func (r *Repository) DeleteRequest(_ context.Context, id string) error {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.requests, id)
+	return nil
+}
+
+// PruneRequests удаляет устаревшие запросы секретов
+func (r *Repository) PruneRequests(_ context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+	for k := range r.requests {
+		if r.requests[k].Expired() {
+			delete(r.requests, k)
+		}
+	}
+	return nil
+}
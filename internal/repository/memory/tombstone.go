@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// SaveTombstone сохраняет запись об исчезнувшем секрете
+func (r *Repository) SaveTombstone(_ context.Context, tombstone model.Tombstone) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.tombstones == nil {
+		r.tombstones = make(map[string]model.Tombstone, 0)
+	}
+	r.tombstones[tombstone.SecretID] = tombstone
+	return nil
+}
+
+// FindTombstone ищет запись об исчезнувшем секрете
+func (r *Repository) FindTombstone(_ context.Context, secretID string) (model.Tombstone, error) {
+	r.RLock()
+	defer r.RUnlock()
+	tombstone, found := r.tombstones[secretID]
+	if !found || tombstone.ExpireAt.Before(time.Now()) {
+		return model.Tombstone{}, model.ErrSecretNotFound
+	}
+	return tombstone, nil
+}
+
+// PruneTombstones удаляет устаревшие записи об исчезнувших секретах
+func (r *Repository) PruneTombstones(_ context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+	now := time.Now()
+	for k := range r.tombstones {
+		if r.tombstones[k].ExpireAt.Before(now) {
+			delete(r.tombstones, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// SaveSubscription сохраняет подписку на события секрета
+func (r *Repository) SaveSubscription(_ context.Context, subscription model.WebhookSubscription) error {
+	r.Lock()
+	defer r.Unlock()
+	r.subscriptions[subscription.SecretID] = subscription
+	return nil
+}
+
+// FindSubscription ищет подписку на события секрета
+func (r *Repository) FindSubscription(_ context.Context, secretID string) (model.WebhookSubscription, error) {
+	r.RLock()
+	defer r.RUnlock()
+	subscription, found := r.subscriptions[secretID]
+	if !found || subscription.ExpireAt.Before(time.Now()) {
+		return model.WebhookSubscription{}, model.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription удаляет подписку на события секрета
+func (r *Repository) DeleteSubscription(_ context.Context, secretID string) error {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.subscriptions, secretID)
+	return nil
+}
+
+// PruneSubscriptions удаляет устаревшие подписки
+func (r *Repository) PruneSubscriptions(_ context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+	now := time.Now()
+	for k := range r.subscriptions {
+		if r.subscriptions[k].ExpireAt.Before(now) {
+			delete(r.subscriptions, k)
+		}
+	}
+	return nil
+}
+
+// RecordDeadLetter сохраняет недоставленное уведомление
+func (r *Repository) RecordDeadLetter(_ context.Context, delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	r.Lock()
+	defer r.Unlock()
+	delivery.ID = misc.UUID()
+	r.deadLetters = append(r.deadLetters, delivery)
+	return delivery, nil
+}
+
+// ListDeadLetters возвращает недоставленные уведомления о событиях секрета
+func (r *Repository) ListDeadLetters(_ context.Context, secretID string) ([]model.WebhookDelivery, error) {
+	r.RLock()
+	defer r.RUnlock()
+	ret := make([]model.WebhookDelivery, 0)
+	for i := range r.deadLetters {
+		if r.deadLetters[i].SecretID == secretID {
+			ret = append(ret, r.deadLetters[i])
+		}
+	}
+	return ret, nil
+}
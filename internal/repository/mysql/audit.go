@@ -0,0 +1,102 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// auditEventData хранит событие журнала аудита
+type auditEventData struct {
+	ID        string    `gorm:"primaryKey;type:varchar(191)"`
+	SecretID  string    `gorm:"type:varchar(191);not null;index:audit_event_secret_id,priority:1"`
+	Action    string    `gorm:"type:varchar(32);not null"`
+	Subject   string    `gorm:"type:varchar(191);not null;default:'';index:audit_event_subject,priority:1"`
+	Transport string    `gorm:"type:varchar(32);not null;default:''"`
+	RemoteIP  string    `gorm:"type:varchar(64);not null;default:''"`
+	UserAgent string    `gorm:"type:varchar(1024);not null;default:''"`
+	TraceID   string    `gorm:"type:varchar(64);not null;default:''"`
+	Detail    string    `gorm:"type:varchar(1024);not null;default:''"`
+	CreatedAt time.Time `gorm:"index;index:audit_event_secret_id,priority:2;index:audit_event_subject,priority:2"`
+}
+
+func (d auditEventData) toModel() model.AuditEvent {
+	return model.AuditEvent{
+		ID:        d.ID,
+		SecretID:  d.SecretID,
+		Action:    model.AuditAction(d.Action),
+		Subject:   d.Subject,
+		Transport: d.Transport,
+		RemoteIP:  d.RemoteIP,
+		UserAgent: d.UserAgent,
+		TraceID:   d.TraceID,
+		Detail:    d.Detail,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+// Record сохраняет событие журнала аудита
+func (r *Repository) Record(ctx context.Context, event model.AuditEvent) (model.AuditEvent, error) {
+	event.ID = misc.UUID()
+	err := r.db.WithContext(ctx).Create(&auditEventData{
+		ID:        event.ID,
+		SecretID:  event.SecretID,
+		Action:    string(event.Action),
+		Subject:   event.Subject,
+		Transport: event.Transport,
+		RemoteIP:  event.RemoteIP,
+		UserAgent: event.UserAgent,
+		TraceID:   event.TraceID,
+		Detail:    event.Detail,
+		CreatedAt: event.CreatedAt,
+	}).Error
+	if err != nil {
+		return model.AuditEvent{}, err
+	}
+	return event, nil
+}
+
+// Query постранично возвращает события журнала аудита, подходящие под фильтры
+func (r *Repository) Query(ctx context.Context, filter repository.AuditFilter) ([]model.AuditEvent, string, error) {
+	cursorCreatedAt, cursorID, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	query := r.db.WithContext(ctx).Model(&auditEventData{})
+	if filter.SecretID != "" {
+		query = query.Where("secret_id = ?", filter.SecretID)
+	}
+	if filter.Subject != "" {
+		query = query.Where("subject = ?", filter.Subject)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if filter.Cursor != "" {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", cursorCreatedAt, cursorCreatedAt, cursorID)
+	}
+	var rows []auditEventData
+	err = query.
+		Order("created_at, id").
+		Limit(int(filter.Limit + 1)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, "", err
+	}
+	ret := make([]model.AuditEvent, 0, len(rows))
+	for i := range rows {
+		ret = append(ret, rows[i].toModel())
+	}
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeAuditCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
@@ -8,23 +8,86 @@ import (
 	"github.com/vodolaz095/purser/pkg/misc"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/plugin/opentelemetry/tracing"
 
+	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/model"
 )
 
 type secretData struct {
-	ID        string    `gorm:"primaryKey"`
-	Encoded   []byte    `gorm:"type:text"`
-	CreatedAt time.Time `json:"createdAt" gorm:"index"`
+	ID      string `gorm:"primaryKey"`
+	Encoded []byte `gorm:"type:text"`
+	// Body хранит тело секрета как есть, у секретов, созданных до появления двоичных секретов, тело лежит в Encoded
+	Body        []byte    `gorm:"type:longblob"`
+	ContentType string    `gorm:"type:varchar(255);not null;default:''"`
+	Filename    string    `gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"index"`
+	ExpireAt    time.Time `json:"expireAt" gorm:"index;default:null"`
+	// NotBefore - момент, начиная с которого секрет можно прочитать, NULL, если секрет доступен сразу
+	NotBefore *time.Time `gorm:"default:null"`
+	Views     int64      `gorm:"not null;default:0"`
+	MaxViews  int64      `gorm:"not null;default:0"`
+	// Version - версия секрета, у секретов, созданных до появления версий, она первая
+	Version int64 `gorm:"not null;default:1"`
+	// PassphraseHash хранит хэш bcrypt, который не длиннее 60 символов
+	PassphraseHash string `gorm:"type:varchar(255);not null;default:''"`
+	FailedAttempts int64  `gorm:"not null;default:0"`
+	// KeyID и WrappedKey задают ключ данных, которым зашифрован Encoded, если шифрование включено
+	KeyID      string `gorm:"type:varchar(64);not null;default:''"`
+	WrappedKey []byte `gorm:"type:varbinary(255)"`
+	Owner      string `gorm:"type:varchar(255);index;not null;default:''"`
+	// Recipients и RecipientGroups хранят адресатов секрета в виде JSON массивов, чтобы искать по ним с помощью JSON_CONTAINS
+	Recipients      []byte `gorm:"type:text"`
+	RecipientGroups []byte `gorm:"type:text"`
+	// ShareTokenHash хранит хэш SHA-256 токена ссылки в шестнадцатеричном виде
+	ShareTokenHash string `gorm:"type:varchar(64);index;not null;default:''"`
+	// Opaque - тело зашифровано клиентом, и ключа у сервера нет
+	Opaque bool `gorm:"not null;default:false"`
+}
+
+// secretVersionData хранит прежнюю версию секрета, тело и метаданные закодированы так же, как в secretData
+type secretVersionData struct {
+	SecretID    string    `gorm:"primaryKey;type:varchar(191)"`
+	Version     int64     `gorm:"primaryKey;autoIncrement:false"`
+	Encoded     []byte    `gorm:"type:text"`
+	Body        []byte    `gorm:"type:longblob"`
+	ContentType string    `gorm:"type:varchar(255);not null;default:''"`
+	Filename    string    `gorm:"type:varchar(255);not null;default:''"`
+	ExpireAt    time.Time `gorm:"default:null"`
+	KeyID       string    `gorm:"type:varchar(64);not null;default:''"`
+	WrappedKey  []byte    `gorm:"type:varbinary(255)"`
+	ReplacedAt  time.Time
+}
+
+// ownerQuotaData - строка создателя секретов, которую блокирует CreateWithinQuota, пока считает его секреты
+type ownerQuotaData struct {
+	Owner string `gorm:"primaryKey;type:varchar(191)"`
+}
+
+// secretAccessData хранит запись журнала прочтений секрета
+type secretAccessData struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	SecretID   string `gorm:"type:varchar(191);index"`
+	Subject    string `gorm:"type:varchar(255);not null;default:''"`
+	AccessedAt time.Time
 }
 
 type bodyData struct {
-	Body string            `json:"Body"`
+	// Body - тело секрета, созданного до появления колонки body, новые секреты хранят тело в ней
+	Body string            `json:"Body,omitempty"`
 	Meta map[string]string `json:"meta"`
 }
 
-// Repository реализует интерфейс SecretRepo с базой данных mysql/mariadb внутри
+// decodeBody возвращает тело секрета из колонки body, а если она пустая - из закодированных данных
+func decodeBody(body []byte, params bodyData) []byte {
+	if len(body) > 0 {
+		return body
+	}
+	return []byte(params.Body)
+}
+
+// Repository реализует интерфейсы SecretRepo, AuditRepo, WebhookRepo, TombstoneRepo и RequestRepo с базой данных mysql/mariadb внутри
 type Repository struct {
 	DatabaseConnectionString string
 	db                       *gorm.DB
@@ -46,7 +109,8 @@ func (r *Repository) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	err = db.Use(tracing.NewPlugin(tracing.WithoutMetrics()))
+	// значения параметров запросов - это тела и метаданные секретов, поэтому в трассировку они не попадают
+	err = db.Use(tracing.NewPlugin(tracing.WithoutMetrics(), tracing.WithoutQueryVariables()))
 	if err != nil {
 		return err
 	}
@@ -65,8 +129,18 @@ func (r *Repository) Init(ctx context.Context) error {
 	}
 	err = db.WithContext(ctx).
 		Set("gorm:table_options", "ENGINE=InnoDB").
-		AutoMigrate(&secretData{})
-	return err
+		AutoMigrate(&secretData{}, &secretVersionData{}, &secretAccessData{}, &auditEventData{},
+			&webhookSubscriptionData{}, &webhookDeadLetterData{}, &tombstoneData{}, &secretRequestData{},
+			&ownerQuotaData{})
+	if err != nil {
+		return err
+	}
+	// секреты, созданные до появления срока жизни у каждого секрета, живут model.TTL
+	return db.WithContext(ctx).
+		Model(&secretData{}).
+		Where("expire_at IS NULL").
+		Update("expire_at", gorm.Expr("DATE_ADD(created_at, INTERVAL ? SECOND)", int64(model.TTL.Seconds()))).
+		Error
 }
 
 // Close закрывает соединение с базой данных
@@ -79,40 +153,204 @@ func (r *Repository) Close(ctx context.Context) error {
 }
 
 // Create создаёт новый model.Secret
-func (r *Repository) Create(ctx context.Context, body string, meta map[string]string) (model.Secret, error) {
-	bd := bodyData{
-		Body: body,
-		Meta: meta,
+func (r *Repository) Create(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	secret.ID = misc.UUID()
+	databaseSecretData, err := toData(secret)
+	if err != nil {
+		return model.Secret{}, err
 	}
-	data, err := json.Marshal(bd)
+	err = r.db.
+		WithContext(ctx).
+		Save(&databaseSecretData).Error
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return secret, nil
+}
+
+// CreateWithinQuota сохраняет новый model.Secret в транзакции, которая сначала блокирует строку создателя
+// в таблице owner_quota_data с помощью SELECT ... FOR UPDATE, поэтому одновременные создания секретов
+// одного создателя считают его секреты по очереди
+func (r *Repository) CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	secret.ID = misc.UUID()
+	databaseSecretData, err := toData(secret)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lErr := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ownerQuotaData{Owner: secret.Owner}).Error
+		if lErr != nil {
+			return lErr
+		}
+		lErr = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&ownerQuotaData{}, "owner = ?", secret.Owner).Error
+		if lErr != nil {
+			return lErr
+		}
+		var count int64
+		lErr = tx.Model(&secretData{}).
+			Where("owner = ? AND expire_at > ?", secret.Owner, time.Now()).
+			Count(&count).Error
+		if lErr != nil {
+			return lErr
+		}
+		if count >= maxSecrets {
+			return model.ErrQuotaExceeded
+		}
+		return tx.Create(&databaseSecretData).Error
+	})
 	if err != nil {
 		return model.Secret{}, err
 	}
+	return secret, nil
+}
 
-	databaseSecretData := secretData{
-		ID:        misc.UUID(),
-		Encoded:   data,
-		CreatedAt: time.Now(),
+// toData собирает строку таблицы из model.Secret с уже назначенным идентификатором
+func toData(secret model.Secret) (secretData, error) {
+	bd := bodyData{
+		Meta: secret.Meta,
+	}
+	data, err := json.Marshal(bd)
+	if err != nil {
+		return secretData{}, err
+	}
+	recipients, err := json.Marshal(nonNil(secret.Recipients))
+	if err != nil {
+		return secretData{}, err
 	}
-	secret := model.Secret{
-		ID:        databaseSecretData.ID,
-		Body:      body,
-		Meta:      meta,
-		CreatedAt: databaseSecretData.CreatedAt,
-		ExpireAt:  databaseSecretData.CreatedAt.Add(model.TTL),
+	groups, err := json.Marshal(nonNil(secret.Groups))
+	if err != nil {
+		return secretData{}, err
 	}
+	return secretData{
+		ID:          secret.ID,
+		Encoded:     data,
+		Body:        secret.Body,
+		ContentType: secret.ContentType,
+		Filename:    secret.Filename,
+		CreatedAt:   secret.CreatedAt,
+		ExpireAt:    secret.ExpireAt,
+		NotBefore:   nullTime(secret.NotBefore),
+		Views:       secret.Views,
+		MaxViews:    secret.MaxViews,
+		Version:     secret.Version,
 
-	err = r.db.
-		WithContext(ctx).
-		Save(&databaseSecretData).Error
+		PassphraseHash: secret.PassphraseHash,
+		FailedAttempts: secret.FailedAttempts,
+		KeyID:          secret.KeyID,
+		WrappedKey:     secret.WrappedKey,
+		Owner:          secret.Owner,
+
+		Recipients:      recipients,
+		RecipientGroups: groups,
+		ShareTokenHash:  secret.ShareTokenHash,
+		Opaque:          secret.Opaque,
+	}, nil
+}
+
+// toModel собирает model.Secret из строки таблицы
+func (d secretData) toModel() (model.Secret, error) {
+	var params bodyData
+	err := json.Unmarshal(d.Encoded, &params)
 	if err != nil {
 		return model.Secret{}, err
 	}
-	return secret, nil
+	var recipients, groups []string
+	if len(d.Recipients) > 0 {
+		err = json.Unmarshal(d.Recipients, &recipients)
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	if len(d.RecipientGroups) > 0 {
+		err = json.Unmarshal(d.RecipientGroups, &groups)
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	var notBefore time.Time
+	if d.NotBefore != nil {
+		notBefore = *d.NotBefore
+	}
+	return model.Secret{
+		ID:          d.ID,
+		Body:        decodeBody(d.Body, params),
+		Meta:        params.Meta,
+		ContentType: d.ContentType,
+		Filename:    d.Filename,
+		CreatedAt:   d.CreatedAt,
+		ExpireAt:    d.ExpireAt,
+		NotBefore:   notBefore,
+		Views:       d.Views,
+		MaxViews:    d.MaxViews,
+		Version:     d.Version,
+
+		PassphraseHash: d.PassphraseHash,
+		FailedAttempts: d.FailedAttempts,
+		KeyID:          d.KeyID,
+		WrappedKey:     d.WrappedKey,
+		Owner:          d.Owner,
+		Recipients:     recipients,
+		Groups:         groups,
+		ShareTokenHash: d.ShareTokenHash,
+		Opaque:         d.Opaque,
+	}, nil
 }
 
-// FindByID ищет model.Secret по идентификатору
+// FindByID ищет model.Secret по идентификатору и засчитывает его прочтение в транзакции,
+// блокируя строку с помощью SELECT ... FOR UPDATE, так как MariaDB не умеет UPDATE ... RETURNING
 func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, error) {
+	var databaseSecretData secretData
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&databaseSecretData, "id = ?", id).Error
+		if lErr != nil {
+			return lErr
+		}
+		// expired
+		if databaseSecretData.ExpireAt.Before(time.Now()) {
+			return gorm.ErrRecordNotFound
+		}
+		if databaseSecretData.NotBefore != nil && databaseSecretData.NotBefore.After(time.Now()) {
+			return &model.NotYetAvailableError{NotBefore: *databaseSecretData.NotBefore}
+		}
+		databaseSecretData.Views++
+		if databaseSecretData.MaxViews > 0 && databaseSecretData.Views >= databaseSecretData.MaxViews {
+			return deleteSecret(tx, id)
+		}
+		return tx.Model(&secretData{}).
+			Where("id = ?", id).
+			Update("views", gorm.Expr("views + 1")).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.Secret{}, model.ErrSecretNotFound
+		}
+		return model.Secret{}, err
+	}
+	return databaseSecretData.toModel()
+}
+
+// FindIDByShareToken возвращает идентификатор секрета по хэшу токена ссылки
+func (r *Repository) FindIDByShareToken(ctx context.Context, tokenHash string) (string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).
+		Model(&secretData{}).
+		Where("share_token_hash = ? AND share_token_hash <> '' AND expire_at > ?", tokenHash, time.Now()).
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", model.ErrSecretNotFound
+	}
+	return ids[0], nil
+}
+
+// Peek ищет model.Secret по идентификатору, не засчитывая его прочтение
+func (r *Repository) Peek(ctx context.Context, id string) (model.Secret, error) {
 	var databaseSecretData secretData
 	err := r.db.WithContext(ctx).First(&databaseSecretData, "id = ?", id).Error
 	if err != nil {
@@ -122,33 +360,515 @@ func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, err
 		return model.Secret{}, err
 	}
 	// expired
-	if databaseSecretData.CreatedAt.Add(model.TTL).Before(time.Now()) {
+	if databaseSecretData.ExpireAt.Before(time.Now()) {
 		return model.Secret{}, model.ErrSecretNotFound
 	}
-	var params bodyData
-	err = json.Unmarshal(databaseSecretData.Encoded, &params)
+	return databaseSecretData.toModel()
+}
+
+// RegisterFailedAttempt засчитывает неверную попытку ввода кодовой фразы в транзакции,
+// удаляя секрет, если попыток не осталось
+func (r *Repository) RegisterFailedAttempt(ctx context.Context, id string, maxAttempts int64) (int64, error) {
+	var databaseSecretData secretData
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&databaseSecretData, "id = ?", id).Error
+		if lErr != nil {
+			return lErr
+		}
+		if databaseSecretData.ExpireAt.Before(time.Now()) {
+			return gorm.ErrRecordNotFound
+		}
+		databaseSecretData.FailedAttempts++
+		if databaseSecretData.FailedAttempts >= maxAttempts {
+			return deleteSecret(tx, id)
+		}
+		return tx.Model(&secretData{}).
+			Where("id = ?", id).
+			Update("failed_attempts", gorm.Expr("failed_attempts + 1")).Error
+	})
 	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, model.ErrSecretNotFound
+		}
+		return 0, err
+	}
+	if databaseSecretData.FailedAttempts >= maxAttempts {
+		return 0, nil
+	}
+	return maxAttempts - databaseSecretData.FailedAttempts, nil
+}
+
+// FindAndDeleteByID ищет model.Secret по идентификатору и удаляет его в транзакции,
+// блокируя строку с помощью SELECT ... FOR UPDATE
+func (r *Repository) FindAndDeleteByID(ctx context.Context, id string) (model.Secret, error) {
+	var databaseSecretData secretData
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&databaseSecretData, "id = ?", id).Error
+		if lErr != nil {
+			return lErr
+		}
+		// секрет, который ещё не стал доступен, остаётся на месте
+		if databaseSecretData.ExpireAt.After(time.Now()) &&
+			databaseSecretData.NotBefore != nil && databaseSecretData.NotBefore.After(time.Now()) {
+			return &model.NotYetAvailableError{NotBefore: *databaseSecretData.NotBefore}
+		}
+		return deleteSecret(tx, id)
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.Secret{}, model.ErrSecretNotFound
+		}
 		return model.Secret{}, err
 	}
-	secret := model.Secret{
-		ID:        id,
-		Body:      params.Body,
-		Meta:      params.Meta,
-		CreatedAt: databaseSecretData.CreatedAt,
-		ExpireAt:  databaseSecretData.CreatedAt.Add(model.TTL),
+	if databaseSecretData.ExpireAt.Before(time.Now()) {
+		return model.Secret{}, model.ErrSecretNotFound
 	}
-	return secret, nil
+	databaseSecretData.Views++
+	return databaseSecretData.toModel()
+}
+
+// FindByRecipient возвращает секреты, адресованные субъекту или его группам, в порядке создания
+func (r *Repository) FindByRecipient(ctx context.Context, subject string, groups []string) ([]model.Secret, error) {
+	addressed := r.db.Where("JSON_CONTAINS(recipients, JSON_QUOTE(?))", subject)
+	for i := range groups {
+		addressed = addressed.Or("JSON_CONTAINS(recipient_groups, JSON_QUOTE(?))", groups[i])
+	}
+	var rows []secretData
+	err := r.db.WithContext(ctx).
+		Where("expire_at > ?", time.Now()).
+		Where(addressed).
+		Order("created_at").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.Secret, 0, len(rows))
+	for i := range rows {
+		secret, tErr := rows[i].toModel()
+		if tErr != nil {
+			return nil, tErr
+		}
+		ret = append(ret, secret)
+	}
+	return ret, nil
 }
 
-// DeleteByID удаляет секрет по идентификатору
+// List возвращает страницу секретов без тел, упорядоченных по времени создания и идентификатору.
+// Метаданные хранятся в колонке encoded, поэтому фильтр по полю метаданных использует JSON_CONTAINS_PATH
+func (r *Repository) List(ctx context.Context, filter repository.ListFilter) ([]model.Secret, string, error) {
+	cursorCreatedAt, cursorID, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	query := r.db.WithContext(ctx).Where("expire_at > ?", time.Now())
+	if filter.Owner != "" {
+		query = query.Where("owner = ?", filter.Owner)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	if filter.MetaKey != "" {
+		query = query.Where("JSON_CONTAINS_PATH(encoded, 'one', CONCAT('$.meta.', JSON_QUOTE(?)))", filter.MetaKey)
+	}
+	if filter.Cursor != "" {
+		query = query.Where("(created_at > ? OR (created_at = ? AND id > ?))", cursorCreatedAt, cursorCreatedAt, cursorID)
+	}
+	var rows []secretData
+	err = query.
+		Order("created_at, id").
+		Limit(int(filter.Limit + 1)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, "", err
+	}
+	ret := make([]model.Secret, 0, len(rows))
+	for i := range rows {
+		secret, tErr := rows[i].toModel()
+		if tErr != nil {
+			return nil, "", tErr
+		}
+		secret.Body = nil
+		ret = append(ret, secret)
+	}
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// CountByOwner возвращает число не устаревших секретов создателя owner
+func (r *Repository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&secretData{}).
+		Where("owner = ? AND expire_at > ?", owner, time.Now()).
+		Count(&count).Error
+	return count, err
+}
+
+// Update заменяет тело, его тип и имя файла, метаданные, момент устаревания и ключ данных секрета в транзакции,
+// блокируя строку с помощью SELECT ... FOR UPDATE и сравнивая её версию с ожидаемой.
+// Заменяемая версия копируется в таблицу secret_version_data
+func (r *Repository) Update(ctx context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error) {
+	data, err := json.Marshal(bodyData{
+		Meta: secret.Meta,
+	})
+	if err != nil {
+		return model.Secret{}, err
+	}
+	var databaseSecretData secretData
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&databaseSecretData, "id = ?", secret.ID).Error
+		if lErr != nil {
+			return lErr
+		}
+		if databaseSecretData.ExpireAt.Before(time.Now()) {
+			return gorm.ErrRecordNotFound
+		}
+		if databaseSecretData.Version != version {
+			return model.ErrVersionConflict
+		}
+		if keepVersions > 0 {
+			lErr = tx.Create(&secretVersionData{
+				SecretID:    databaseSecretData.ID,
+				Version:     databaseSecretData.Version,
+				Encoded:     databaseSecretData.Encoded,
+				Body:        databaseSecretData.Body,
+				ContentType: databaseSecretData.ContentType,
+				Filename:    databaseSecretData.Filename,
+				ExpireAt:    databaseSecretData.ExpireAt,
+				KeyID:       databaseSecretData.KeyID,
+				WrappedKey:  databaseSecretData.WrappedKey,
+				ReplacedAt:  time.Now(),
+			}).Error
+			if lErr != nil {
+				return lErr
+			}
+			lErr = tx.Where("secret_id = ? AND version <= ?", databaseSecretData.ID, version-keepVersions).
+				Delete(&secretVersionData{}).Error
+			if lErr != nil {
+				return lErr
+			}
+		}
+		databaseSecretData.Encoded = data
+		databaseSecretData.Body = secret.Body
+		databaseSecretData.ContentType = secret.ContentType
+		databaseSecretData.Filename = secret.Filename
+		databaseSecretData.ExpireAt = secret.ExpireAt
+		databaseSecretData.KeyID = secret.KeyID
+		databaseSecretData.WrappedKey = secret.WrappedKey
+		databaseSecretData.Version = version + 1
+		return tx.Model(&secretData{}).
+			Where("id = ?", secret.ID).
+			Updates(map[string]interface{}{
+				"encoded":      databaseSecretData.Encoded,
+				"body":         databaseSecretData.Body,
+				"content_type": databaseSecretData.ContentType,
+				"filename":     databaseSecretData.Filename,
+				"expire_at":    databaseSecretData.ExpireAt,
+				"key_id":       databaseSecretData.KeyID,
+				"wrapped_key":  databaseSecretData.WrappedKey,
+				"version":      databaseSecretData.Version,
+			}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.Secret{}, model.ErrSecretNotFound
+		}
+		return model.Secret{}, err
+	}
+	return databaseSecretData.toModel()
+}
+
+// toModel собирает model.SecretVersion из строки таблицы прежних версий
+func (d secretVersionData) toModel() (model.SecretVersion, error) {
+	var params bodyData
+	err := json.Unmarshal(d.Encoded, &params)
+	if err != nil {
+		return model.SecretVersion{}, err
+	}
+	return model.SecretVersion{
+		SecretID:    d.SecretID,
+		Version:     d.Version,
+		Body:        decodeBody(d.Body, params),
+		Meta:        params.Meta,
+		ContentType: d.ContentType,
+		Filename:    d.Filename,
+		ExpireAt:    d.ExpireAt,
+		ReplacedAt:  d.ReplacedAt,
+		KeyID:       d.KeyID,
+		WrappedKey:  d.WrappedKey,
+	}, nil
+}
+
+// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой
+func (r *Repository) ListVersions(ctx context.Context, id string) ([]model.SecretVersion, error) {
+	var rows []secretVersionData
+	err := r.db.WithContext(ctx).
+		Where("secret_id = ?", id).
+		Order("version DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.SecretVersion, 0, len(rows))
+	for i := range rows {
+		version, tErr := rows[i].toModel()
+		if tErr != nil {
+			return nil, tErr
+		}
+		version.Body = nil
+		ret = append(ret, version)
+	}
+	return ret, nil
+}
+
+// GetVersion возвращает прежнюю версию секрета
+func (r *Repository) GetVersion(ctx context.Context, id string, version int64) (model.SecretVersion, error) {
+	var row secretVersionData
+	err := r.db.WithContext(ctx).
+		First(&row, "secret_id = ? AND version = ?", id, version).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.SecretVersion{}, model.ErrSecretNotFound
+		}
+		return model.SecretVersion{}, err
+	}
+	return row.toModel()
+}
+
+// nonNil заменяет nil на пустой список, чтобы в базе хранился JSON массив, а не null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// nullTime превращает нулевое время в NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// RecordAccess добавляет прочтение в таблицу secret_access_data в транзакции, блокируя строку секрета,
+// и удаляет из неё прочтения сверх keepAccesses последних
+func (r *Repository) RecordAccess(ctx context.Context, id string, access model.SecretAccess, keepAccesses int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var databaseSecretData secretData
+		lErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&databaseSecretData, "id = ? AND expire_at > ?", id, time.Now()).Error
+		if lErr != nil {
+			if lErr == gorm.ErrRecordNotFound {
+				return model.ErrSecretNotFound
+			}
+			return lErr
+		}
+		lErr = tx.Create(&secretAccessData{
+			SecretID:   id,
+			Subject:    access.Subject,
+			AccessedAt: access.AccessedAt,
+		}).Error
+		if lErr != nil {
+			return lErr
+		}
+		// MySQL не поддерживает LIMIT в подзапросах с IN, поэтому сначала ищется последнее прочтение, которое не хранится
+		var outdated []uint64
+		lErr = tx.Model(&secretAccessData{}).
+			Where("secret_id = ?", id).
+			Order("id DESC").
+			Offset(int(keepAccesses)).
+			Limit(1).
+			Pluck("id", &outdated).Error
+		if lErr != nil || len(outdated) == 0 {
+			return lErr
+		}
+		return tx.Where("secret_id = ? AND id <= ?", id, outdated[0]).Delete(&secretAccessData{}).Error
+	})
+}
+
+// ListAccesses возвращает журнал прочтений секрета, начиная с самого раннего прочтения
+func (r *Repository) ListAccesses(ctx context.Context, id string) ([]model.SecretAccess, error) {
+	var rows []secretAccessData
+	err := r.db.WithContext(ctx).
+		Where("secret_id = ?", id).
+		Order("id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.SecretAccess, 0, len(rows))
+	for i := range rows {
+		ret = append(ret, model.SecretAccess{
+			Subject:    rows[i].Subject,
+			AccessedAt: rows[i].AccessedAt,
+		})
+	}
+	return ret, nil
+}
+
+// deleteSecret удаляет секрет вместе с его прежними версиями и журналом прочтений в транзакции tx
+func deleteSecret(tx *gorm.DB, id string) error {
+	err := tx.Where("secret_id = ?", id).Delete(&secretVersionData{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("secret_id = ?", id).Delete(&secretAccessData{}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("id = ?", id).Delete(&secretData{}).Error
+}
+
+// DeleteByID удаляет секрет по идентификатору вместе с его прежними версиями и журналом прочтений
 func (r *Repository) DeleteByID(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).
-		Delete(&secretData{}).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteSecret(tx, id)
+	})
 }
 
-// Prune удаляет старые секреты
-func (r *Repository) Prune(ctx context.Context) error {
-	return r.db.WithContext(ctx).
-		Where("created_at < ?", time.Now().Add(-model.TTL)).
-		Delete(&secretData{}).Error
+// Prune удаляет старые секреты, а также прежние версии и журналы прочтений удалённых секретов
+func (r *Repository) Prune(ctx context.Context) ([]string, error) {
+	pruned := make([]string, 0)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		tErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&secretData{}).
+			Where("expire_at < ?", now).
+			Pluck("id", &pruned).Error
+		if tErr != nil {
+			return tErr
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return tx.Where("id IN ?", pruned).Delete(&secretData{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).
+		Where("secret_id NOT IN (?)", r.db.Model(&secretData{}).Select("id")).
+		Delete(&secretVersionData{}).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).
+		Where("secret_id NOT IN (?)", r.db.Model(&secretData{}).Select("id")).
+		Delete(&secretAccessData{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return pruned, nil
+}
+
+// ListWrappedKeys возвращает обёрнутые ключи данных секретов, упорядоченных по идентификатору
+func (r *Repository) ListWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.Secret, string, error) {
+	var rows []secretData
+	err := r.db.WithContext(ctx).
+		Select("id", "key_id", "wrapped_key").
+		Where("key_id <> '' AND key_id <> ? AND id > ? AND expire_at > ?", exceptKeyID, cursor, time.Now()).
+		Order("id").
+		Limit(int(limit)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, "", err
+	}
+	ret := make([]model.Secret, 0, len(rows))
+	for i := range rows {
+		ret = append(ret, model.Secret{
+			ID:         rows[i].ID,
+			KeyID:      rows[i].KeyID,
+			WrappedKey: rows[i].WrappedKey,
+		})
+	}
+	next := ""
+	if int64(len(ret)) == limit {
+		next = ret[len(ret)-1].ID
+	}
+	return ret, next, nil
+}
+
+// UpdateWrappedKey заменяет обёрнутый ключ данных секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateWrappedKey(ctx context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error {
+	res := r.db.WithContext(ctx).
+		Model(&secretData{}).
+		Where("id = ? AND key_id = ?", id, oldKeyID).
+		Updates(map[string]interface{}{
+			"key_id":      newKeyID,
+			"wrapped_key": wrapped,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
+}
+
+// ListVersionWrappedKeys возвращает обёрнутые ключи данных прежних версий действующих секретов,
+// упорядоченных по идентификатору секрета и номеру версии
+func (r *Repository) ListVersionWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.SecretVersion, string, error) {
+	afterID, afterVersion, err := repository.DecodeVersionCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	var rows []secretVersionData
+	err = r.db.WithContext(ctx).
+		Select("secret_id", "version", "key_id", "wrapped_key").
+		Where("key_id <> '' AND key_id <> ?", exceptKeyID).
+		Where("(secret_id > ? OR (secret_id = ? AND version > ?))", afterID, afterID, afterVersion).
+		Where("secret_id IN (?)", r.db.Model(&secretData{}).Select("id").Where("expire_at > ?", now)).
+		Order("secret_id").
+		Order("version").
+		Limit(int(limit)).
+		Find(&rows).Error
+	if err != nil {
+		return nil, "", err
+	}
+	ret := make([]model.SecretVersion, 0, len(rows))
+	for i := range rows {
+		ret = append(ret, model.SecretVersion{
+			SecretID:   rows[i].SecretID,
+			Version:    rows[i].Version,
+			KeyID:      rows[i].KeyID,
+			WrappedKey: rows[i].WrappedKey,
+		})
+	}
+	next := ""
+	if int64(len(ret)) == limit {
+		next = repository.EncodeVersionCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// UpdateVersionWrappedKey заменяет обёрнутый ключ данных прежней версии секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateVersionWrappedKey(ctx context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error {
+	res := r.db.WithContext(ctx).
+		Model(&secretVersionData{}).
+		Where("secret_id = ? AND version = ? AND key_id = ?", id, version, oldKeyID).
+		Updates(map[string]interface{}{
+			"key_id":      newKeyID,
+			"wrapped_key": wrapped,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
 }
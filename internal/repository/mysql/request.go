@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+	"gorm.io/gorm"
+)
+
+// secretRequestData хранит запрос секрета
+type secretRequestData struct {
+	ID          string `gorm:"primaryKey;type:varchar(191)"`
+	Requester   string `gorm:"type:varchar(255);not null"`
+	Description string `gorm:"type:text"`
+	// TokenHash хранит хэш SHA-256 токена ссылки в шестнадцатеричном виде
+	TokenHash string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	CreatedAt time.Time
+	ExpireAt  time.Time `gorm:"index"`
+	// SecretID пустой, пока по запросу не прислали секрет
+	SecretID    string     `gorm:"type:varchar(191);not null;default:''"`
+	FulfilledAt *time.Time `gorm:"default:null"`
+}
+
+// toModel собирает model.SecretRequest из строки таблицы
+func (d secretRequestData) toModel() model.SecretRequest {
+	var fulfilledAt time.Time
+	if d.FulfilledAt != nil {
+		fulfilledAt = *d.FulfilledAt
+	}
+	return model.SecretRequest{
+		ID:          d.ID,
+		Requester:   d.Requester,
+		Description: d.Description,
+		TokenHash:   d.TokenHash,
+		CreatedAt:   d.CreatedAt,
+		ExpireAt:    d.ExpireAt,
+		SecretID:    d.SecretID,
+		FulfilledAt: fulfilledAt,
+	}
+}
+
+// CreateRequest сохраняет новый запрос секрета
+func (r *Repository) CreateRequest(ctx context.Context, request model.SecretRequest) (model.SecretRequest, error) {
+	request.ID = misc.UUID()
+	request.Token = ""
+	err := r.db.WithContext(ctx).Create(&secretRequestData{
+		ID:          request.ID,
+		Requester:   request.Requester,
+		Description: request.Description,
+		TokenHash:   request.TokenHash,
+		CreatedAt:   request.CreatedAt,
+		ExpireAt:    request.ExpireAt,
+	}).Error
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	return request, nil
+}
+
+// findRequest ищет не устаревший запрос секрета по условию query
+func (r *Repository) findRequest(ctx context.Context, query string, arg interface{}) (model.SecretRequest, error) {
+	var data secretRequestData
+	err := r.db.WithContext(ctx).
+		First(&data, query+" AND expire_at > ?", arg, time.Now()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.SecretRequest{}, model.ErrRequestNotFound
+		}
+		return model.SecretRequest{}, err
+	}
+	return data.toModel(), nil
+}
+
+// FindRequest ищет запрос секрета по идентификатору
+func (r *Repository) FindRequest(ctx context.Context, id string) (model.SecretRequest, error) {
+	return r.findRequest(ctx, "id = ?", id)
+}
+
+// FindRequestByToken ищет запрос секрета по хэшу токена ссылки
+func (r *Repository) FindRequestByToken(ctx context.Context, tokenHash string) (model.SecretRequest, error) {
+	return r.findRequest(ctx, "token_hash = ?", tokenHash)
+}
+
+// FulfilRequest отмечает, что по запросу прислали секрет, запросом UPDATE с условием, что секрета ещё нет
+func (r *Repository) FulfilRequest(ctx context.Context, id, secretID string, fulfilledAt time.Time) error {
+	res := r.db.WithContext(ctx).
+		Model(&secretRequestData{}).
+		Where("id = ? AND expire_at > ? AND secret_id = ''", id, time.Now()).
+		Updates(map[string]interface{}{
+			"secret_id":    secretID,
+			"fulfilled_at": fulfilledAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	request, err := r.FindRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+	if request.Fulfilled() {
+		return model.ErrRequestFulfilled
+	}
+	return model.ErrRequestNotFound
+}
+
+// DeleteRequest удаляет запрос секрета
+func (r *Repository) DeleteRequest(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&secretRequestData{}).Error
+}
+
+// PruneRequests удаляет устаревшие запросы секретов
+func (r *Repository) PruneRequests(ctx context.Context) error {
+	return r.db.WithContext(ctx).
+		Where("expire_at < ?", time.Now()).
+		Delete(&secretRequestData{}).Error
+}
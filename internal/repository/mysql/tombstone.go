@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tombstoneData хранит запись об исчезнувшем секрете
+type tombstoneData struct {
+	SecretID  string `gorm:"primaryKey;type:varchar(191)"`
+	Reason    string `gorm:"type:varchar(64);not null"`
+	DeletedAt time.Time
+	ExpireAt  time.Time `gorm:"index"`
+}
+
+// SaveTombstone сохраняет запись об исчезнувшем секрете
+func (r *Repository) SaveTombstone(ctx context.Context, tombstone model.Tombstone) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&tombstoneData{
+		SecretID:  tombstone.SecretID,
+		Reason:    string(tombstone.Reason),
+		DeletedAt: tombstone.DeletedAt,
+		ExpireAt:  tombstone.ExpireAt,
+	}).Error
+}
+
+// FindTombstone ищет запись об исчезнувшем секрете
+func (r *Repository) FindTombstone(ctx context.Context, secretID string) (model.Tombstone, error) {
+	var data tombstoneData
+	err := r.db.WithContext(ctx).
+		First(&data, "secret_id = ? AND expire_at > ?", secretID, time.Now()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.Tombstone{}, model.ErrSecretNotFound
+		}
+		return model.Tombstone{}, err
+	}
+	return model.Tombstone{
+		SecretID:  data.SecretID,
+		Reason:    model.TombstoneReason(data.Reason),
+		DeletedAt: data.DeletedAt,
+		ExpireAt:  data.ExpireAt,
+	}, nil
+}
+
+// PruneTombstones удаляет устаревшие записи об исчезнувших секретах
+func (r *Repository) PruneTombstones(ctx context.Context) error {
+	return r.db.WithContext(ctx).
+		Where("expire_at < ?", time.Now()).
+		Delete(&tombstoneData{}).Error
+}
@@ -0,0 +1,122 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookSubscriptionData хранит подписку на события секрета
+type webhookSubscriptionData struct {
+	SecretID string    `gorm:"primaryKey;type:varchar(191)"`
+	Owner    string    `gorm:"type:varchar(191);not null;default:''"`
+	URL      string    `gorm:"type:varchar(2048);not null"`
+	Read     bool      `gorm:"not null;default:false"`
+	ExpireAt time.Time `gorm:"index"`
+}
+
+// webhookDeadLetterData хранит недоставленное уведомление
+type webhookDeadLetterData struct {
+	ID        string    `gorm:"primaryKey;type:varchar(191)"`
+	SecretID  string    `gorm:"type:varchar(191);not null;index:webhook_dead_letter_secret_id,priority:1"`
+	URL       string    `gorm:"type:varchar(2048);not null"`
+	EventType string    `gorm:"type:varchar(64);not null"`
+	Payload   []byte    `gorm:"type:blob;not null"`
+	Attempts  int64     `gorm:"not null;default:0"`
+	LastError string    `gorm:"type:varchar(1024);not null;default:''"`
+	CreatedAt time.Time `gorm:"index:webhook_dead_letter_secret_id,priority:2"`
+}
+
+func (d webhookDeadLetterData) toModel() model.WebhookDelivery {
+	return model.WebhookDelivery{
+		ID:        d.ID,
+		SecretID:  d.SecretID,
+		URL:       d.URL,
+		EventType: model.WebhookEventType(d.EventType),
+		Payload:   d.Payload,
+		Attempts:  d.Attempts,
+		LastError: d.LastError,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+// SaveSubscription сохраняет подписку на события секрета
+func (r *Repository) SaveSubscription(ctx context.Context, subscription model.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&webhookSubscriptionData{
+		SecretID: subscription.SecretID,
+		Owner:    subscription.Owner,
+		URL:      subscription.URL,
+		Read:     subscription.Read,
+		ExpireAt: subscription.ExpireAt,
+	}).Error
+}
+
+// FindSubscription ищет подписку на события секрета
+func (r *Repository) FindSubscription(ctx context.Context, secretID string) (model.WebhookSubscription, error) {
+	var data webhookSubscriptionData
+	err := r.db.WithContext(ctx).
+		First(&data, "secret_id = ? AND expire_at > ?", secretID, time.Now()).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return model.WebhookSubscription{}, model.ErrSubscriptionNotFound
+		}
+		return model.WebhookSubscription{}, err
+	}
+	return model.WebhookSubscription{
+		SecretID: data.SecretID,
+		Owner:    data.Owner,
+		URL:      data.URL,
+		Read:     data.Read,
+		ExpireAt: data.ExpireAt,
+	}, nil
+}
+
+// DeleteSubscription удаляет подписку на события секрета
+func (r *Repository) DeleteSubscription(ctx context.Context, secretID string) error {
+	return r.db.WithContext(ctx).Delete(&webhookSubscriptionData{}, "secret_id = ?", secretID).Error
+}
+
+// PruneSubscriptions удаляет устаревшие подписки
+func (r *Repository) PruneSubscriptions(ctx context.Context) error {
+	return r.db.WithContext(ctx).Delete(&webhookSubscriptionData{}, "expire_at < ?", time.Now()).Error
+}
+
+// RecordDeadLetter сохраняет недоставленное уведомление
+func (r *Repository) RecordDeadLetter(ctx context.Context, delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	delivery.ID = misc.UUID()
+	err := r.db.WithContext(ctx).Create(&webhookDeadLetterData{
+		ID:        delivery.ID,
+		SecretID:  delivery.SecretID,
+		URL:       delivery.URL,
+		EventType: string(delivery.EventType),
+		Payload:   delivery.Payload,
+		Attempts:  delivery.Attempts,
+		LastError: delivery.LastError,
+		CreatedAt: delivery.CreatedAt,
+	}).Error
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// ListDeadLetters возвращает недоставленные уведомления о событиях секрета
+func (r *Repository) ListDeadLetters(ctx context.Context, secretID string) ([]model.WebhookDelivery, error) {
+	var rows []webhookDeadLetterData
+	err := r.db.WithContext(ctx).
+		Where("secret_id = ?", secretID).
+		Order("created_at, id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.WebhookDelivery, 0, len(rows))
+	for i := range rows {
+		ret = append(ret, rows[i].toModel())
+	}
+	return ret, nil
+}
@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+)
+
+// auditColumns перечисляет колонки, из которых собирается model.AuditEvent
+const auditColumns = "id,secret_id,action,subject,transport,remote_ip,user_agent,trace_id,detail,created_at"
+
+// Record сохраняет событие журнала аудита
+func (r *Repository) Record(ctx context.Context, event model.AuditEvent) (model.AuditEvent, error) {
+	err := r.conn.QueryRow(ctx,
+		`INSERT INTO audit_event (secret_id, action, subject, transport, remote_ip, user_agent, trace_id, detail, created_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`,
+		event.SecretID, string(event.Action), event.Subject, event.Transport, event.RemoteIP, event.UserAgent,
+		event.TraceID, event.Detail, event.CreatedAt.UTC(),
+	).Scan(&event.ID)
+	if err != nil {
+		return model.AuditEvent{}, err
+	}
+	return event, nil
+}
+
+// Query постранично возвращает события журнала аудита, подходящие под фильтры
+func (r *Repository) Query(ctx context.Context, filter repository.AuditFilter) ([]model.AuditEvent, string, error) {
+	cursorCreatedAt, cursorID, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	rows, err := r.conn.Query(ctx,
+		"SELECT "+auditColumns+` FROM audit_event
+WHERE ($1 = '' OR secret_id = $1) AND ($2 = '' OR subject = $2)
+AND ($3::timestamp IS NULL OR created_at >= $3::timestamp) AND ($4::timestamp IS NULL OR created_at < $4::timestamp)
+AND ($5::timestamp IS NULL OR (created_at, id) > ($5::timestamp, $6::uuid))
+ORDER BY created_at, id LIMIT $7`,
+		filter.SecretID, filter.Subject, nullTime(filter.Since), nullTime(filter.Until),
+		nullTime(cursorCreatedAt), nullString(cursorID), filter.Limit+1,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.AuditEvent, 0, filter.Limit+1)
+	for rows.Next() {
+		var event model.AuditEvent
+		var action string
+		err = rows.Scan(&event.ID, &event.SecretID, &action, &event.Subject, &event.Transport, &event.RemoteIP,
+			&event.UserAgent, &event.TraceID, &event.Detail, &event.CreatedAt)
+		if err != nil {
+			return nil, "", err
+		}
+		event.Action = model.AuditAction(action)
+		ret = append(ret, event)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeAuditCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN expire_at timestamp;
+UPDATE secret SET expire_at = created_at + interval '3 hours';
+ALTER TABLE secret ALTER COLUMN expire_at SET NOT NULL;
+CREATE INDEX secret_expire_at_index ON secret (expire_at);
+
+-- +goose Down
+DROP INDEX secret_expire_at_index;
+ALTER TABLE secret DROP COLUMN expire_at;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN views bigint NOT NULL DEFAULT 0;
+ALTER TABLE secret ADD COLUMN max_views bigint NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN max_views;
+ALTER TABLE secret DROP COLUMN views;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN passphrase_hash text NOT NULL DEFAULT '';
+ALTER TABLE secret ADD COLUMN failed_attempts bigint NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN failed_attempts;
+ALTER TABLE secret DROP COLUMN passphrase_hash;
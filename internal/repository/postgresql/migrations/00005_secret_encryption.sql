@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN key_id text NOT NULL DEFAULT '';
+ALTER TABLE secret ADD COLUMN wrapped_key bytea NOT NULL DEFAULT ''::bytea;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN wrapped_key;
+ALTER TABLE secret DROP COLUMN key_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN owner text NOT NULL DEFAULT '';
+CREATE INDEX secret_owner_index ON secret (owner);
+
+-- +goose Down
+DROP INDEX secret_owner_index;
+ALTER TABLE secret DROP COLUMN owner;
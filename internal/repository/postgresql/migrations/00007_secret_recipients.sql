@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN recipients text[] NOT NULL DEFAULT '{}';
+ALTER TABLE secret ADD COLUMN recipient_groups text[] NOT NULL DEFAULT '{}';
+CREATE INDEX secret_recipients_index ON secret USING GIN (recipients);
+CREATE INDEX secret_recipient_groups_index ON secret USING GIN (recipient_groups);
+
+-- +goose Down
+DROP INDEX secret_recipient_groups_index;
+DROP INDEX secret_recipients_index;
+ALTER TABLE secret DROP COLUMN recipient_groups;
+ALTER TABLE secret DROP COLUMN recipients;
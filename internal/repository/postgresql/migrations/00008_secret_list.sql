@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX secret_owner_created_at_index ON secret (owner, created_at, id);
+
+-- +goose Down
+DROP INDEX secret_owner_created_at_index;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN version bigint NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN version;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE secret_version
+(
+    secret_id   uuid      NOT NULL REFERENCES secret (id) ON DELETE CASCADE,
+    version     bigint    NOT NULL,
+    body        text,
+    meta        hstore,
+    expire_at   timestamp,
+    key_id      text      NOT NULL DEFAULT '',
+    wrapped_key bytea,
+    replaced_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (secret_id, version)
+);
+
+-- +goose Down
+DROP TABLE secret_version;
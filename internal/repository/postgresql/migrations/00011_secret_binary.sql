@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE secret ALTER COLUMN body TYPE bytea USING convert_to(body, 'UTF8');
+ALTER TABLE secret ADD COLUMN content_type text NOT NULL DEFAULT '';
+ALTER TABLE secret ADD COLUMN filename text NOT NULL DEFAULT '';
+ALTER TABLE secret_version ALTER COLUMN body TYPE bytea USING convert_to(body, 'UTF8');
+ALTER TABLE secret_version ADD COLUMN content_type text NOT NULL DEFAULT '';
+ALTER TABLE secret_version ADD COLUMN filename text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE secret_version DROP COLUMN filename;
+ALTER TABLE secret_version DROP COLUMN content_type;
+ALTER TABLE secret_version ALTER COLUMN body TYPE text USING convert_from(body, 'UTF8');
+ALTER TABLE secret DROP COLUMN filename;
+ALTER TABLE secret DROP COLUMN content_type;
+ALTER TABLE secret ALTER COLUMN body TYPE text USING convert_from(body, 'UTF8');
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE audit_event
+(
+    id         uuid      NOT NULL default gen_random_uuid(),
+    secret_id  text      NOT NULL,
+    action     text      NOT NULL,
+    subject    text      NOT NULL DEFAULT '',
+    transport  text      NOT NULL DEFAULT '',
+    remote_ip  text      NOT NULL DEFAULT '',
+    user_agent text      NOT NULL DEFAULT '',
+    trace_id   text      NOT NULL DEFAULT '',
+    detail     text      NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX audit_event_created_at_index ON audit_event (created_at, id);
+CREATE INDEX audit_event_secret_id_index ON audit_event (secret_id, created_at, id);
+CREATE INDEX audit_event_subject_index ON audit_event (subject, created_at, id);
+
+-- +goose Down
+DROP TABLE audit_event;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE webhook_subscription
+(
+    secret_id text      NOT NULL,
+    owner     text      NOT NULL DEFAULT '',
+    url       text      NOT NULL,
+    read      boolean   NOT NULL DEFAULT false,
+    expire_at timestamp NOT NULL,
+    PRIMARY KEY (secret_id)
+);
+CREATE INDEX webhook_subscription_expire_at_index ON webhook_subscription (expire_at);
+
+CREATE TABLE webhook_dead_letter
+(
+    id         uuid      NOT NULL default gen_random_uuid(),
+    secret_id  text      NOT NULL,
+    url        text      NOT NULL,
+    event_type text      NOT NULL,
+    payload    bytea     NOT NULL,
+    attempts   bigint    NOT NULL DEFAULT 0,
+    last_error text      NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX webhook_dead_letter_secret_id_index ON webhook_dead_letter (secret_id, created_at, id);
+
+-- +goose Down
+DROP TABLE webhook_dead_letter;
+DROP TABLE webhook_subscription;
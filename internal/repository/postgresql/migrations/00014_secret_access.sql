@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE secret_access
+(
+    id          bigserial PRIMARY KEY,
+    secret_id   uuid      NOT NULL REFERENCES secret (id) ON DELETE CASCADE,
+    subject     text      NOT NULL DEFAULT '',
+    accessed_at timestamp NOT NULL
+);
+CREATE INDEX secret_access_secret_id ON secret_access (secret_id, id);
+
+-- +goose Down
+DROP TABLE secret_access;
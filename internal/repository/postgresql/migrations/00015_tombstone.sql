@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE tombstone
+(
+    secret_id  text      NOT NULL,
+    reason     text      NOT NULL,
+    deleted_at timestamp NOT NULL,
+    expire_at  timestamp NOT NULL,
+    PRIMARY KEY (secret_id)
+);
+CREATE INDEX tombstone_expire_at_index ON tombstone (expire_at);
+
+-- +goose Down
+DROP TABLE tombstone;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN not_before timestamp NULL;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN not_before;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN share_token_hash text NOT NULL DEFAULT '';
+CREATE INDEX secret_share_token_hash_index ON secret (share_token_hash) WHERE share_token_hash <> '';
+
+-- +goose Down
+DROP INDEX secret_share_token_hash_index;
+ALTER TABLE secret DROP COLUMN share_token_hash;
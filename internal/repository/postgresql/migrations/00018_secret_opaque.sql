@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE secret ADD COLUMN opaque boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE secret DROP COLUMN opaque;
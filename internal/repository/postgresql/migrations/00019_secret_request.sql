@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE secret_request
+(
+    id           uuid      NOT NULL default gen_random_uuid(),
+    requester    text      NOT NULL,
+    description  text      NOT NULL DEFAULT '',
+    token_hash   text      NOT NULL,
+    created_at   timestamp NOT NULL,
+    expire_at    timestamp NOT NULL,
+    secret_id    text      NOT NULL DEFAULT '',
+    fulfilled_at timestamp,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX secret_request_token_hash_index ON secret_request (token_hash);
+CREATE INDEX secret_request_expire_at_index ON secret_request (expire_at);
+
+-- +goose Down
+DROP TABLE secret_request;
@@ -9,18 +9,20 @@ import (
 	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // https://stackoverflow.com/questions/76865674/how-to-use-goose-migrations-with-pgx
 	"github.com/pressly/goose/v3"
+	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/model"
 )
 
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
-// Repository реализует интерфейс SecretRepo с базой данных postgresql внутри
+// Repository реализует интерфейсы SecretRepo, AuditRepo, WebhookRepo, TombstoneRepo и RequestRepo с базой данных postgresql внутри
 type Repository struct {
 	DatabaseConnectionString string
-	conn                     *pgx.Conn
+	conn                     *pgxpool.Pool
 }
 
 // Ping проверяет соединение с базой данных
@@ -30,12 +32,13 @@ func (r *Repository) Ping(ctx context.Context) error {
 
 // Init настраивает соединение с базой данных
 func (r *Repository) Init(ctx context.Context) error {
-	opts, err := pgx.ParseConfig(r.DatabaseConnectionString)
+	opts, err := pgxpool.ParseConfig(r.DatabaseConnectionString)
 	if err != nil {
 		return err
 	}
-	opts.Tracer = otelpgx.NewTracer()
-	conn, err := pgx.ConnectConfig(ctx, opts)
+	// параметры запросов, то есть тела и метаданные секретов, otelpgx по умолчанию в трассировку не записывает
+	opts.ConnConfig.Tracer = otelpgx.NewTracer()
+	conn, err := pgxpool.NewWithConfig(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -60,26 +63,66 @@ func (r *Repository) Init(ctx context.Context) error {
 }
 
 // Close закрывает соединение с базой данных
-func (r *Repository) Close(ctx context.Context) error {
-	return r.conn.Close(ctx)
+func (r *Repository) Close(_ context.Context) error {
+	r.conn.Close()
+	return nil
 }
 
 // Create создаёт новый model.Secret
-func (r *Repository) Create(ctx context.Context, body string, meta map[string]string) (model.Secret, error) {
-	var secret model.Secret
-	now := time.Now()
-	secret.Body = body
-	secret.Meta = meta
-	secret.CreatedAt = now
-	secret.ExpireAt = now.Add(model.TTL)
+func (r *Repository) Create(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	return insertSecret(ctx, r.conn, secret)
+}
+
+// CreateWithinQuota сохраняет новый model.Secret в транзакции, которая сначала берёт транзакционную
+// рекомендательную блокировку по создателю, поэтому одновременные создания секретов одного создателя
+// считают его секреты по очереди
+func (r *Repository) CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	var created model.Secret
+	err := pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		_, lErr := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", secret.Owner)
+		if lErr != nil {
+			return lErr
+		}
+		var count int64
+		lErr = tx.QueryRow(ctx, "SELECT count(*) FROM secret WHERE owner = $1 AND expire_at > $2",
+			secret.Owner, time.Now().UTC(),
+		).Scan(&count)
+		if lErr != nil {
+			return lErr
+		}
+		if count >= maxSecrets {
+			return model.ErrQuotaExceeded
+		}
+		created, lErr = insertSecret(ctx, tx, secret)
+		return lErr
+	})
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return created, nil
+}
 
+// queryRower - соединение или транзакция, в которых выполняется запрос, возвращающий одну строку
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// insertSecret сохраняет новый model.Secret запросом INSERT ... RETURNING id
+func insertSecret(ctx context.Context, conn queryRower, secret model.Secret) (model.Secret, error) {
 	dbMeta := make(pgtype.Hstore, 0)
-	for k, v := range meta {
+	for k, v := range secret.Meta {
+		v := v
 		dbMeta[k] = &v
 	}
-	row := r.conn.QueryRow(ctx,
-		`INSERT INTO secret (body, meta, created_at) VALUES ($1,$2::hstore,$3) RETURNING id;`,
-		body, dbMeta, now,
+	row := conn.QueryRow(ctx,
+		`INSERT INTO secret (body, meta, created_at, expire_at, views, max_views, passphrase_hash, failed_attempts,
+key_id, wrapped_key, owner, recipients, recipient_groups, version, content_type, filename, not_before, share_token_hash,
+opaque)
+VALUES ($1,$2::hstore,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19) RETURNING id;`,
+		secret.Body, dbMeta, secret.CreatedAt.UTC(), secret.ExpireAt.UTC(), secret.Views, secret.MaxViews,
+		secret.PassphraseHash, secret.FailedAttempts, secret.KeyID, secret.WrappedKey, secret.Owner,
+		nonNil(secret.Recipients), nonNil(secret.Groups), secret.Version, secret.ContentType, secret.Filename,
+		nullTime(secret.NotBefore), secret.ShareTokenHash, secret.Opaque,
 	)
 	err := row.Scan(&secret.ID)
 	if err != nil {
@@ -88,35 +131,524 @@ func (r *Repository) Create(ctx context.Context, body string, meta map[string]st
 	return secret, nil
 }
 
-// FindByID ищет model.Secret по идентификатору
-func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, error) {
+// secretColumns перечисляет колонки, из которых собирается model.Secret функцией scanSecret
+const secretColumns = "id,body,meta,created_at,expire_at,views,max_views,passphrase_hash,failed_attempts," +
+	"key_id,wrapped_key,owner,recipients,recipient_groups,version,content_type,filename,not_before,share_token_hash,opaque"
+
+// scanSecret собирает model.Secret из строки результата запроса, выбравшего колонки secretColumns
+func scanSecret(row pgx.Row) (model.Secret, error) {
 	var secret model.Secret
+	var notBefore *time.Time
 	dbMeta := make(pgtype.Hstore, 0)
-	row := r.conn.QueryRow(ctx, "SELECT body,meta,created_at FROM secret WHERE id = $1::uuid", id)
-	err := row.Scan(&secret.Body, &dbMeta, &secret.CreatedAt)
+	err := row.Scan(&secret.ID, &secret.Body, &dbMeta, &secret.CreatedAt, &secret.ExpireAt,
+		&secret.Views, &secret.MaxViews, &secret.PassphraseHash, &secret.FailedAttempts,
+		&secret.KeyID, &secret.WrappedKey, &secret.Owner, &secret.Recipients, &secret.Groups,
+		&secret.Version, &secret.ContentType, &secret.Filename, &notBefore, &secret.ShareTokenHash,
+		&secret.Opaque)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return model.Secret{}, model.ErrSecretNotFound
 		}
 		return model.Secret{}, err
 	}
+	if notBefore != nil {
+		secret.NotBefore = *notBefore
+	}
 	secret.Meta = make(map[string]string, len(dbMeta))
 	for k := range dbMeta {
-		secret.Meta[k] = *dbMeta[k]
+		if dbMeta[k] != nil {
+			secret.Meta[k] = *dbMeta[k]
+		}
 	}
-	secret.ID = id
-	secret.ExpireAt = secret.CreatedAt.Add(model.TTL)
 	return secret, nil
 }
 
-// DeleteByID удаляет секрет по идентификатору
+// FindByID ищет model.Secret по идентификатору и засчитывает его прочтение запросом UPDATE ... RETURNING.
+// Блокировка строки при обновлении гарантирует, что секрет с ограниченным числом прочтений
+// не будет прочитан больше max_views раз
+func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, error) {
+	secret, err := scanSecret(r.conn.QueryRow(ctx,
+		`UPDATE secret SET views = views + 1
+WHERE id = $1::uuid AND expire_at > $2 AND (max_views = 0 OR views < max_views)
+AND (not_before IS NULL OR not_before <= $2)
+RETURNING `+secretColumns,
+		id, time.Now().UTC(),
+	))
+	if err != nil {
+		return model.Secret{}, r.explainNotFound(ctx, id, err)
+	}
+	if secret.ViewsLeft() == 0 {
+		_, err = r.conn.Exec(ctx, "DELETE FROM secret WHERE id = $1::uuid AND views >= max_views", id)
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	return secret, nil
+}
+
+// Peek ищет model.Secret по идентификатору, не засчитывая его прочтение
+func (r *Repository) Peek(ctx context.Context, id string) (model.Secret, error) {
+	return scanSecret(r.conn.QueryRow(ctx,
+		"SELECT "+secretColumns+" FROM secret WHERE id = $1::uuid AND expire_at > $2",
+		id, time.Now().UTC(),
+	))
+}
+
+// FindIDByShareToken возвращает идентификатор секрета по хэшу токена ссылки
+func (r *Repository) FindIDByShareToken(ctx context.Context, tokenHash string) (string, error) {
+	var id string
+	err := r.conn.QueryRow(ctx,
+		"SELECT id FROM secret WHERE share_token_hash = $1 AND share_token_hash <> '' AND expire_at > $2",
+		tokenHash, time.Now().UTC(),
+	).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", model.ErrSecretNotFound
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// explainNotFound заменяет model.ErrSecretNotFound на *model.NotYetAvailableError,
+// если секрет не нашёлся только потому, что ещё не стал доступен
+func (r *Repository) explainNotFound(ctx context.Context, id string, err error) error {
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		return err
+	}
+	secret, pErr := r.Peek(ctx, id)
+	if pErr == nil && !secret.Active(time.Now()) {
+		return &model.NotYetAvailableError{NotBefore: secret.NotBefore}
+	}
+	return err
+}
+
+// RegisterFailedAttempt засчитывает неверную попытку ввода кодовой фразы, удаляя секрет, если попыток не осталось
+func (r *Repository) RegisterFailedAttempt(ctx context.Context, id string, maxAttempts int64) (int64, error) {
+	var attempts int64
+	err := r.conn.QueryRow(ctx,
+		`UPDATE secret SET failed_attempts = failed_attempts + 1
+WHERE id = $1::uuid AND expire_at > $2 RETURNING failed_attempts`,
+		id, time.Now().UTC(),
+	).Scan(&attempts)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, model.ErrSecretNotFound
+		}
+		return 0, err
+	}
+	if attempts < maxAttempts {
+		return maxAttempts - attempts, nil
+	}
+	_, err = r.conn.Exec(ctx, "DELETE FROM secret WHERE id = $1::uuid AND failed_attempts >= $2", id, maxAttempts)
+	return 0, err
+}
+
+// FindAndDeleteByID ищет model.Secret по идентификатору и удаляет его одним запросом DELETE ... RETURNING.
+// Секрет, который ещё не стал доступен, не удаляется
+func (r *Repository) FindAndDeleteByID(ctx context.Context, id string) (model.Secret, error) {
+	secret, err := scanSecret(r.conn.QueryRow(ctx,
+		`DELETE FROM secret WHERE id = $1::uuid AND (not_before IS NULL OR not_before <= $2 OR expire_at <= $2)
+RETURNING `+secretColumns,
+		id, time.Now().UTC(),
+	))
+	if err != nil {
+		return model.Secret{}, r.explainNotFound(ctx, id, err)
+	}
+	if secret.Expired() {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	secret.Views++
+	return secret, nil
+}
+
+// FindByRecipient возвращает секреты, адресованные субъекту или его группам, в порядке создания
+func (r *Repository) FindByRecipient(ctx context.Context, subject string, groups []string) ([]model.Secret, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT "+secretColumns+` FROM secret
+WHERE expire_at > $3 AND (recipients @> ARRAY[$1]::text[] OR recipient_groups && $2::text[])
+ORDER BY created_at`,
+		subject, nonNil(groups), time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := make([]model.Secret, 0)
+	for rows.Next() {
+		secret, sErr := scanSecret(rows)
+		if sErr != nil {
+			return nil, sErr
+		}
+		ret = append(ret, secret)
+	}
+	return ret, rows.Err()
+}
+
+// listColumns перечисляет те же колонки, что и secretColumns, но вместо тела секрета выбирает пустое значение
+const listColumns = "id,''::bytea AS body,meta,created_at,expire_at,views,max_views,passphrase_hash,failed_attempts," +
+	"key_id,wrapped_key,owner,recipients,recipient_groups,version,content_type,filename,not_before,share_token_hash,opaque"
+
+// List возвращает страницу секретов без тел, упорядоченных по времени создания и идентификатору
+func (r *Repository) List(ctx context.Context, filter repository.ListFilter) ([]model.Secret, string, error) {
+	cursorCreatedAt, cursorID, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	rows, err := r.conn.Query(ctx,
+		"SELECT "+listColumns+` FROM secret
+WHERE expire_at > $1 AND ($2 = '' OR owner = $2)
+AND ($3::timestamp IS NULL OR created_at >= $3::timestamp) AND ($4::timestamp IS NULL OR created_at < $4::timestamp)
+AND ($5 = '' OR meta ? $5) AND ($6::timestamp IS NULL OR (created_at, id) > ($6::timestamp, $7::uuid))
+ORDER BY created_at, id LIMIT $8`,
+		time.Now().UTC(), filter.Owner, nullTime(filter.CreatedAfter), nullTime(filter.CreatedBefore),
+		filter.MetaKey, nullTime(cursorCreatedAt), nullString(cursorID), filter.Limit+1,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.Secret, 0, filter.Limit+1)
+	for rows.Next() {
+		secret, sErr := scanSecret(rows)
+		if sErr != nil {
+			return nil, "", sErr
+		}
+		ret = append(ret, secret)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// nullTime превращает нулевое время в NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// nullString превращает пустую строку в NULL
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nonNil заменяет nil на пустой список, так как колонки с массивами не могут быть NULL
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// CountByOwner возвращает число не устаревших секретов создателя owner
+func (r *Repository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	var count int64
+	err := r.conn.QueryRow(ctx, "SELECT count(*) FROM secret WHERE owner = $1 AND expire_at > $2",
+		owner, time.Now().UTC(),
+	).Scan(&count)
+	return count, err
+}
+
+// Update заменяет тело, его тип и имя файла, метаданные, момент устаревания и ключ данных секрета в транзакции, блокируя строку
+// с помощью SELECT ... FOR UPDATE. Заменяемая версия копируется в таблицу secret_version
+func (r *Repository) Update(ctx context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error) {
+	dbMeta := make(pgtype.Hstore, 0)
+	for k, v := range secret.Meta {
+		v := v
+		dbMeta[k] = &v
+	}
+	now := time.Now().UTC()
+	var updated model.Secret
+	err := pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		var current int64
+		lErr := tx.QueryRow(ctx,
+			"SELECT version FROM secret WHERE id = $1::uuid AND expire_at > $2 FOR UPDATE",
+			secret.ID, now,
+		).Scan(&current)
+		if lErr != nil {
+			if lErr == pgx.ErrNoRows {
+				return model.ErrSecretNotFound
+			}
+			return lErr
+		}
+		if current != version {
+			return model.ErrVersionConflict
+		}
+		if keepVersions > 0 {
+			_, lErr = tx.Exec(ctx,
+				`INSERT INTO secret_version (secret_id, version, body, meta, expire_at, key_id, wrapped_key, replaced_at,
+content_type, filename)
+SELECT id, version, body, meta, expire_at, key_id, wrapped_key, $2, content_type, filename FROM secret WHERE id = $1::uuid`,
+				secret.ID, now,
+			)
+			if lErr != nil {
+				return lErr
+			}
+			_, lErr = tx.Exec(ctx,
+				"DELETE FROM secret_version WHERE secret_id = $1::uuid AND version <= $2",
+				secret.ID, version-keepVersions,
+			)
+			if lErr != nil {
+				return lErr
+			}
+		}
+		updated, lErr = scanSecret(tx.QueryRow(ctx,
+			`UPDATE secret SET body = $2, meta = $3::hstore, expire_at = $4, key_id = $5, wrapped_key = $6, version = version + 1,
+content_type = $7, filename = $8
+WHERE id = $1::uuid RETURNING `+secretColumns,
+			secret.ID, secret.Body, dbMeta, secret.ExpireAt.UTC(), secret.KeyID, secret.WrappedKey,
+			secret.ContentType, secret.Filename,
+		))
+		return lErr
+	})
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return updated, nil
+}
+
+// versionColumns перечисляет колонки, из которых собирается model.SecretVersion функцией scanVersion
+const versionColumns = "secret_id,version,body,meta,expire_at,key_id,wrapped_key,replaced_at,content_type,filename"
+
+// scanVersion собирает model.SecretVersion из строки результата запроса, выбравшего колонки versionColumns
+func scanVersion(row pgx.Row) (model.SecretVersion, error) {
+	var version model.SecretVersion
+	dbMeta := make(pgtype.Hstore, 0)
+	err := row.Scan(&version.SecretID, &version.Version, &version.Body, &dbMeta, &version.ExpireAt,
+		&version.KeyID, &version.WrappedKey, &version.ReplacedAt, &version.ContentType, &version.Filename)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.SecretVersion{}, model.ErrSecretNotFound
+		}
+		return model.SecretVersion{}, err
+	}
+	version.Meta = make(map[string]string, len(dbMeta))
+	for k := range dbMeta {
+		if dbMeta[k] != nil {
+			version.Meta[k] = *dbMeta[k]
+		}
+	}
+	return version, nil
+}
+
+// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой
+func (r *Repository) ListVersions(ctx context.Context, id string) ([]model.SecretVersion, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT secret_id,version,''::bytea AS body,meta,expire_at,key_id,wrapped_key,replaced_at,content_type,filename "+
+			"FROM secret_version "+
+			"WHERE secret_id = $1::uuid ORDER BY version DESC",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := make([]model.SecretVersion, 0)
+	for rows.Next() {
+		version, sErr := scanVersion(rows)
+		if sErr != nil {
+			return nil, sErr
+		}
+		ret = append(ret, version)
+	}
+	return ret, rows.Err()
+}
+
+// GetVersion возвращает прежнюю версию секрета
+func (r *Repository) GetVersion(ctx context.Context, id string, version int64) (model.SecretVersion, error) {
+	return scanVersion(r.conn.QueryRow(ctx,
+		"SELECT "+versionColumns+" FROM secret_version WHERE secret_id = $1::uuid AND version = $2",
+		id, version,
+	))
+}
+
+// RecordAccess добавляет прочтение в таблицу secret_access и удаляет из неё прочтения сверх keepAccesses последних
+func (r *Repository) RecordAccess(ctx context.Context, id string, access model.SecretAccess, keepAccesses int64) error {
+	return pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		tag, lErr := tx.Exec(ctx,
+			`INSERT INTO secret_access (secret_id, subject, accessed_at)
+SELECT id, $2, $3 FROM secret WHERE id = $1::uuid AND expire_at > $4`,
+			id, access.Subject, access.AccessedAt.UTC(), time.Now().UTC(),
+		)
+		if lErr != nil {
+			return lErr
+		}
+		if tag.RowsAffected() == 0 {
+			return model.ErrSecretNotFound
+		}
+		_, lErr = tx.Exec(ctx,
+			`DELETE FROM secret_access WHERE secret_id = $1::uuid AND id NOT IN
+(SELECT id FROM secret_access WHERE secret_id = $1::uuid ORDER BY id DESC LIMIT $2)`,
+			id, keepAccesses,
+		)
+		return lErr
+	})
+}
+
+// ListAccesses возвращает журнал прочтений секрета, начиная с самого раннего прочтения
+func (r *Repository) ListAccesses(ctx context.Context, id string) ([]model.SecretAccess, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT subject, accessed_at FROM secret_access WHERE secret_id = $1::uuid ORDER BY id",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := make([]model.SecretAccess, 0)
+	for rows.Next() {
+		var access model.SecretAccess
+		err = rows.Scan(&access.Subject, &access.AccessedAt)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, access)
+	}
+	return ret, rows.Err()
+}
+
+// DeleteByID удаляет секрет по идентификатору, прежние версии и журнал прочтений удаляются каскадно
 func (r *Repository) DeleteByID(ctx context.Context, id string) error {
 	_, err := r.conn.Exec(ctx, "DELETE FROM secret WHERE id = $1::uuid", id)
 	return err
 }
 
 // Prune удаляет старые секреты
-func (r *Repository) Prune(ctx context.Context) error {
-	_, err := r.conn.Exec(ctx, "DELETE FROM secret WHERE created_at < $1", time.Now().Add(-model.TTL))
-	return err
+func (r *Repository) Prune(ctx context.Context) ([]string, error) {
+	rows, err := r.conn.Query(ctx, "DELETE FROM secret WHERE expire_at < $1 RETURNING id", time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	pruned := make([]string, 0)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		pruned = append(pruned, id)
+	}
+	return pruned, rows.Err()
+}
+
+// ListWrappedKeys возвращает обёрнутые ключи данных секретов, упорядоченных по идентификатору
+func (r *Repository) ListWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.Secret, string, error) {
+	var after *string
+	if cursor != "" {
+		after = &cursor
+	}
+	rows, err := r.conn.Query(ctx,
+		`SELECT id, key_id, wrapped_key FROM secret
+WHERE key_id <> '' AND key_id <> $1 AND ($2::uuid IS NULL OR id > $2::uuid) AND expire_at > $3
+ORDER BY id LIMIT $4`,
+		exceptKeyID, after, time.Now().UTC(), limit,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.Secret, 0, limit)
+	for rows.Next() {
+		var secret model.Secret
+		err = rows.Scan(&secret.ID, &secret.KeyID, &secret.WrappedKey)
+		if err != nil {
+			return nil, "", err
+		}
+		ret = append(ret, secret)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if int64(len(ret)) == limit {
+		next = ret[len(ret)-1].ID
+	}
+	return ret, next, nil
+}
+
+// UpdateWrappedKey заменяет обёрнутый ключ данных секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateWrappedKey(ctx context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE secret SET key_id = $3, wrapped_key = $4 WHERE id = $1::uuid AND key_id = $2",
+		id, oldKeyID, newKeyID, wrapped,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
+}
+
+// ListVersionWrappedKeys возвращает обёрнутые ключи данных прежних версий действующих секретов,
+// упорядоченных по идентификатору секрета и номеру версии
+func (r *Repository) ListVersionWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.SecretVersion, string, error) {
+	afterID, afterVersion, err := repository.DecodeVersionCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var after *string
+	if afterID != "" {
+		after = &afterID
+	}
+	rows, err := r.conn.Query(ctx,
+		`SELECT v.secret_id, v.version, v.key_id, v.wrapped_key FROM secret_version v JOIN secret s ON s.id = v.secret_id
+WHERE v.key_id <> '' AND v.key_id <> $1 AND ($2::uuid IS NULL OR (v.secret_id, v.version) > ($2::uuid, $3))
+AND s.expire_at > $4
+ORDER BY v.secret_id, v.version LIMIT $5`,
+		exceptKeyID, after, afterVersion, time.Now().UTC(), limit,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	ret := make([]model.SecretVersion, 0, limit)
+	for rows.Next() {
+		var version model.SecretVersion
+		err = rows.Scan(&version.SecretID, &version.Version, &version.KeyID, &version.WrappedKey)
+		if err != nil {
+			return nil, "", err
+		}
+		ret = append(ret, version)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if int64(len(ret)) == limit {
+		next = repository.EncodeVersionCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// UpdateVersionWrappedKey заменяет обёрнутый ключ данных прежней версии секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateVersionWrappedKey(ctx context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE secret_version SET key_id = $4, wrapped_key = $5 WHERE secret_id = $1::uuid AND version = $2 AND key_id = $3",
+		id, version, oldKeyID, newKeyID, wrapped,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
 }
@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vodolaz095/purser/model"
+)
+
+// requestColumns перечисляет колонки, из которых собирается model.SecretRequest функцией scanRequest
+const requestColumns = "id,requester,description,token_hash,created_at,expire_at,secret_id,fulfilled_at"
+
+// scanRequest собирает model.SecretRequest из строки результата запроса, выбравшего колонки requestColumns
+func scanRequest(row pgx.Row) (model.SecretRequest, error) {
+	var request model.SecretRequest
+	var fulfilledAt *time.Time
+	err := row.Scan(&request.ID, &request.Requester, &request.Description, &request.TokenHash,
+		&request.CreatedAt, &request.ExpireAt, &request.SecretID, &fulfilledAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.SecretRequest{}, model.ErrRequestNotFound
+		}
+		return model.SecretRequest{}, err
+	}
+	if fulfilledAt != nil {
+		request.FulfilledAt = *fulfilledAt
+	}
+	return request, nil
+}
+
+// CreateRequest сохраняет новый запрос секрета
+func (r *Repository) CreateRequest(ctx context.Context, request model.SecretRequest) (model.SecretRequest, error) {
+	err := r.conn.QueryRow(ctx,
+		`INSERT INTO secret_request (requester, description, token_hash, created_at, expire_at)
+VALUES ($1,$2,$3,$4,$5) RETURNING id;`,
+		request.Requester, request.Description, request.TokenHash, request.CreatedAt.UTC(), request.ExpireAt.UTC(),
+	).Scan(&request.ID)
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	request.Token = ""
+	return request, nil
+}
+
+// FindRequest ищет запрос секрета по идентификатору
+func (r *Repository) FindRequest(ctx context.Context, id string) (model.SecretRequest, error) {
+	return scanRequest(r.conn.QueryRow(ctx,
+		"SELECT "+requestColumns+" FROM secret_request WHERE id = $1::uuid AND expire_at > $2",
+		id, time.Now().UTC(),
+	))
+}
+
+// FindRequestByToken ищет запрос секрета по хэшу токена ссылки
+func (r *Repository) FindRequestByToken(ctx context.Context, tokenHash string) (model.SecretRequest, error) {
+	return scanRequest(r.conn.QueryRow(ctx,
+		"SELECT "+requestColumns+" FROM secret_request WHERE token_hash = $1 AND expire_at > $2",
+		tokenHash, time.Now().UTC(),
+	))
+}
+
+// FulfilRequest отмечает, что по запросу прислали секрет, запросом UPDATE с условием, что секрета ещё нет
+func (r *Repository) FulfilRequest(ctx context.Context, id, secretID string, fulfilledAt time.Time) error {
+	tag, err := r.conn.Exec(ctx,
+		`UPDATE secret_request SET secret_id = $2, fulfilled_at = $3
+WHERE id = $1::uuid AND expire_at > $4 AND secret_id = ''`,
+		id, secretID, fulfilledAt.UTC(), time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+	request, err := r.FindRequest(ctx, id)
+	if err != nil {
+		return err
+	}
+	if request.Fulfilled() {
+		return model.ErrRequestFulfilled
+	}
+	return model.ErrRequestNotFound
+}
+
+// DeleteRequest удаляет запрос секрета
+func (r *Repository) DeleteRequest(ctx context.Context, id string) error {
+	_, err := r.conn.Exec(ctx, "DELETE FROM secret_request WHERE id = $1::uuid", id)
+	return err
+}
+
+// PruneRequests удаляет устаревшие запросы секретов
+func (r *Repository) PruneRequests(ctx context.Context) error {
+	_, err := r.conn.Exec(ctx, "DELETE FROM secret_request WHERE expire_at < $1", time.Now().UTC())
+	return err
+}
@@ -0,0 +1,43 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vodolaz095/purser/model"
+)
+
+// SaveTombstone сохраняет запись об исчезнувшем секрете
+func (r *Repository) SaveTombstone(ctx context.Context, tombstone model.Tombstone) error {
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO tombstone (secret_id, reason, deleted_at, expire_at) VALUES ($1,$2,$3,$4)
+ON CONFLICT (secret_id) DO UPDATE SET reason = $2, deleted_at = $3, expire_at = $4`,
+		tombstone.SecretID, string(tombstone.Reason), tombstone.DeletedAt.UTC(), tombstone.ExpireAt.UTC(),
+	)
+	return err
+}
+
+// FindTombstone ищет запись об исчезнувшем секрете
+func (r *Repository) FindTombstone(ctx context.Context, secretID string) (model.Tombstone, error) {
+	var tombstone model.Tombstone
+	var reason string
+	err := r.conn.QueryRow(ctx,
+		"SELECT secret_id, reason, deleted_at, expire_at FROM tombstone WHERE secret_id = $1 AND expire_at > $2",
+		secretID, time.Now().UTC(),
+	).Scan(&tombstone.SecretID, &reason, &tombstone.DeletedAt, &tombstone.ExpireAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.Tombstone{}, model.ErrSecretNotFound
+		}
+		return model.Tombstone{}, err
+	}
+	tombstone.Reason = model.TombstoneReason(reason)
+	return tombstone, nil
+}
+
+// PruneTombstones удаляет устаревшие записи об исчезнувших секретах
+func (r *Repository) PruneTombstones(ctx context.Context) error {
+	_, err := r.conn.Exec(ctx, "DELETE FROM tombstone WHERE expire_at < $1", time.Now().UTC())
+	return err
+}
@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/vodolaz095/purser/model"
+)
+
+// SaveSubscription сохраняет подписку на события секрета
+func (r *Repository) SaveSubscription(ctx context.Context, subscription model.WebhookSubscription) error {
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO webhook_subscription (secret_id, owner, url, read, expire_at) VALUES ($1,$2,$3,$4,$5)
+ON CONFLICT (secret_id) DO UPDATE SET owner = $2, url = $3, read = $4, expire_at = $5`,
+		subscription.SecretID, subscription.Owner, subscription.URL, subscription.Read, subscription.ExpireAt.UTC(),
+	)
+	return err
+}
+
+// FindSubscription ищет подписку на события секрета
+func (r *Repository) FindSubscription(ctx context.Context, secretID string) (model.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+	err := r.conn.QueryRow(ctx,
+		`SELECT secret_id, owner, url, read, expire_at FROM webhook_subscription
+WHERE secret_id = $1 AND expire_at > $2`, secretID, time.Now().UTC(),
+	).Scan(&subscription.SecretID, &subscription.Owner, &subscription.URL, &subscription.Read, &subscription.ExpireAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return model.WebhookSubscription{}, model.ErrSubscriptionNotFound
+		}
+		return model.WebhookSubscription{}, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription удаляет подписку на события секрета
+func (r *Repository) DeleteSubscription(ctx context.Context, secretID string) error {
+	_, err := r.conn.Exec(ctx, "DELETE FROM webhook_subscription WHERE secret_id = $1", secretID)
+	return err
+}
+
+// PruneSubscriptions удаляет устаревшие подписки
+func (r *Repository) PruneSubscriptions(ctx context.Context) error {
+	_, err := r.conn.Exec(ctx, "DELETE FROM webhook_subscription WHERE expire_at < $1", time.Now().UTC())
+	return err
+}
+
+// RecordDeadLetter сохраняет недоставленное уведомление
+func (r *Repository) RecordDeadLetter(ctx context.Context, delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	err := r.conn.QueryRow(ctx,
+		`INSERT INTO webhook_dead_letter (secret_id, url, event_type, payload, attempts, last_error, created_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id`,
+		delivery.SecretID, delivery.URL, string(delivery.EventType), delivery.Payload, delivery.Attempts,
+		delivery.LastError, delivery.CreatedAt.UTC(),
+	).Scan(&delivery.ID)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+// ListDeadLetters возвращает недоставленные уведомления о событиях секрета
+func (r *Repository) ListDeadLetters(ctx context.Context, secretID string) ([]model.WebhookDelivery, error) {
+	rows, err := r.conn.Query(ctx,
+		`SELECT id, secret_id, url, event_type, payload, attempts, last_error, created_at FROM webhook_dead_letter
+WHERE secret_id = $1 ORDER BY created_at, id`, secretID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := make([]model.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		var eventType string
+		err = rows.Scan(&delivery.ID, &delivery.SecretID, &delivery.URL, &eventType, &delivery.Payload,
+			&delivery.Attempts, &delivery.LastError, &delivery.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		delivery.EventType = model.WebhookEventType(eventType)
+		ret = append(ret, delivery)
+	}
+	return ret, rows.Err()
+}
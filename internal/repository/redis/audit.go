@@ -0,0 +1,152 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+)
+
+// auditPrefix задаёт префикс потоков журнала аудита. Все события пишутся в общий поток, а также в потоки секрета
+// и субъекта, чтобы выборка по ним не просматривала весь журнал
+const auditPrefix = "audit"
+
+// auditKey возвращает ключ потока событий секрета (kind = secret), субъекта (kind = subject) или, для пустого kind, всех событий
+func auditKey(kind, name string) string {
+	if kind == "" {
+		return auditPrefix
+	}
+	return auditPrefix + ":" + kind + ":" + name
+}
+
+// recordScript атомарно добавляет событие в общий поток и с тем же идентификатором - в остальные потоки,
+// так что идентификаторы во всех потоках возрастают
+var recordScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], '*', unpack(ARGV))
+for i = 2, #KEYS do
+  redis.call('XADD', KEYS[i], id, unpack(ARGV))
+end
+return id
+`)
+
+// streamTime возвращает момент, закодированный в идентификаторе записи потока
+func streamTime(id string) (time.Time, error) {
+	ms, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// Record сохраняет событие журнала аудита в потоки redis. Идентификатором события служит идентификатор записи потока,
+// а моментом события - время сервера redis, закодированное в нём, чтобы выборка по времени совпадала с порядком в потоке
+func (r *Repository) Record(ctx context.Context, event model.AuditEvent) (model.AuditEvent, error) {
+	keys := []string{auditKey("", ""), auditKey("secret", event.SecretID)}
+	if event.Subject != "" {
+		keys = append(keys, auditKey("subject", event.Subject))
+	}
+	id, err := recordScript.Run(ctx, r.client, keys,
+		"secret_id", event.SecretID,
+		"action", string(event.Action),
+		"subject", event.Subject,
+		"transport", event.Transport,
+		"remote_ip", event.RemoteIP,
+		"user_agent", event.UserAgent,
+		"trace_id", event.TraceID,
+		"detail", event.Detail,
+	).Text()
+	if err != nil {
+		return model.AuditEvent{}, err
+	}
+	event.ID = id
+	event.CreatedAt, err = streamTime(id)
+	if err != nil {
+		return model.AuditEvent{}, err
+	}
+	return event, nil
+}
+
+// decodeEvent собирает model.AuditEvent из записи потока
+func decodeEvent(message redis.XMessage) (model.AuditEvent, error) {
+	createdAt, err := streamTime(message.ID)
+	if err != nil {
+		return model.AuditEvent{}, err
+	}
+	field := func(name string) string {
+		value, _ := message.Values[name].(string)
+		return value
+	}
+	return model.AuditEvent{
+		ID:        message.ID,
+		SecretID:  field("secret_id"),
+		Action:    model.AuditAction(field("action")),
+		Subject:   field("subject"),
+		Transport: field("transport"),
+		RemoteIP:  field("remote_ip"),
+		UserAgent: field("user_agent"),
+		TraceID:   field("trace_id"),
+		Detail:    field("detail"),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// Query выбирает события из потока секрета, субъекта или общего потока, диапазон времени задаётся
+// диапазоном идентификаторов записей, а курсором служит идентификатор последней записи на странице
+func (r *Repository) Query(ctx context.Context, filter repository.AuditFilter) ([]model.AuditEvent, string, error) {
+	key := auditKey("", "")
+	if filter.SecretID != "" {
+		key = auditKey("secret", filter.SecretID)
+	} else if filter.Subject != "" {
+		key = auditKey("subject", filter.Subject)
+	}
+	start := "-"
+	if !filter.Since.IsZero() {
+		start = strconv.FormatInt(filter.Since.UnixMilli(), 10)
+	}
+	if filter.Cursor != "" {
+		_, err := streamTime(filter.Cursor)
+		if err != nil {
+			return nil, "", model.ErrInvalidCursor
+		}
+		start = "(" + filter.Cursor
+	}
+	end := "+"
+	if !filter.Until.IsZero() {
+		end = "(" + strconv.FormatInt(filter.Until.UnixMilli(), 10) + "-0"
+	}
+	ret := make([]model.AuditEvent, 0, filter.Limit+1)
+	batch := filter.Limit + 1
+	for {
+		messages, err := r.client.XRangeN(ctx, key, start, end, batch).Result()
+		if err != nil {
+			return nil, "", err
+		}
+		for i := range messages {
+			event, dErr := decodeEvent(messages[i])
+			if dErr != nil {
+				return nil, "", dErr
+			}
+			if !filter.Match(event) {
+				continue
+			}
+			ret = append(ret, event)
+			if int64(len(ret)) > filter.Limit {
+				break
+			}
+		}
+		if int64(len(ret)) > filter.Limit || int64(len(messages)) < batch {
+			break
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = ret[len(ret)-1].ID
+	}
+	return ret, next, nil
+}
@@ -2,14 +2,20 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/model"
 	"github.com/vodolaz095/purser/pkg/misc"
 )
 
-// Repository реализует интерфейс SecretRepo с базой данных redis внутри
+// Repository реализует интерфейсы SecretRepo, AuditRepo, WebhookRepo, TombstoneRepo и RequestRepo с базой данных redis внутри
 type Repository struct {
 	RedisConnectionString string
 	client                *redis.Client
@@ -35,32 +41,255 @@ func (r *Repository) Close(ctx context.Context) error {
 	return r.client.Close()
 }
 
-// Create создаёт новый model.Secret
-func (r *Repository) Create(ctx context.Context, body string, meta map[string]string) (model.Secret, error) {
-	id := misc.UUID()
-	meta["body"] = body
-	pipe := r.client.Pipeline()
-	for k := range meta {
-		pipe.HSet(ctx, id, k, meta[k])
+// metaPrefix задаёт префикс для полей хэша, в которых хранятся метаданные секрета,
+// чтобы они не пересекались со служебными полями
+const metaPrefix = "meta:"
+
+// inboxPrefix задаёт префикс для множеств идентификаторов секретов, адресованных субъекту или группе
+const inboxPrefix = "inbox:"
+
+// inboxKey возвращает ключ множества секретов, адресованных субъекту (kind = subject) или группе (kind = group)
+func inboxKey(kind, name string) string {
+	return inboxPrefix + kind + ":" + name
+}
+
+// versionsSuffix задаёт суффикс ключа хэша, в котором хранятся прежние версии секрета.
+// Поля хэша называются "<версия>|<поле секрета>", чтобы версии не смешивались между собой
+const versionsSuffix = ":versions"
+
+// versionsKey возвращает ключ хэша прежних версий секрета
+func versionsKey(id string) string {
+	return id + versionsSuffix
+}
+
+// accessesSuffix задаёт суффикс ключа списка, в котором хранится журнал прочтений секрета.
+// Элементы списка - прочтения в JSON, начиная с самого раннего
+const accessesSuffix = ":accesses"
+
+// accessesKey возвращает ключ списка прочтений секрета
+func accessesKey(id string) string {
+	return id + accessesSuffix
+}
+
+// indexPrefix задаёт префикс для сортированных множеств идентификаторов секретов, упорядоченных по времени создания
+const indexPrefix = "index:"
+
+// indexKey возвращает ключ сортированного множества секретов создателя owner, а для пустого owner - всех секретов
+func indexKey(owner string) string {
+	if owner == "" {
+		return indexPrefix + "all"
+	}
+	return indexPrefix + "owner:" + owner
+}
+
+// indexScore возвращает вес секрета в сортированных множествах - время создания в микросекундах,
+// чтобы оно точно помещалось в число с плавающей точкой
+func indexScore(createdAt time.Time) float64 {
+	return float64(createdAt.UnixMicro())
+}
+
+// usagePrefix задаёт префикс для сортированных множеств идентификаторов секретов создателя, упорядоченных
+// по моменту устаревания, по которым считается, сколько у создателя действующих секретов
+const usagePrefix = "usage:"
+
+// usageKey возвращает ключ сортированного множества секретов создателя owner, упорядоченных по моменту устаревания
+func usageKey(owner string) string {
+	return usagePrefix + owner
+}
+
+// sharePrefix задаёт префикс для ключей, по которым хэш токена ссылки указывает на идентификатор секрета.
+// Ключи устаревают вместе с секретом, а если секрет сожгли или удалили раньше, ключ удаляется вместе с ним
+const sharePrefix = "share:"
+
+// shareKey возвращает ключ, в котором хранится идентификатор секрета с токеном ссылки, хэш которого tokenHash
+func shareKey(tokenHash string) string {
+	return sharePrefix + tokenHash
+}
+
+// expiryKey задаёт ключ сортированного множества идентификаторов всех секретов, упорядоченных по моменту устаревания.
+// Секреты удаляются из него, когда их удаляют или сжигают, так что в нём остаются только секреты,
+// которые устарели, и Prune узнаёт, какие секреты устарели, хотя их хэши удалил сам redis
+const expiryKey = "expiry"
+
+// extendExpireScript продлевает срок жизни ключа, если он короче заданного в миллисекундах, и никогда его не сокращает
+var extendExpireScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < tonumber(ARGV[1]) then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// destroyLua - фрагмент Lua скриптов с функцией destroy, которая удаляет секрет KEYS[1] вместе с его прежними
+// версиями KEYS[2] и журналом прочтений KEYS[4], убирает его из множества сроков устаревания KEYS[3] и из множества
+// секретов его создателя, префикс которого передаётся в usagePrefix, и удаляет ключ его токена ссылки,
+// префикс которого передаётся в sharePrefix
+const destroyLua = `
+local function destroy(sharePrefix, usagePrefix)
+  local owner = redis.call('HGET', KEYS[1], 'owner')
+  if owner and owner ~= '' then
+    redis.call('ZREM', usagePrefix .. owner, KEYS[1])
+  end
+  local shareTokenHash = redis.call('HGET', KEYS[1], 'share_token_hash')
+  if shareTokenHash and shareTokenHash ~= '' then
+    local shareKey = sharePrefix .. shareTokenHash
+    if redis.call('GET', shareKey) == KEYS[1] then
+      redis.call('DEL', shareKey)
+    end
+  end
+  redis.call('DEL', KEYS[1], KEYS[2], KEYS[4])
+  redis.call('ZREM', KEYS[3], KEYS[1])
+end
+`
+
+// Create создаёт новый model.Secret и добавляет его во входящие адресатов
+func (r *Repository) Create(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	secret.ID = misc.UUID()
+	return r.save(ctx, secret)
+}
+
+// reserveScript атомарно убирает из сортированного множества секретов создателя KEYS[1] секреты, устаревшие
+// к моменту ARGV[1] в миллисекундах, и, если в нём осталось меньше ARGV[2] секретов, добавляет в него секрет ARGV[4]
+// с моментом устаревания ARGV[3]. Возвращает 1, если секрет добавлен, и 0, если ограничение исчерпано
+var reserveScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[2]) then
+  return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[4])
+return 1
+`)
+
+// CreateWithinQuota занимает место в сортированном множестве секретов создателя Lua скриптом и только после этого
+// сохраняет секрет. Если сохранить секрет не удалось, место освобождается
+func (r *Repository) CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	secret.ID = misc.UUID()
+	key := usageKey(secret.Owner)
+	reserved, err := reserveScript.Run(ctx, r.client, []string{key},
+		time.Now().UnixMilli(), maxSecrets, secret.ExpireAt.UnixMilli(), secret.ID).Int64()
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if reserved == 0 {
+		return model.Secret{}, model.ErrQuotaExceeded
+	}
+	created, err := r.save(ctx, secret)
+	if err != nil {
+		r.client.ZRem(ctx, key, secret.ID)
+		return model.Secret{}, err
 	}
-	pipe.Expire(ctx, id, model.TTL)
-	_, err := pipe.Exec(ctx)
+	return created, nil
+}
+
+// save сохраняет model.Secret с уже назначенным идентификатором одной транзакцией
+func (r *Repository) save(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	recipients, err := json.Marshal(secret.Recipients)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	groups, err := json.Marshal(secret.Groups)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	fields := make(map[string]interface{}, len(secret.Meta)+15)
+	for k := range secret.Meta {
+		fields[metaPrefix+k] = secret.Meta[k]
+	}
+	fields["body"] = secret.Body
+	fields["content_type"] = secret.ContentType
+	fields["filename"] = secret.Filename
+	fields["created_at"] = secret.CreatedAt.Format(time.RFC3339Nano)
+	fields["expire_at"] = secret.ExpireAt.Format(time.RFC3339Nano)
+	if !secret.NotBefore.IsZero() {
+		// момент доступности хранится в миллисекундах, чтобы Lua скрипты могли сравнить его с текущим временем
+		fields["not_before"] = secret.NotBefore.UnixMilli()
+	}
+	fields["views"] = secret.Views
+	fields["max_views"] = secret.MaxViews
+	fields["version"] = secret.Version
+	fields["passphrase_hash"] = secret.PassphraseHash
+	fields["failed_attempts"] = secret.FailedAttempts
+	fields["key_id"] = secret.KeyID
+	fields["wrapped_key"] = secret.WrappedKey
+	fields["owner"] = secret.Owner
+	fields["recipients"] = recipients
+	fields["recipient_groups"] = groups
+	fields["share_token_hash"] = secret.ShareTokenHash
+	fields["opaque"] = secret.Opaque
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, secret.ID, fields)
+	pipe.ExpireAt(ctx, secret.ID, secret.ExpireAt)
+	if secret.ShareTokenHash != "" {
+		pipe.Set(ctx, shareKey(secret.ShareTokenHash), secret.ID, 0)
+		pipe.ExpireAt(ctx, shareKey(secret.ShareTokenHash), secret.ExpireAt)
+	}
+	inboxTTL := time.Until(secret.ExpireAt).Milliseconds()
+	for _, key := range inboxKeys(secret.Recipients, secret.Groups) {
+		pipe.SAdd(ctx, key, secret.ID)
+		extendExpireScript.Eval(ctx, pipe, []string{key}, inboxTTL)
+	}
+	indexKeys := []string{indexKey("")}
+	if secret.Owner != "" {
+		indexKeys = append(indexKeys, indexKey(secret.Owner))
+	}
+	for _, key := range indexKeys {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: indexScore(secret.CreatedAt), Member: secret.ID})
+		extendExpireScript.Eval(ctx, pipe, []string{key}, inboxTTL)
+	}
+	if secret.Owner != "" {
+		pipe.ZAdd(ctx, usageKey(secret.Owner), &redis.Z{Score: float64(secret.ExpireAt.UnixMilli()), Member: secret.ID})
+		extendExpireScript.Eval(ctx, pipe, []string{usageKey(secret.Owner)}, inboxTTL)
+	}
+	pipe.ZAdd(ctx, expiryKey, &redis.Z{Score: float64(secret.ExpireAt.UnixMilli()), Member: secret.ID})
+	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return model.Secret{}, err
 	}
-	delete(meta, "body")
-	return model.Secret{
-		ID:        id,
-		Body:      body,
-		Meta:      meta,
-		CreatedAt: time.Now(),
-		ExpireAt:  time.Now().Add(model.TTL),
-	}, nil
+	return secret, nil
 }
 
-// FindByID ищет model.Secret по идентификатору
+// findScript атомарно засчитывает прочтение секрета с помощью HINCRBY и возвращает его хэш,
+// удаляя секрет, если число прочтений достигло max_views. Если секрет станет доступен только
+// после момента ARGV[1] в миллисекундах, прочтение не засчитывается, а возвращается момент его доступности
+var findScript = redis.NewScript(destroyLua + `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return {}
+end
+local notBefore = tonumber(redis.call('HGET', KEYS[1], 'not_before') or '0')
+if notBefore > tonumber(ARGV[1]) then
+  return notBefore
+end
+local views = redis.call('HINCRBY', KEYS[1], 'views', 1)
+local data = redis.call('HGETALL', KEYS[1])
+local maxViews = tonumber(redis.call('HGET', KEYS[1], 'max_views') or '0')
+if maxViews > 0 and views >= maxViews then
+  destroy(ARGV[2], ARGV[3])
+end
+return data
+`)
+
+// FindByID ищет model.Secret по идентификатору и засчитывает его прочтение
 func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, error) {
-	var ret model.Secret
+	res, err := findScript.Run(ctx, r.client, []string{id, versionsKey(id), expiryKey, accessesKey(id)},
+		time.Now().UnixMilli(), sharePrefix, usagePrefix).Result()
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if notBefore, ok := res.(int64); ok {
+		return model.Secret{}, &model.NotYetAvailableError{NotBefore: time.UnixMilli(notBefore)}
+	}
+	raw, err := toMap(res)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if len(raw) == 0 {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	return decode(id, raw)
+}
+
+// Peek ищет model.Secret по идентификатору, не засчитывая его прочтение
+func (r *Repository) Peek(ctx context.Context, id string) (model.Secret, error) {
 	raw, err := r.client.HGetAll(ctx, id).Result()
 	if err != nil {
 		return model.Secret{}, err
@@ -68,28 +297,781 @@ func (r *Repository) FindByID(ctx context.Context, id string) (model.Secret, err
 	if len(raw) == 0 {
 		return model.Secret{}, model.ErrSecretNotFound
 	}
-	ret.ID = id
-	ret.Body = raw["body"]
-	delete(raw, "body")
-	ret.Meta = raw
-	ttl, err := r.client.TTL(ctx, id).Result()
+	return decode(id, raw)
+}
+
+// failedAttemptScript атомарно засчитывает неверную попытку ввода кодовой фразы,
+// удаляя секрет, если попыток не осталось
+var failedAttemptScript = redis.NewScript(destroyLua + `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return -1
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'failed_attempts', 1)
+local maxAttempts = tonumber(ARGV[1])
+if attempts >= maxAttempts then
+  destroy(ARGV[2], ARGV[3])
+  return 0
+end
+return maxAttempts - attempts
+`)
+
+// FindIDByShareToken возвращает идентификатор секрета по хэшу токена ссылки
+func (r *Repository) FindIDByShareToken(ctx context.Context, tokenHash string) (string, error) {
+	id, err := r.client.Get(ctx, shareKey(tokenHash)).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return model.Secret{}, model.ErrSecretNotFound
+			return "", model.ErrSecretNotFound
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// RegisterFailedAttempt засчитывает неверную попытку ввода кодовой фразы, удаляя секрет, если попыток не осталось
+func (r *Repository) RegisterFailedAttempt(ctx context.Context, id string, maxAttempts int64) (int64, error) {
+	left, err := failedAttemptScript.Run(ctx, r.client, []string{id, versionsKey(id), expiryKey, accessesKey(id)},
+		maxAttempts, sharePrefix, usagePrefix).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if left < 0 {
+		return 0, model.ErrSecretNotFound
+	}
+	return left, nil
+}
+
+// findAndDeleteScript атомарно читает хэш секрета и удаляет его. Если секрет станет доступен только
+// после момента ARGV[1] в миллисекундах, он не удаляется, а возвращается момент его доступности
+var findAndDeleteScript = redis.NewScript(destroyLua + `
+local notBefore = tonumber(redis.call('HGET', KEYS[1], 'not_before') or '0')
+if notBefore > tonumber(ARGV[1]) then
+  return notBefore
+end
+local data = redis.call('HGETALL', KEYS[1])
+if #data > 0 then
+  destroy(ARGV[2], ARGV[3])
+end
+return data
+`)
+
+// FindAndDeleteByID ищет model.Secret по идентификатору и удаляет его одним Lua скриптом
+func (r *Repository) FindAndDeleteByID(ctx context.Context, id string) (model.Secret, error) {
+	res, err := findAndDeleteScript.Run(ctx, r.client, []string{id, versionsKey(id), expiryKey, accessesKey(id)},
+		time.Now().UnixMilli(), sharePrefix, usagePrefix).Result()
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if notBefore, ok := res.(int64); ok {
+		return model.Secret{}, &model.NotYetAvailableError{NotBefore: time.UnixMilli(notBefore)}
+	}
+	raw, err := toMap(res)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if len(raw) == 0 {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	secret, err := decode(id, raw)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	secret.Views++
+	return secret, nil
+}
+
+// toMap превращает результат HGETALL, полученный из Lua скрипта, в словарь
+func toMap(res interface{}) (map[string]string, error) {
+	list, ok := res.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected script result %T", res)
+	}
+	ret := make(map[string]string, len(list)/2)
+	for i := 0; i+1 < len(list); i += 2 {
+		k, kOk := list[i].(string)
+		v, vOk := list[i+1].(string)
+		if !kOk || !vOk {
+			return nil, fmt.Errorf("unexpected script result item %T=%T", list[i], list[i+1])
+		}
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+// decode собирает model.Secret из полей хэша
+func decode(id string, raw map[string]string) (ret model.Secret, err error) {
+	ret.ID = id
+	ret.Body = []byte(raw["body"])
+	ret.ContentType = raw["content_type"]
+	ret.Filename = raw["filename"]
+	ret.CreatedAt, err = time.Parse(time.RFC3339Nano, raw["created_at"])
+	if err != nil {
+		return model.Secret{}, err
+	}
+	ret.ExpireAt, err = time.Parse(time.RFC3339Nano, raw["expire_at"])
+	if err != nil {
+		return model.Secret{}, err
+	}
+	if raw["not_before"] != "" {
+		notBefore, nErr := parseInt(raw["not_before"])
+		if nErr != nil {
+			return model.Secret{}, nErr
 		}
+		ret.NotBefore = time.UnixMilli(notBefore)
+	}
+	ret.Views, err = parseInt(raw["views"])
+	if err != nil {
+		return model.Secret{}, err
+	}
+	ret.MaxViews, err = parseInt(raw["max_views"])
+	if err != nil {
+		return model.Secret{}, err
+	}
+	// у секретов, созданных до появления версий, версия первая
+	ret.Version = 1
+	if raw["version"] != "" {
+		ret.Version, err = parseInt(raw["version"])
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	ret.PassphraseHash = raw["passphrase_hash"]
+	ret.FailedAttempts, err = parseInt(raw["failed_attempts"])
+	if err != nil {
 		return model.Secret{}, err
 	}
-	ret.ExpireAt = time.Now().Add(ttl)
-	ret.CreatedAt = ret.ExpireAt.Add(-model.TTL) // lol
+	ret.Owner = raw["owner"]
+	ret.ShareTokenHash = raw["share_token_hash"]
+	ret.Opaque = raw["opaque"] == "1"
+	if raw["recipients"] != "" {
+		err = json.Unmarshal([]byte(raw["recipients"]), &ret.Recipients)
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	if raw["recipient_groups"] != "" {
+		err = json.Unmarshal([]byte(raw["recipient_groups"]), &ret.Groups)
+		if err != nil {
+			return model.Secret{}, err
+		}
+	}
+	ret.KeyID = raw["key_id"]
+	if raw["wrapped_key"] != "" {
+		ret.WrappedKey = []byte(raw["wrapped_key"])
+	}
+	ret.Meta = make(map[string]string, 0)
+	for k := range raw {
+		if strings.HasPrefix(k, metaPrefix) {
+			ret.Meta[strings.TrimPrefix(k, metaPrefix)] = raw[k]
+		}
+	}
 	return ret, nil
 }
 
-// DeleteByID удаляет секрет по идентификатору
+// inboxKeys возвращает ключи множеств входящих для субъектов и групп
+func inboxKeys(subjects, groups []string) []string {
+	ret := make([]string, 0, len(subjects)+len(groups))
+	for i := range subjects {
+		ret = append(ret, inboxKey("subject", subjects[i]))
+	}
+	for i := range groups {
+		ret = append(ret, inboxKey("group", groups[i]))
+	}
+	return ret
+}
+
+// FindByRecipient возвращает секреты, адресованные субъекту или его группам, в порядке создания.
+// Идентификаторы удалённых и устаревших секретов попутно убираются из входящих
+func (r *Repository) FindByRecipient(ctx context.Context, subject string, groups []string) ([]model.Secret, error) {
+	keys := inboxKeys([]string{subject}, groups)
+	ids, err := r.client.SUnion(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(ids))
+	for i := range ids {
+		cmds[i] = pipe.HGetAll(ctx, ids[i])
+	}
+	if len(ids) > 0 {
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	identity := model.Identity{Subject: subject, Groups: groups}
+	ret := make([]model.Secret, 0, len(ids))
+	gone := make([]interface{}, 0)
+	for i := range ids {
+		raw := cmds[i].Val()
+		if len(raw) == 0 {
+			gone = append(gone, ids[i])
+			continue
+		}
+		secret, dErr := decode(ids[i], raw)
+		if dErr != nil {
+			return nil, dErr
+		}
+		if secret.Expired() || !secret.AddressedTo(identity) {
+			continue
+		}
+		ret = append(ret, secret)
+	}
+	if len(gone) > 0 {
+		cleanup := r.client.Pipeline()
+		for i := range keys {
+			cleanup.SRem(ctx, keys[i], gone...)
+		}
+		_, err = cleanup.Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+	return ret, nil
+}
+
+// List выбирает секреты из сортированного множества создателя или всех секретов, начиная с курсора,
+// и отбрасывает не подходящие под фильтры. Идентификаторы удалённых и устаревших секретов попутно убираются из множества
+func (r *Repository) List(ctx context.Context, filter repository.ListFilter) ([]model.Secret, string, error) {
+	cursorCreatedAt, cursorID, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	key := indexKey(filter.Owner)
+	minScore := "-inf"
+	if !filter.CreatedAfter.IsZero() {
+		minScore = strconv.FormatFloat(indexScore(filter.CreatedAfter), 'f', -1, 64)
+	}
+	if filter.Cursor != "" && cursorCreatedAt.After(filter.CreatedAfter) {
+		minScore = strconv.FormatFloat(indexScore(cursorCreatedAt), 'f', -1, 64)
+	}
+	maxScore := "+inf"
+	if !filter.CreatedBefore.IsZero() {
+		// секреты, созданные в ту же микросекунду, отсеиваются фильтром
+		maxScore = strconv.FormatFloat(indexScore(filter.CreatedBefore), 'f', -1, 64)
+	}
+	ret := make([]model.Secret, 0, filter.Limit+1)
+	gone := make([]interface{}, 0)
+	var lastScore float64
+	var offset int64
+	batch := filter.Limit + 1
+	for {
+		items, zErr := r.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min:    minScore,
+			Max:    maxScore,
+			Offset: offset,
+			Count:  batch,
+		}).Result()
+		if zErr != nil {
+			return nil, "", zErr
+		}
+		offset += int64(len(items))
+		pipe := r.client.Pipeline()
+		cmds := make([]*redis.StringStringMapCmd, len(items))
+		for i := range items {
+			cmds[i] = pipe.HGetAll(ctx, items[i].Member.(string))
+		}
+		if len(items) > 0 {
+			_, err = pipe.Exec(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		full := false
+		for i := range items {
+			id := items[i].Member.(string)
+			// порядок секретов внутри одной микросекунды уточняется сортировкой,
+			// поэтому они выбираются все, даже если страница уже заполнена
+			if int64(len(ret)) > filter.Limit && items[i].Score != lastScore {
+				full = true
+				break
+			}
+			raw := cmds[i].Val()
+			if len(raw) == 0 {
+				gone = append(gone, id)
+				continue
+			}
+			secret, dErr := decode(id, raw)
+			if dErr != nil {
+				return nil, "", dErr
+			}
+			if secret.Expired() || !filter.Match(secret) {
+				continue
+			}
+			if filter.Cursor != "" && !repository.IsAfterCursor(secret, cursorCreatedAt, cursorID) {
+				continue
+			}
+			secret.Body = nil
+			ret = append(ret, secret)
+			lastScore = items[i].Score
+		}
+		if full || int64(len(items)) < batch {
+			break
+		}
+	}
+	if len(gone) > 0 {
+		err = r.client.ZRem(ctx, key, gone...).Err()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return repository.IsAfterCursor(ret[j], ret[i].CreatedAt, ret[i].ID)
+	})
+	next := ""
+	if int64(len(ret)) > filter.Limit {
+		ret = ret[:filter.Limit]
+		next = repository.EncodeCursor(ret[len(ret)-1])
+	}
+	return ret, next, nil
+}
+
+// updateScript атомарно заменяет поля секрета и его срок жизни, если версия секрета равна ARGV[1].
+// Заменяемые поля предварительно копируются в хэш прежних версий KEYS[2], в котором остаются только ARGV[4] последних версий.
+// Поля метаданных удаляются и записываются заново, так как набор полей мог измениться.
+// Возвращает -1, если секрета нет, 0, если версия другая, и 1, если секрет изменён
+var updateScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return -1
+end
+local version = tonumber(redis.call('HGET', KEYS[1], 'version') or '1')
+if version ~= tonumber(ARGV[1]) then
+  return 0
+end
+local keep = tonumber(ARGV[4])
+local archivedFields = {body = true, content_type = true, filename = true, expire_at = true, key_id = true, wrapped_key = true}
+local prefixLength = string.len(ARGV[3])
+local old = redis.call('HGETALL', KEYS[1])
+for i = 1, #old, 2 do
+  local isMeta = string.sub(old[i], 1, prefixLength) == ARGV[3]
+  if keep > 0 and (isMeta or archivedFields[old[i]]) then
+    redis.call('HSET', KEYS[2], version .. '|' .. old[i], old[i + 1])
+  end
+  if isMeta then
+    redis.call('HDEL', KEYS[1], old[i])
+  end
+end
+if keep > 0 then
+  redis.call('HSET', KEYS[2], version .. '|replaced_at', ARGV[5])
+  local archived = redis.call('HKEYS', KEYS[2])
+  for i = 1, #archived do
+    local archivedVersion = tonumber(string.match(archived[i], '^(%d+)|'))
+    if archivedVersion ~= nil and archivedVersion <= version - keep then
+      redis.call('HDEL', KEYS[2], archived[i])
+    end
+  end
+end
+for i = 6, #ARGV, 2 do
+  redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+redis.call('HSET', KEYS[1], 'version', version + 1)
+redis.call('PEXPIREAT', KEYS[1], ARGV[2])
+for i = 2, 3 do
+  if redis.call('EXISTS', KEYS[i]) == 1 then
+    redis.call('PEXPIREAT', KEYS[i], ARGV[2])
+  end
+end
+return 1
+`)
+
+// CountByOwner считает секреты в сортированном множестве создателя. Сожжённые и удалённые секреты убираются
+// из множества сразу, а устаревшие, которые redis удалил сам, - попутно по моменту устаревания, так что
+// множество не растёт больше числа действующих секретов
+func (r *Repository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	key := usageKey(owner)
+	err := r.client.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10)).Err()
+	if err != nil {
+		return 0, err
+	}
+	return r.client.ZCard(ctx, key).Result()
+}
+
+// Update заменяет тело, его тип и имя файла, метаданные, момент устаревания и ключ данных секрета, если его версия равна version,
+// и продлевает срок жизни множеств, в которые секрет входит. Создатель и адресаты берутся из secret
+func (r *Repository) Update(ctx context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error) {
+	args := make([]interface{}, 0, 17+2*len(secret.Meta))
+	args = append(args, version, secret.ExpireAt.UnixMilli(), metaPrefix,
+		keepVersions, time.Now().Format(time.RFC3339Nano),
+		"body", secret.Body,
+		"content_type", secret.ContentType,
+		"filename", secret.Filename,
+		"expire_at", secret.ExpireAt.Format(time.RFC3339Nano),
+		"key_id", secret.KeyID,
+		"wrapped_key", secret.WrappedKey,
+	)
+	for k := range secret.Meta {
+		args = append(args, metaPrefix+k, secret.Meta[k])
+	}
+	updated, err := updateScript.Run(ctx, r.client, []string{secret.ID, versionsKey(secret.ID), accessesKey(secret.ID)}, args...).Int64()
+	if err != nil {
+		return model.Secret{}, err
+	}
+	switch updated {
+	case -1:
+		return model.Secret{}, model.ErrSecretNotFound
+	case 0:
+		return model.Secret{}, model.ErrVersionConflict
+	}
+	secret.Version = version + 1
+	ttl := time.Until(secret.ExpireAt).Milliseconds()
+	keys := append(inboxKeys(secret.Recipients, secret.Groups), indexKey(""))
+	if secret.Owner != "" {
+		keys = append(keys, indexKey(secret.Owner))
+	}
+	pipe := r.client.Pipeline()
+	pipe.ZAdd(ctx, expiryKey, &redis.Z{Score: float64(secret.ExpireAt.UnixMilli()), Member: secret.ID})
+	if secret.Owner != "" {
+		pipe.ZAdd(ctx, usageKey(secret.Owner), &redis.Z{Score: float64(secret.ExpireAt.UnixMilli()), Member: secret.ID})
+		keys = append(keys, usageKey(secret.Owner))
+	}
+	if secret.ShareTokenHash != "" {
+		keys = append(keys, shareKey(secret.ShareTokenHash))
+	}
+	for _, key := range keys {
+		extendExpireScript.Eval(ctx, pipe, []string{key}, ttl)
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return model.Secret{}, err
+	}
+	return secret, nil
+}
+
+// decodeVersions собирает прежние версии секрета из полей хэша прежних версий
+func decodeVersions(id string, raw map[string]string) (map[int64]model.SecretVersion, error) {
+	ret := make(map[int64]model.SecretVersion, 0)
+	for k := range raw {
+		parts := strings.SplitN(k, "|", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		number, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed version field %s of secret %s: %w", k, id, err)
+		}
+		version, found := ret[number]
+		if !found {
+			version = model.SecretVersion{SecretID: id, Version: number, Meta: make(map[string]string, 0)}
+		}
+		switch field := parts[1]; {
+		case field == "body":
+			version.Body = []byte(raw[k])
+		case field == "content_type":
+			version.ContentType = raw[k]
+		case field == "filename":
+			version.Filename = raw[k]
+		case field == "key_id":
+			version.KeyID = raw[k]
+		case field == "wrapped_key":
+			if raw[k] != "" {
+				version.WrappedKey = []byte(raw[k])
+			}
+		case field == "expire_at":
+			version.ExpireAt, err = time.Parse(time.RFC3339Nano, raw[k])
+		case field == "replaced_at":
+			version.ReplacedAt, err = time.Parse(time.RFC3339Nano, raw[k])
+		case strings.HasPrefix(field, metaPrefix):
+			version.Meta[strings.TrimPrefix(field, metaPrefix)] = raw[k]
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed version field %s of secret %s: %w", k, id, err)
+		}
+		ret[number] = version
+	}
+	return ret, nil
+}
+
+// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой
+func (r *Repository) ListVersions(ctx context.Context, id string) ([]model.SecretVersion, error) {
+	raw, err := r.client.HGetAll(ctx, versionsKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	versions, err := decodeVersions(id, raw)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.SecretVersion, 0, len(versions))
+	for k := range versions {
+		version := versions[k]
+		version.Body = nil
+		ret = append(ret, version)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Version > ret[j].Version
+	})
+	return ret, nil
+}
+
+// GetVersion возвращает прежнюю версию секрета
+func (r *Repository) GetVersion(ctx context.Context, id string, version int64) (model.SecretVersion, error) {
+	raw, err := r.client.HGetAll(ctx, versionsKey(id)).Result()
+	if err != nil {
+		return model.SecretVersion{}, err
+	}
+	versions, err := decodeVersions(id, raw)
+	if err != nil {
+		return model.SecretVersion{}, err
+	}
+	archived, found := versions[version]
+	if !found {
+		return model.SecretVersion{}, model.ErrSecretNotFound
+	}
+	return archived, nil
+}
+
+// recordAccessScript добавляет прочтение в конец списка прочтений секрета, если секрет существует,
+// оставляя в списке ARGV[2] последних прочтений, и ставит списку тот же срок жизни, что и у секрета
+var recordAccessScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+  return 0
+end
+redis.call('RPUSH', KEYS[2], ARGV[1])
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[2]), -1)
+redis.call('PEXPIRE', KEYS[2], ttl)
+return 1
+`)
+
+// RecordAccess добавляет прочтение в список прочтений секрета одним Lua скриптом
+func (r *Repository) RecordAccess(ctx context.Context, id string, access model.SecretAccess, keepAccesses int64) error {
+	if keepAccesses <= 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(access)
+	if err != nil {
+		return err
+	}
+	recorded, err := recordAccessScript.Run(ctx, r.client, []string{id, accessesKey(id)}, encoded, keepAccesses).Int64()
+	if err != nil {
+		return err
+	}
+	if recorded == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
+}
+
+// ListAccesses возвращает журнал прочтений секрета, начиная с самого раннего прочтения
+func (r *Repository) ListAccesses(ctx context.Context, id string) ([]model.SecretAccess, error) {
+	raw, err := r.client.LRange(ctx, accessesKey(id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.SecretAccess, 0, len(raw))
+	for i := range raw {
+		var access model.SecretAccess
+		err = json.Unmarshal([]byte(raw[i]), &access)
+		if err != nil {
+			return nil, fmt.Errorf("malformed access of secret %s: %w", id, err)
+		}
+		ret = append(ret, access)
+	}
+	return ret, nil
+}
+
+// deleteScript атомарно удаляет секрет вместе со всем, что с ним связано
+var deleteScript = redis.NewScript(destroyLua + `
+destroy(ARGV[1], ARGV[2])
+return 1
+`)
+
+// DeleteByID удаляет секрет по идентификатору вместе с его прежними версиями, журналом прочтений и токеном ссылки,
+// убирая его из множества секретов создателя
 func (r *Repository) DeleteByID(ctx context.Context, id string) error {
-	return r.client.Del(ctx, id).Err()
+	return deleteScript.Run(ctx, r.client, []string{id, versionsKey(id), expiryKey, accessesKey(id)}, sharePrefix, usagePrefix).Err()
 }
 
-// Prune удаляет старые секреты
-func (r *Repository) Prune(ctx context.Context) error {
+// Prune ничего не удаляет, так как устаревшие секреты удаляет сам redis, а только возвращает их идентификаторы,
+// убирая их из сортированного множества сроков устаревания
+func (r *Repository) Prune(ctx context.Context) ([]string, error) {
+	expired, err := r.client.ZRangeByScore(ctx, expiryKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return expired, nil
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(expired))
+	for i := range expired {
+		cmds[i] = pipe.Exists(ctx, expired[i])
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pruned := make([]string, 0, len(expired))
+	gone := make([]interface{}, 0, len(expired))
+	for i := range expired {
+		// секрет могли продлить, пока множество ещё не обновлено
+		if cmds[i].Val() == 1 {
+			continue
+		}
+		pruned = append(pruned, expired[i])
+		gone = append(gone, expired[i])
+	}
+	if len(gone) > 0 {
+		err = r.client.ZRem(ctx, expiryKey, gone...).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pruned, nil
+}
+
+// parseInt разбирает целочисленное поле хэша, отсутствующее поле считается нулём
+func parseInt(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+// ListWrappedKeys обходит хэши секретов с помощью SCAN, курсором служит курсор SCAN.
+// Redis не гарантирует число элементов на странице, поэтому страница может быть и пустой, и больше limit
+func (r *Repository) ListWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.Secret, string, error) {
+	var scanCursor uint64
+	var err error
+	if cursor != "" {
+		scanCursor, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed cursor %s: %w", cursor, err)
+		}
+	}
+	keys, scanCursor, err := r.client.ScanType(ctx, scanCursor, "*", limit, "hash").Result()
+	if err != nil {
+		return nil, "", err
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(keys))
+	for i := range keys {
+		cmds[i] = pipe.HMGet(ctx, keys[i], "key_id", "wrapped_key")
+	}
+	if len(keys) > 0 {
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	ret := make([]model.Secret, 0, len(keys))
+	for i := range keys {
+		vals := cmds[i].Val()
+		keyID, _ := vals[0].(string)
+		wrapped, _ := vals[1].(string)
+		if keyID == "" || keyID == exceptKeyID {
+			continue
+		}
+		ret = append(ret, model.Secret{
+			ID:         keys[i],
+			KeyID:      keyID,
+			WrappedKey: []byte(wrapped),
+		})
+	}
+	next := ""
+	if scanCursor != 0 {
+		next = strconv.FormatUint(scanCursor, 10)
+	}
+	return ret, next, nil
+}
+
+// updateWrappedKeyScript атомарно заменяет обёрнутый ключ данных, если он обёрнут ожидаемым мастер-ключом
+var updateWrappedKeyScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'key_id') ~= ARGV[1] then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'key_id', ARGV[2], 'wrapped_key', ARGV[3])
+return 1
+`)
+
+// UpdateWrappedKey заменяет обёрнутый ключ данных секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateWrappedKey(ctx context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error {
+	updated, err := updateWrappedKeyScript.Run(ctx, r.client, []string{id}, oldKeyID, newKeyID, wrapped).Int64()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return model.ErrSecretNotFound
+	}
+	return nil
+}
+
+// ListVersionWrappedKeys обходит хэши прежних версий секретов с помощью SCAN, курсором служит курсор SCAN.
+// Как и у ListWrappedKeys, страница может быть и пустой, и больше limit
+func (r *Repository) ListVersionWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) ([]model.SecretVersion, string, error) {
+	var scanCursor uint64
+	var err error
+	if cursor != "" {
+		scanCursor, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed cursor %s: %w", cursor, err)
+		}
+	}
+	keys, scanCursor, err := r.client.ScanType(ctx, scanCursor, "*"+versionsSuffix, limit, "hash").Result()
+	if err != nil {
+		return nil, "", err
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(keys))
+	for i := range keys {
+		cmds[i] = pipe.HGetAll(ctx, keys[i])
+	}
+	if len(keys) > 0 {
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	ret := make([]model.SecretVersion, 0, len(keys))
+	for i := range keys {
+		id := strings.TrimSuffix(keys[i], versionsSuffix)
+		versions, dErr := decodeVersions(id, cmds[i].Val())
+		if dErr != nil {
+			return nil, "", dErr
+		}
+		for k := range versions {
+			if versions[k].KeyID == "" || versions[k].KeyID == exceptKeyID {
+				continue
+			}
+			ret = append(ret, model.SecretVersion{
+				SecretID:   id,
+				Version:    versions[k].Version,
+				KeyID:      versions[k].KeyID,
+				WrappedKey: versions[k].WrappedKey,
+			})
+		}
+	}
+	next := ""
+	if scanCursor != 0 {
+		next = strconv.FormatUint(scanCursor, 10)
+	}
+	return ret, next, nil
+}
+
+// updateVersionWrappedKeyScript атомарно заменяет обёрнутый ключ данных прежней версии,
+// если он обёрнут ожидаемым мастер-ключом
+var updateVersionWrappedKeyScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1] .. '|key_id') ~= ARGV[2] then
+  return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1] .. '|key_id', ARGV[3], ARGV[1] .. '|wrapped_key', ARGV[4])
+return 1
+`)
+
+// UpdateVersionWrappedKey заменяет обёрнутый ключ данных прежней версии секрета, если он обёрнут мастер-ключом oldKeyID
+func (r *Repository) UpdateVersionWrappedKey(ctx context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error {
+	updated, err := updateVersionWrappedKeyScript.Run(ctx, r.client, []string{versionsKey(id)},
+		version, oldKeyID, newKeyID, wrapped).Int64()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return model.ErrSecretNotFound
+	}
 	return nil
 }
@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// requestPrefix задаёт префикс хэшей запросов секретов, они устаревают вместе с запросами
+const requestPrefix = "request:"
+
+// requestTokenPrefix задаёт префикс ключей, по которым хэш токена ссылки указывает на идентификатор запроса
+const requestTokenPrefix = "request_token:"
+
+// requestKey возвращает ключ хэша запроса секрета
+func requestKey(id string) string {
+	return requestPrefix + id
+}
+
+// requestTokenKey возвращает ключ, по которому хэш токена ссылки указывает на идентификатор запроса
+func requestTokenKey(tokenHash string) string {
+	return requestTokenPrefix + tokenHash
+}
+
+// fulfilRequestScript атомарно отмечает, что по запросу прислали секрет. Возвращает 0, если запроса нет,
+// -1, если секрет по нему уже прислали, и 1, если запрос отмечен
+var fulfilRequestScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+  return 0
+end
+local secretID = redis.call("HGET", KEYS[1], "secret_id")
+if secretID and secretID ~= "" then
+  return -1
+end
+redis.call("HSET", KEYS[1], "secret_id", ARGV[1], "fulfilled_at", ARGV[2])
+return 1
+`)
+
+// CreateRequest сохраняет новый запрос секрета в хэш, который устаревает вместе с ним
+func (r *Repository) CreateRequest(ctx context.Context, request model.SecretRequest) (model.SecretRequest, error) {
+	request.ID = misc.UUID()
+	request.Token = ""
+	key := requestKey(request.ID)
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		"requester", request.Requester,
+		"description", request.Description,
+		"token_hash", request.TokenHash,
+		"created_at", request.CreatedAt.Format(time.RFC3339Nano),
+		"expire_at", request.ExpireAt.Format(time.RFC3339Nano),
+		"secret_id", "",
+		"fulfilled_at", "",
+	)
+	pipe.PExpireAt(ctx, key, request.ExpireAt)
+	pipe.Set(ctx, requestTokenKey(request.TokenHash), request.ID, 0)
+	pipe.PExpireAt(ctx, requestTokenKey(request.TokenHash), request.ExpireAt)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	return request, nil
+}
+
+// FindRequest ищет запрос секрета по идентификатору
+func (r *Repository) FindRequest(ctx context.Context, id string) (model.SecretRequest, error) {
+	raw, err := r.client.HGetAll(ctx, requestKey(id)).Result()
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	if len(raw) == 0 {
+		return model.SecretRequest{}, model.ErrRequestNotFound
+	}
+	request := model.SecretRequest{
+		ID:          id,
+		Requester:   raw["requester"],
+		Description: raw["description"],
+		TokenHash:   raw["token_hash"],
+		SecretID:    raw["secret_id"],
+	}
+	request.CreatedAt, err = time.Parse(time.RFC3339Nano, raw["created_at"])
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	request.ExpireAt, err = time.Parse(time.RFC3339Nano, raw["expire_at"])
+	if err != nil {
+		return model.SecretRequest{}, err
+	}
+	if raw["fulfilled_at"] != "" {
+		request.FulfilledAt, err = time.Parse(time.RFC3339Nano, raw["fulfilled_at"])
+		if err != nil {
+			return model.SecretRequest{}, err
+		}
+	}
+	if request.Expired() {
+		return model.SecretRequest{}, model.ErrRequestNotFound
+	}
+	return request, nil
+}
+
+// FindRequestByToken ищет запрос секрета по хэшу токена ссылки
+func (r *Repository) FindRequestByToken(ctx context.Context, tokenHash string) (model.SecretRequest, error) {
+	id, err := r.client.Get(ctx, requestTokenKey(tokenHash)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return model.SecretRequest{}, model.ErrRequestNotFound
+		}
+		return model.SecretRequest{}, err
+	}
+	return r.FindRequest(ctx, id)
+}
+
+// FulfilRequest отмечает, что по запросу прислали секрет, с помощью Lua скрипта
+func (r *Repository) FulfilRequest(ctx context.Context, id, secretID string, fulfilledAt time.Time) error {
+	res, err := fulfilRequestScript.Run(ctx, r.client, []string{requestKey(id)},
+		secretID, fulfilledAt.Format(time.RFC3339Nano)).Int64()
+	if err != nil {
+		return err
+	}
+	switch res {
+	case 0:
+		return model.ErrRequestNotFound
+	case -1:
+		return model.ErrRequestFulfilled
+	default:
+		return nil
+	}
+}
+
+// DeleteRequest удаляет запрос секрета вместе с ключом его токена
+func (r *Repository) DeleteRequest(ctx context.Context, id string) error {
+	tokenHash, err := r.client.HGet(ctx, requestKey(id), "token_hash").Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	}
+	return r.client.Del(ctx, requestKey(id), requestTokenKey(tokenHash)).Err()
+}
+
+// PruneRequests ничего не делает, так как устаревшие запросы удаляет сам redis
+func (r *Repository) PruneRequests(_ context.Context) error {
+	return nil
+}
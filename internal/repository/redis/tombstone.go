@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// tombstonePrefix задаёт префикс хэшей записей об исчезнувших секретах, они устаревают вместе с записями
+const tombstonePrefix = "tombstone:"
+
+// tombstoneKey возвращает ключ хэша записи об исчезнувшем секрете
+func tombstoneKey(secretID string) string {
+	return tombstonePrefix + secretID
+}
+
+// SaveTombstone сохраняет запись об исчезнувшем секрете в хэш, который устаревает вместе с ней
+func (r *Repository) SaveTombstone(ctx context.Context, tombstone model.Tombstone) error {
+	key := tombstoneKey(tombstone.SecretID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key,
+		"reason", string(tombstone.Reason),
+		"deleted_at", tombstone.DeletedAt.Format(time.RFC3339Nano),
+		"expire_at", tombstone.ExpireAt.Format(time.RFC3339Nano),
+	)
+	pipe.PExpireAt(ctx, key, tombstone.ExpireAt)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// FindTombstone ищет запись об исчезнувшем секрете
+func (r *Repository) FindTombstone(ctx context.Context, secretID string) (model.Tombstone, error) {
+	raw, err := r.client.HGetAll(ctx, tombstoneKey(secretID)).Result()
+	if err != nil {
+		return model.Tombstone{}, err
+	}
+	if len(raw) == 0 {
+		return model.Tombstone{}, model.ErrSecretNotFound
+	}
+	deletedAt, err := time.Parse(time.RFC3339Nano, raw["deleted_at"])
+	if err != nil {
+		return model.Tombstone{}, err
+	}
+	expireAt, err := time.Parse(time.RFC3339Nano, raw["expire_at"])
+	if err != nil {
+		return model.Tombstone{}, err
+	}
+	return model.Tombstone{
+		SecretID:  secretID,
+		Reason:    model.TombstoneReason(raw["reason"]),
+		DeletedAt: deletedAt,
+		ExpireAt:  expireAt,
+	}, nil
+}
+
+// PruneTombstones ничего не делает, так как устаревшие записи удаляет сам redis
+func (r *Repository) PruneTombstones(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/vodolaz095/purser/model"
+)
+
+// webhookPrefix задаёт префикс хэшей подписок на события секретов и потоков недоставленных уведомлений
+const webhookPrefix = "webhook:"
+
+// subscriptionKey возвращает ключ хэша подписки на события секрета, он устаревает вместе с подпиской
+func subscriptionKey(secretID string) string {
+	return webhookPrefix + "subscription:" + secretID
+}
+
+// deadLetterKey возвращает ключ потока недоставленных уведомлений о событиях секрета
+func deadLetterKey(secretID string) string {
+	return webhookPrefix + "dead:" + secretID
+}
+
+// SaveSubscription сохраняет подписку на события секрета в хэш, который устаревает вместе с ней
+func (r *Repository) SaveSubscription(ctx context.Context, subscription model.WebhookSubscription) error {
+	key := subscriptionKey(subscription.SecretID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key,
+		"owner", subscription.Owner,
+		"url", subscription.URL,
+		"read", strconv.FormatBool(subscription.Read),
+		"expire_at", subscription.ExpireAt.UnixMilli(),
+	)
+	pipe.PExpireAt(ctx, key, subscription.ExpireAt)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// FindSubscription ищет подписку на события секрета
+func (r *Repository) FindSubscription(ctx context.Context, secretID string) (model.WebhookSubscription, error) {
+	raw, err := r.client.HGetAll(ctx, subscriptionKey(secretID)).Result()
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	if len(raw) == 0 {
+		return model.WebhookSubscription{}, model.ErrSubscriptionNotFound
+	}
+	expireAt, err := strconv.ParseInt(raw["expire_at"], 10, 64)
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	read, err := strconv.ParseBool(raw["read"])
+	if err != nil {
+		return model.WebhookSubscription{}, err
+	}
+	return model.WebhookSubscription{
+		SecretID: secretID,
+		Owner:    raw["owner"],
+		URL:      raw["url"],
+		Read:     read,
+		ExpireAt: time.UnixMilli(expireAt),
+	}, nil
+}
+
+// DeleteSubscription удаляет подписку на события секрета
+func (r *Repository) DeleteSubscription(ctx context.Context, secretID string) error {
+	return r.client.Del(ctx, subscriptionKey(secretID)).Err()
+}
+
+// PruneSubscriptions ничего не делает, потому что подписки устаревают средствами redis
+func (r *Repository) PruneSubscriptions(_ context.Context) error {
+	return nil
+}
+
+// RecordDeadLetter сохраняет недоставленное уведомление в поток недоставленных уведомлений секрета,
+// идентификатором служит идентификатор записи потока
+func (r *Repository) RecordDeadLetter(ctx context.Context, delivery model.WebhookDelivery) (model.WebhookDelivery, error) {
+	id, err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: deadLetterKey(delivery.SecretID),
+		Values: []interface{}{
+			"url", delivery.URL,
+			"event_type", string(delivery.EventType),
+			"payload", delivery.Payload,
+			"attempts", delivery.Attempts,
+			"last_error", delivery.LastError,
+			"created_at", delivery.CreatedAt.UnixMilli(),
+		},
+	}).Result()
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	delivery.ID = id
+	return delivery, nil
+}
+
+// ListDeadLetters возвращает недоставленные уведомления о событиях секрета
+func (r *Repository) ListDeadLetters(ctx context.Context, secretID string) ([]model.WebhookDelivery, error) {
+	messages, err := r.client.XRange(ctx, deadLetterKey(secretID), "-", "+").Result()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.WebhookDelivery, 0, len(messages))
+	for i := range messages {
+		field := func(name string) string {
+			value, _ := messages[i].Values[name].(string)
+			return value
+		}
+		attempts, pErr := strconv.ParseInt(field("attempts"), 10, 64)
+		if pErr != nil {
+			return nil, pErr
+		}
+		createdAt, pErr := strconv.ParseInt(field("created_at"), 10, 64)
+		if pErr != nil {
+			return nil, pErr
+		}
+		ret = append(ret, model.WebhookDelivery{
+			ID:        messages[i].ID,
+			SecretID:  secretID,
+			URL:       field("url"),
+			EventType: model.WebhookEventType(field("event_type")),
+			Payload:   []byte(field("payload")),
+			Attempts:  attempts,
+			LastError: field("last_error"),
+			CreatedAt: time.UnixMilli(createdAt),
+		})
+	}
+	return ret, nil
+}
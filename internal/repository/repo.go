@@ -9,12 +9,66 @@ import (
 // SecretRepo задаёт интерфейс, которому должен соответствовать репозиторий для работы с model.Secret
 type SecretRepo interface {
 	BaseRepo
-	// Create создаёт новый секрет
-	Create(ctx context.Context, body string, meta map[string]string) (model.Secret, error)
-	// FindByID ищет секрет по идентификатору
+	// Create сохраняет новый секрет с уже заданными сроками жизни, идентификатор назначается репозиторием
+	Create(ctx context.Context, secret model.Secret) (model.Secret, error)
+	// CreateWithinQuota сохраняет новый секрет как Create, если у его создателя secret.Owner меньше maxSecrets
+	// не устаревших секретов, иначе возвращает model.ErrQuotaExceeded. Проверка и сохранение атомарны,
+	// так что одновременно созданные секреты не превышают ограничение
+	CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error)
+	// FindByID ищет секрет по идентификатору и атомарно засчитывает его прочтение,
+	// секрет удаляется, когда число прочтений достигает model.Secret.MaxViews.
+	// Секрет, который ещё не стал доступен, не засчитывается, а возвращается *model.NotYetAvailableError
 	FindByID(ctx context.Context, id string) (model.Secret, error)
-	// DeleteByID удаляет секрет по идентификатору
+	// Peek ищет секрет по идентификатору, не засчитывая его прочтение
+	Peek(ctx context.Context, id string) (model.Secret, error)
+	// FindIDByShareToken возвращает идентификатор не устаревшего секрета по хэшу токена ссылки tokenHash,
+	// если такого нет - model.ErrSecretNotFound
+	FindIDByShareToken(ctx context.Context, tokenHash string) (id string, err error)
+	// RegisterFailedAttempt атомарно засчитывает неверную попытку ввода кодовой фразы и возвращает,
+	// сколько попыток осталось. Когда попыток не осталось, секрет удаляется
+	RegisterFailedAttempt(ctx context.Context, id string, maxAttempts int64) (attemptsLeft int64, err error)
+	// FindAndDeleteByID атомарно ищет секрет по идентификатору и удаляет его, так что
+	// из нескольких одновременных читателей секрет получит только один.
+	// Секрет, который ещё не стал доступен, не удаляется, а возвращается *model.NotYetAvailableError
+	FindAndDeleteByID(ctx context.Context, id string) (model.Secret, error)
+	// FindByRecipient возвращает не устаревшие секреты, адресованные субъекту subject или одной из групп groups,
+	// не засчитывая их прочтение
+	FindByRecipient(ctx context.Context, subject string, groups []string) ([]model.Secret, error)
+	// List постранично возвращает не устаревшие секреты, подходящие под фильтры, без тел.
+	// Пустой next означает, что страниц больше нет
+	List(ctx context.Context, filter ListFilter) (secrets []model.Secret, next string, err error)
+	// CountByOwner возвращает число не устаревших секретов создателя owner
+	CountByOwner(ctx context.Context, owner string) (int64, error)
+	// Update атомарно заменяет тело, его тип и имя файла, метаданные, момент устаревания и ключ данных секрета secret.ID,
+	// если его текущая версия равна version, и возвращает секрет с увеличенной на единицу версией.
+	// Если версия другая, возвращается model.ErrVersionConflict. Заменённая версия сохраняется в истории,
+	// в которой остаются только keepVersions последних версий
+	Update(ctx context.Context, secret model.Secret, version, keepVersions int64) (model.Secret, error)
+	// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой
+	ListVersions(ctx context.Context, id string) ([]model.SecretVersion, error)
+	// GetVersion возвращает прежнюю версию секрета, если её нет - model.ErrSecretNotFound
+	GetVersion(ctx context.Context, id string, version int64) (model.SecretVersion, error)
+	// RecordAccess добавляет прочтение в журнал прочтений секрета id, в котором остаются только keepAccesses
+	// последних прочтений. Если секрета нет, возвращается model.ErrSecretNotFound
+	RecordAccess(ctx context.Context, id string, access model.SecretAccess, keepAccesses int64) error
+	// ListAccesses возвращает журнал прочтений секрета, начиная с самого раннего прочтения
+	ListAccesses(ctx context.Context, id string) ([]model.SecretAccess, error)
+	// DeleteByID удаляет секрет по идентификатору вместе с его прежними версиями и журналом прочтений
 	DeleteByID(ctx context.Context, id string) error
-	// Prune удаляет все устаревшие секреты
-	Prune(context.Context) error
+	// Prune удаляет все устаревшие секреты вместе с их прежними версиями и журналами прочтений и возвращает их идентификаторы
+	Prune(context.Context) (pruned []string, err error)
+	// ListWrappedKeys постранично возвращает идентификаторы и обёрнутые ключи данных зашифрованных секретов,
+	// ключ данных которых обёрнут не мастер-ключом exceptKeyID. Пустой cursor означает начало списка,
+	// а пустой next - что список закончился
+	ListWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) (secrets []model.Secret, next string, err error)
+	// UpdateWrappedKey атомарно заменяет обёрнутый ключ данных секрета, если он всё ещё обёрнут мастер-ключом oldKeyID,
+	// иначе возвращает model.ErrSecretNotFound
+	UpdateWrappedKey(ctx context.Context, id, oldKeyID, newKeyID string, wrapped []byte) error
+	// ListVersionWrappedKeys постранично возвращает идентификаторы секретов, номера и обёрнутые ключи данных
+	// зашифрованных прежних версий, ключ данных которых обёрнут не мастер-ключом exceptKeyID. Пустой cursor означает
+	// начало списка, а пустой next - что список закончился
+	ListVersionWrappedKeys(ctx context.Context, exceptKeyID, cursor string, limit int64) (versions []model.SecretVersion, next string, err error)
+	// UpdateVersionWrappedKey атомарно заменяет обёрнутый ключ данных прежней версии секрета, если он всё ещё обёрнут
+	// мастер-ключом oldKeyID, иначе возвращает model.ErrSecretNotFound
+	UpdateVersionWrappedKey(ctx context.Context, id string, version int64, oldKeyID, newKeyID string, wrapped []byte) error
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// RequestRepo задаёт интерфейс, которому должен соответствовать репозиторий запросов секретов
+type RequestRepo interface {
+	BaseRepo
+	// CreateRequest сохраняет новый запрос секрета, идентификатор назначается репозиторием
+	CreateRequest(ctx context.Context, request model.SecretRequest) (model.SecretRequest, error)
+	// FindRequest ищет не устаревший запрос по идентификатору, если его нет - model.ErrRequestNotFound
+	FindRequest(ctx context.Context, id string) (model.SecretRequest, error)
+	// FindRequestByToken ищет не устаревший запрос по хэшу токена ссылки tokenHash, если его нет - model.ErrRequestNotFound
+	FindRequestByToken(ctx context.Context, tokenHash string) (model.SecretRequest, error)
+	// FulfilRequest атомарно отмечает, что по запросу id прислали секрет secretID, так что из нескольких
+	// одновременно присланных секретов запрос примет только один. Если секрет уже прислали,
+	// возвращается model.ErrRequestFulfilled, а если запроса нет или он устарел - model.ErrRequestNotFound
+	FulfilRequest(ctx context.Context, id, secretID string, fulfilledAt time.Time) error
+	// DeleteRequest удаляет запрос по идентификатору
+	DeleteRequest(ctx context.Context, id string) error
+	// PruneRequests удаляет устаревшие запросы
+	PruneRequests(ctx context.Context) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// TombstoneRepo задаёт интерфейс, которому должен соответствовать репозиторий записей об исчезнувших секретах
+type TombstoneRepo interface {
+	BaseRepo
+	// SaveTombstone сохраняет запись об исчезнувшем секрете, заменяя прежнюю запись о нём
+	SaveTombstone(ctx context.Context, tombstone model.Tombstone) error
+	// FindTombstone ищет не устаревшую запись об исчезнувшем секрете, если её нет - model.ErrSecretNotFound
+	FindTombstone(ctx context.Context, secretID string) (model.Tombstone, error)
+	// PruneTombstones удаляет устаревшие записи
+	PruneTombstones(ctx context.Context) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// WebhookRepo задаёт интерфейс, которому должен соответствовать репозиторий подписок на события секретов
+// и недоставленных уведомлений
+type WebhookRepo interface {
+	BaseRepo
+	// SaveSubscription сохраняет подписку на события секрета, заменяя прежнюю
+	SaveSubscription(ctx context.Context, subscription model.WebhookSubscription) error
+	// FindSubscription ищет не устаревшую подписку на события секрета, если её нет - model.ErrSubscriptionNotFound
+	FindSubscription(ctx context.Context, secretID string) (model.WebhookSubscription, error)
+	// DeleteSubscription удаляет подписку на события секрета, если она есть
+	DeleteSubscription(ctx context.Context, secretID string) error
+	// PruneSubscriptions удаляет устаревшие подписки
+	PruneSubscriptions(ctx context.Context) error
+	// RecordDeadLetter сохраняет уведомление, которое не удалось доставить, идентификатор назначается репозиторием
+	RecordDeadLetter(ctx context.Context, delivery model.WebhookDelivery) (model.WebhookDelivery, error)
+	// ListDeadLetters возвращает недоставленные уведомления о событиях секрета, начиная с самого старого
+	ListDeadLetters(ctx context.Context, secretID string) ([]model.WebhookDelivery, error)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +15,27 @@ import (
 	"github.com/vodolaz095/purser/pkg/misc"
 )
 
+// parallelReaders задаёт, сколько читателей одновременно пытаются сжечь один секрет
+const parallelReaders = 10
+
+// limitedViews задаёт, сколько раз можно прочитать секрет с ограниченным числом прочтений
+const limitedViews = 3
+
+// maxPassphraseAttempts задаёт, после скольких неверных попыток ввода кодовой фразы секрет уничтожается
+const maxPassphraseAttempts int64 = 3
+
+// parallelCreators задаёт, сколько создателей одновременно пытаются сохранить секрет одного создателя с ограничением
+const parallelCreators = 10
+
+// secretsQuota задаёт, сколько действующих секретов может быть у создателя с ограничением
+const secretsQuota int64 = 3
+
+// keptVersions задаёт, сколько прежних версий секрета хранится в истории
+const keptVersions int64 = 2
+
+// keptAccesses задаёт, сколько последних прочтений секрета хранится в журнале прочтений
+const keptAccesses int64 = 2
+
 // ValidateRepo используется в юнит тестах, чтобы базово проверить репозиторий
 func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 	ctx := context.TODO()
@@ -38,12 +61,16 @@ func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 		return
 	}
 	t.Logf("Repo pinged")
-	secret, err := repo.Create(ctx,
-		fmt.Sprintf("test body for repo %s", name),
-		map[string]string{
+	now := time.Now()
+	secret, err := repo.Create(ctx, model.Secret{
+		Body: []byte(fmt.Sprintf("test body for repo %s", name)),
+		Meta: map[string]string{
 			"repo": name,
 		},
-	)
+		Owner:     "repotest",
+		CreatedAt: now,
+		ExpireAt:  now.Add(5 * time.Minute),
+	})
 	if err != nil {
 		t.Errorf("error creating secret : %v", err)
 		return
@@ -59,6 +86,7 @@ func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 	}
 	assert.Equal(t, secret.ID, secretExtracted.ID, "id differs")
 	assert.Equal(t, secret.Body, secretExtracted.Body, "body differs")
+	assert.Equal(t, secret.Owner, secretExtracted.Owner, "owner differs")
 	assert.Equal(t, len(secret.Meta), len(secretExtracted.Meta), "meta size differs")
 	for k := range secret.Meta {
 		assert.Equalf(t, secret.Meta[k], secretExtracted.Meta[k], "meta %s differs", k)
@@ -66,6 +94,8 @@ func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 	for k := range secretExtracted.Meta {
 		assert.Equalf(t, secretExtracted.Meta[k], secret.Meta[k], "meta %s differs", k)
 	}
+	assert.WithinDuration(t, secret.CreatedAt, secretExtracted.CreatedAt, time.Second, "creation time differs")
+	assert.WithinDuration(t, secret.ExpireAt, secretExtracted.ExpireAt, time.Second, "expiration time differs")
 	t.Logf("Repo %s returns proper secret by known key", name)
 
 	unknownID := misc.UUID()
@@ -83,10 +113,67 @@ func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 		return
 	}
 	assert.Equal(t, "", secretNotFound.ID, "not found secret's id is not null")
-	assert.Equal(t, "", secretNotFound.Body, "not found secret's body is not null")
+	assert.Empty(t, secretNotFound.Body, "not found secret's body is not null")
 	assert.Empty(t, secretNotFound.Meta, "not found secret's meta is not null")
 	t.Logf("Repo %s returns proper error for secret not found", name)
 
+	// тело двоичного секрета содержит все возможные байты, включая нулевой и не входящие в UTF-8
+	binaryBody := make([]byte, 512)
+	for i := range binaryBody {
+		binaryBody[i] = byte(i)
+	}
+	binary, err := repo.Create(ctx, model.Secret{
+		Body:        binaryBody,
+		Meta:        map[string]string{"repo": name},
+		ContentType: "application/x-pkcs12",
+		Filename:    "client.p12",
+		CreatedAt:   now,
+		ExpireAt:    now.Add(5 * time.Minute),
+		Version:     1,
+	})
+	if err != nil {
+		t.Errorf("error creating binary secret : %v", err)
+		return
+	}
+	binaryPeeked, err := repo.Peek(ctx, binary.ID)
+	if err != nil {
+		t.Errorf("error peeking binary secret : %v", err)
+		return
+	}
+	assert.Equal(t, binaryBody, binaryPeeked.Body, "binary body differs")
+	assert.Equal(t, "application/x-pkcs12", binaryPeeked.ContentType, "content type differs")
+	assert.Equal(t, "client.p12", binaryPeeked.Filename, "filename differs")
+	binary.Body = []byte("kubeconfig")
+	binary.ContentType = "text/plain; charset=utf-8"
+	binary.Filename = ""
+	_, err = repo.Update(ctx, binary, 1, keptVersions)
+	if err != nil {
+		t.Errorf("error updating binary secret : %v", err)
+		return
+	}
+	binaryVersion, err := repo.GetVersion(ctx, binary.ID, 1)
+	if err != nil {
+		t.Errorf("error getting version of binary secret : %v", err)
+		return
+	}
+	assert.Equal(t, binaryBody, binaryVersion.Body, "binary body of version differs")
+	assert.Equal(t, "application/x-pkcs12", binaryVersion.ContentType, "content type of version differs")
+	assert.Equal(t, "client.p12", binaryVersion.Filename, "filename of version differs")
+	binaryPeeked, err = repo.Peek(ctx, binary.ID)
+	if err != nil {
+		t.Errorf("error peeking updated binary secret : %v", err)
+		return
+	}
+	assert.Equal(t, "kubeconfig", string(binaryPeeked.Body), "body is not updated")
+	assert.Equal(t, "text/plain; charset=utf-8", binaryPeeked.ContentType, "content type is not updated")
+	assert.Empty(t, binaryPeeked.Filename, "filename is not updated")
+	err = repo.DeleteByID(ctx, binary.ID)
+	if err != nil {
+		t.Errorf("error deleting binary secret : %v", err)
+		return
+	}
+	t.Logf("Repo %s stores binary secrets byte to byte", name)
+
 	err = repo.DeleteByID(ctx, secret.ID)
 	if err != nil {
 		t.Errorf("error deleting existent secret : %v", err)
@@ -105,7 +192,1177 @@ func ValidateRepo(t *testing.T, name string, repo repository.SecretRepo) {
 		t.Error("error not thrown for secret not found")
 	}
 	assert.Equal(t, "", secretThatShouldBeNotFound.ID, "not found secret's id is not null")
-	assert.Equal(t, "", secretThatShouldBeNotFound.Body, "not found secret's body is not null")
+	assert.Empty(t, secretThatShouldBeNotFound.Body, "not found secret's body is not null")
 	assert.Empty(t, secretThatShouldBeNotFound.Meta, "not found secret's meta is not null")
 	t.Logf("Repo %s allows secret to be deleted", name)
+
+	expired, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("expired body for repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		CreatedAt: now.Add(-time.Hour),
+		ExpireAt:  now.Add(-time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating expired secret : %v", err)
+		return
+	}
+	_, err = repo.FindByID(ctx, expired.ID)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("expired secret is returned: %v", err)
+		return
+	}
+	pruned, err := repo.Prune(ctx)
+	if err != nil {
+		t.Errorf("error pruning expired secrets : %v", err)
+		return
+	}
+	assert.Contains(t, pruned, expired.ID, "pruned secret is not reported")
+	_, err = repo.FindByID(ctx, expired.ID)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("pruned secret is returned: %v", err)
+		return
+	}
+	t.Logf("Repo %s honours expiration time of each secret", name)
+
+	burnable, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("burnable body for repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		CreatedAt: now,
+		ExpireAt:  now.Add(time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating burnable secret : %v", err)
+		return
+	}
+	var winners, losers int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < parallelReaders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			burned, lErr := repo.FindAndDeleteByID(ctx, burnable.ID)
+			if lErr != nil {
+				if errors.Is(lErr, model.ErrSecretNotFound) {
+					atomic.AddInt32(&losers, 1)
+					return
+				}
+				t.Errorf("error burning secret : %v", lErr)
+				return
+			}
+			assert.Equal(t, burnable.Body, burned.Body, "burned body differs")
+			atomic.AddInt32(&winners, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), winners, "wrong number of readers got burnable secret")
+	assert.Equal(t, int32(parallelReaders-1), losers, "wrong number of readers missed burnable secret")
+	_, err = repo.FindByID(ctx, burnable.ID)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("burned secret is still readable: %v", err)
+		return
+	}
+	t.Logf("Repo %s allows only one of %v parallel readers to burn secret", name, parallelReaders)
+
+	limited, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("limited body for repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		CreatedAt: now,
+		ExpireAt:  now.Add(time.Minute),
+		MaxViews:  limitedViews,
+	})
+	if err != nil {
+		t.Errorf("error creating secret with limited views : %v", err)
+		return
+	}
+	viewed, err := repo.FindByID(ctx, limited.ID)
+	if err != nil {
+		t.Errorf("error finding secret with limited views : %v", err)
+		return
+	}
+	assert.Equal(t, int64(1), viewed.Views, "view is not counted")
+	assert.Equal(t, int64(limitedViews-1), viewed.ViewsLeft(), "wrong number of views left")
+	winners, losers = 0, 0
+	for i := 0; i < parallelReaders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, lErr := repo.FindByID(ctx, limited.ID)
+			if lErr != nil {
+				if errors.Is(lErr, model.ErrSecretNotFound) {
+					atomic.AddInt32(&losers, 1)
+					return
+				}
+				t.Errorf("error viewing secret : %v", lErr)
+				return
+			}
+			atomic.AddInt32(&winners, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(limitedViews-1), winners, "wrong number of readers viewed limited secret")
+	assert.Equal(t, int32(parallelReaders-limitedViews+1), losers, "wrong number of readers missed limited secret")
+	_, err = repo.FindByID(ctx, limited.ID)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("secret is readable after all views are spent: %v", err)
+		return
+	}
+	t.Logf("Repo %s destroys secret after %v views", name, limitedViews)
+
+	protected, err := repo.Create(ctx, model.Secret{
+		Body:           []byte(fmt.Sprintf("protected body for repo %s", name)),
+		Meta:           map[string]string{"repo": name},
+		CreatedAt:      now,
+		ExpireAt:       now.Add(time.Minute),
+		MaxViews:       1,
+		PassphraseHash: "not really a hash",
+	})
+	if err != nil {
+		t.Errorf("error creating protected secret : %v", err)
+		return
+	}
+	peeked, err := repo.Peek(ctx, protected.ID)
+	if err != nil {
+		t.Errorf("error peeking protected secret : %v", err)
+		return
+	}
+	assert.Equal(t, protected.Body, peeked.Body, "peeked body differs")
+	assert.Equal(t, protected.PassphraseHash, peeked.PassphraseHash, "peeked passphrase hash differs")
+	assert.Equal(t, int64(0), peeked.Views, "peeking counts view")
+	for i := int64(1); i < maxPassphraseAttempts; i++ {
+		left, lErr := repo.RegisterFailedAttempt(ctx, protected.ID, maxPassphraseAttempts)
+		if lErr != nil {
+			t.Errorf("error registering failed attempt : %v", lErr)
+			return
+		}
+		assert.Equal(t, maxPassphraseAttempts-i, left, "wrong number of attempts left")
+	}
+	peeked, err = repo.Peek(ctx, protected.ID)
+	if err != nil {
+		t.Errorf("error peeking protected secret : %v", err)
+		return
+	}
+	assert.Equal(t, maxPassphraseAttempts-1, peeked.FailedAttempts, "failed attempts are not stored")
+	left, err := repo.RegisterFailedAttempt(ctx, protected.ID, maxPassphraseAttempts)
+	if err != nil {
+		t.Errorf("error registering last failed attempt : %v", err)
+		return
+	}
+	assert.Equal(t, int64(0), left, "attempts left after the last one")
+	_, err = repo.Peek(ctx, protected.ID)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("secret is not destroyed after too many failed attempts: %v", err)
+		return
+	}
+	_, err = repo.RegisterFailedAttempt(ctx, protected.ID, maxPassphraseAttempts)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("failed attempt is registered for destroyed secret: %v", err)
+		return
+	}
+	t.Logf("Repo %s destroys secret after %v failed passphrase attempts", name, maxPassphraseAttempts)
+
+	encrypted, err := repo.Create(ctx, model.Secret{
+		Body:       []byte(fmt.Sprintf("encrypted body for repo %s", name)),
+		Meta:       map[string]string{"repo": name},
+		CreatedAt:  now,
+		ExpireAt:   now.Add(5 * time.Minute),
+		KeyID:      "test-key",
+		WrappedKey: []byte{0, 1, 2, 127, 128, 255},
+	})
+	if err != nil {
+		t.Errorf("error creating encrypted secret : %v", err)
+		return
+	}
+	peeked, err = repo.Peek(ctx, encrypted.ID)
+	if err != nil {
+		t.Errorf("error peeking encrypted secret : %v", err)
+		return
+	}
+	assert.Equal(t, encrypted.KeyID, peeked.KeyID, "key id differs")
+	assert.Equal(t, encrypted.WrappedKey, peeked.WrappedKey, "wrapped key differs")
+	assert.Equal(t, encrypted.Body, peeked.Body, "body differs")
+	t.Logf("Repo %s stores wrapped data key of secret", name)
+
+	const newKeyID = "rotation-new-key"
+	toRewrap := make(map[string]model.Secret, 0)
+	for i := 0; i < 3; i++ {
+		created, cErr := repo.Create(ctx, model.Secret{
+			Body:       []byte(fmt.Sprintf("rotated body #%v for repo %s", i, name)),
+			Meta:       map[string]string{"repo": name},
+			CreatedAt:  now,
+			ExpireAt:   now.Add(5 * time.Minute),
+			KeyID:      "rotation-old-key",
+			WrappedKey: []byte{byte(i)},
+		})
+		if cErr != nil {
+			t.Errorf("error creating secret to rewrap : %v", cErr)
+			return
+		}
+		toRewrap[created.ID] = created
+	}
+	listed := listWrappedKeys(t, repo, newKeyID)
+	for id := range toRewrap {
+		if assert.Containsf(t, listed, id, "secret %s is not listed for rewrap", id) {
+			assert.Equal(t, toRewrap[id].KeyID, listed[id].KeyID, "listed key id differs")
+			assert.Equal(t, toRewrap[id].WrappedKey, listed[id].WrappedKey, "listed wrapped key differs")
+		}
+	}
+	for id := range toRewrap {
+		err = repo.UpdateWrappedKey(ctx, id, toRewrap[id].KeyID, newKeyID, []byte("rewrapped"))
+		if err != nil {
+			t.Errorf("error updating wrapped key : %v", err)
+			return
+		}
+		err = repo.UpdateWrappedKey(ctx, id, toRewrap[id].KeyID, newKeyID, []byte("rewrapped twice"))
+		if !errors.Is(err, model.ErrSecretNotFound) {
+			t.Errorf("wrapped key is updated twice: %v", err)
+			return
+		}
+	}
+	listed = listWrappedKeys(t, repo, newKeyID)
+	for id := range toRewrap {
+		assert.NotContainsf(t, listed, id, "rewrapped secret %s is listed for rewrap", id)
+	}
+	err = repo.UpdateWrappedKey(ctx, unknownID, "rotation-old-key", newKeyID, []byte("rewrapped"))
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrapped key is updated for unknown secret: %v", err)
+		return
+	}
+	t.Logf("Repo %s allows to rewrap data keys of secrets", name)
+
+	withHistory, err := repo.Create(ctx, model.Secret{
+		Body:       []byte(fmt.Sprintf("archived body for repo %s", name)),
+		Meta:       map[string]string{"repo": name},
+		CreatedAt:  now,
+		ExpireAt:   now.Add(5 * time.Minute),
+		Version:    1,
+		KeyID:      "rotation-old-key",
+		WrappedKey: []byte("archived"),
+	})
+	if err != nil {
+		t.Errorf("error creating secret with history : %v", err)
+		return
+	}
+	current := withHistory
+	current.Body = []byte(fmt.Sprintf("current body for repo %s", name))
+	current.KeyID = newKeyID
+	current.WrappedKey = []byte("current")
+	_, err = repo.Update(ctx, current, 1, 5)
+	if err != nil {
+		t.Errorf("error archiving secret version : %v", err)
+		return
+	}
+	listedVersions := listVersionWrappedKeys(t, repo, newKeyID)
+	archivedKey := withHistory.ID + "|1"
+	if assert.Containsf(t, listedVersions, archivedKey, "version of secret %s is not listed for rewrap", withHistory.ID) {
+		assert.Equal(t, withHistory.KeyID, listedVersions[archivedKey].KeyID, "listed key id of version differs")
+		assert.Equal(t, withHistory.WrappedKey, listedVersions[archivedKey].WrappedKey, "listed wrapped key of version differs")
+	}
+	err = repo.UpdateVersionWrappedKey(ctx, withHistory.ID, 1, withHistory.KeyID, newKeyID, []byte("rewrapped"))
+	if err != nil {
+		t.Errorf("error updating wrapped key of version : %v", err)
+		return
+	}
+	err = repo.UpdateVersionWrappedKey(ctx, withHistory.ID, 1, withHistory.KeyID, newKeyID, []byte("rewrapped twice"))
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrapped key of version is updated twice: %v", err)
+		return
+	}
+	err = repo.UpdateVersionWrappedKey(ctx, withHistory.ID, 2, withHistory.KeyID, newKeyID, []byte("rewrapped"))
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrapped key is updated for unknown version: %v", err)
+		return
+	}
+	assert.NotContains(t, listVersionWrappedKeys(t, repo, newKeyID), archivedKey, "rewrapped version is listed for rewrap")
+	err = repo.DeleteByID(ctx, withHistory.ID)
+	if err != nil {
+		t.Errorf("error deleting secret with history : %v", err)
+		return
+	}
+	t.Logf("Repo %s allows to rewrap data keys of previous versions", name)
+
+	forAlice, err := repo.Create(ctx, model.Secret{
+		Body:       []byte(fmt.Sprintf("body for alice and sre from repo %s", name)),
+		Meta:       map[string]string{"repo": name},
+		Owner:      "repotest",
+		Recipients: []string{"alice"},
+		Groups:     []string{"sre"},
+		CreatedAt:  now,
+		ExpireAt:   now.Add(5 * time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating addressed secret : %v", err)
+		return
+	}
+	forDBA, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("body for dba from repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		Owner:     "repotest",
+		Groups:    []string{"dba"},
+		CreatedAt: now.Add(time.Second),
+		ExpireAt:  now.Add(5 * time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating addressed secret : %v", err)
+		return
+	}
+	peeked, err = repo.Peek(ctx, forAlice.ID)
+	if err != nil {
+		t.Errorf("error peeking addressed secret : %v", err)
+		return
+	}
+	assert.Equal(t, []string{"alice"}, peeked.Recipients, "recipients differ")
+	assert.Equal(t, []string{"sre"}, peeked.Groups, "groups differ")
+	assert.Equal(t, []string{forAlice.ID}, findByRecipient(t, repo, "alice", nil),
+		"wrong secrets addressed to subject")
+	assert.Equal(t, []string{forAlice.ID}, findByRecipient(t, repo, "bob", []string{"sre"}),
+		"wrong secrets addressed to group")
+	assert.Equal(t, []string{forAlice.ID, forDBA.ID}, findByRecipient(t, repo, "alice", []string{"dba"}),
+		"wrong secrets addressed to subject and group")
+	assert.Empty(t, findByRecipient(t, repo, "carol", []string{"qa"}), "secrets addressed to nobody are found")
+	err = repo.DeleteByID(ctx, forAlice.ID)
+	if err != nil {
+		t.Errorf("error deleting addressed secret : %v", err)
+		return
+	}
+	assert.Equal(t, []string{forDBA.ID}, findByRecipient(t, repo, "alice", []string{"dba"}),
+		"deleted secret is found by recipient")
+	t.Logf("Repo %s finds secrets by recipients", name)
+
+	versioned, err := repo.Create(ctx, model.Secret{
+		Body:       []byte(fmt.Sprintf("first version from repo %s", name)),
+		Meta:       map[string]string{"repo": name, "stale": "yes"},
+		Owner:      "repotest",
+		Recipients: []string{"alice"},
+		CreatedAt:  now,
+		ExpireAt:   now.Add(5 * time.Minute),
+		Version:    1,
+	})
+	if err != nil {
+		t.Errorf("error creating versioned secret : %v", err)
+		return
+	}
+	versioned.Body = []byte(fmt.Sprintf("second version from repo %s", name))
+	versioned.Meta = map[string]string{"repo": name, "fresh": "yes"}
+	versioned.ExpireAt = now.Add(10 * time.Minute)
+	updated, err := repo.Update(ctx, versioned, 1, keptVersions)
+	if err != nil {
+		t.Errorf("error updating secret : %v", err)
+		return
+	}
+	assert.Equal(t, int64(2), updated.Version, "version is not increased")
+	peeked, err = repo.Peek(ctx, versioned.ID)
+	if err != nil {
+		t.Errorf("error peeking updated secret : %v", err)
+		return
+	}
+	assert.Equal(t, versioned.Body, peeked.Body, "body is not updated")
+	assert.Equal(t, versioned.Meta, peeked.Meta, "meta is not replaced")
+	assert.Equal(t, int64(2), peeked.Version, "version is not stored")
+	assert.Equal(t, "repotest", peeked.Owner, "owner is lost on update")
+	assert.Equal(t, []string{"alice"}, peeked.Recipients, "recipients are lost on update")
+	assert.WithinDuration(t, versioned.ExpireAt, peeked.ExpireAt, time.Second, "expiration is not updated")
+	_, err = repo.Update(ctx, versioned, 1, keptVersions)
+	if !errors.Is(err, model.ErrVersionConflict) {
+		t.Errorf("wrong error for stale version : %v", err)
+		return
+	}
+	unknown := versioned
+	unknown.ID = unknownID
+	_, err = repo.Update(ctx, unknown, 1, keptVersions)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrong error for updating unknown secret : %v", err)
+		return
+	}
+	t.Logf("Repo %s updates secrets with optimistic concurrency", name)
+
+	for v := int64(2); v <= 3; v++ {
+		versioned.Body = []byte(fmt.Sprintf("version %v from repo %s", v+1, name))
+		versioned.Meta = map[string]string{"repo": name, "version": fmt.Sprint(v + 1)}
+		_, err = repo.Update(ctx, versioned, v, keptVersions)
+		if err != nil {
+			t.Errorf("error updating secret : %v", err)
+			return
+		}
+	}
+	history, err := repo.ListVersions(ctx, versioned.ID)
+	if err != nil {
+		t.Errorf("error listing versions : %v", err)
+		return
+	}
+	if assert.Len(t, history, int(keptVersions), "old versions are not trimmed") {
+		assert.Equal(t, int64(3), history[0].Version, "newest version is not first")
+		assert.Equal(t, int64(2), history[1].Version, "wrong version is kept")
+		for i := range history {
+			assert.Equal(t, versioned.ID, history[i].SecretID, "wrong secret id of version")
+			assert.Empty(t, history[i].Body, "body of version is listed")
+			assert.False(t, history[i].ReplacedAt.IsZero(), "replacement time is not stored")
+		}
+		assert.Equal(t, "3", history[0].Meta["version"], "meta of version is not listed")
+	}
+	second, err := repo.GetVersion(ctx, versioned.ID, 2)
+	if err != nil {
+		t.Errorf("error getting version : %v", err)
+		return
+	}
+	assert.Equal(t, fmt.Sprintf("second version from repo %s", name), string(second.Body), "wrong body of version")
+	assert.Equal(t, map[string]string{"repo": name, "fresh": "yes"}, second.Meta, "wrong meta of version")
+	_, err = repo.GetVersion(ctx, versioned.ID, 1)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrong error for trimmed version : %v", err)
+		return
+	}
+	err = repo.DeleteByID(ctx, versioned.ID)
+	if err != nil {
+		t.Errorf("error deleting versioned secret : %v", err)
+		return
+	}
+	_, err = repo.GetVersion(ctx, versioned.ID, 3)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("version of deleted secret is found : %v", err)
+		return
+	}
+	history, err = repo.ListVersions(ctx, versioned.ID)
+	if err != nil {
+		t.Errorf("error listing versions : %v", err)
+		return
+	}
+	assert.Empty(t, history, "versions of deleted secret are listed")
+	t.Logf("Repo %s keeps history of versions", name)
+
+	watched, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("watched secret from repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		Owner:     "repotest",
+		CreatedAt: now,
+		ExpireAt:  now.Add(5 * time.Minute),
+		Version:   1,
+	})
+	if err != nil {
+		t.Errorf("error creating watched secret : %v", err)
+		return
+	}
+	for i, subject := range []string{"alice", "bob", "carol"} {
+		err = repo.RecordAccess(ctx, watched.ID, model.SecretAccess{
+			Subject:    subject,
+			AccessedAt: now.Add(time.Duration(i) * time.Second),
+		}, keptAccesses)
+		if err != nil {
+			t.Errorf("error recording access : %v", err)
+			return
+		}
+	}
+	accesses, err := repo.ListAccesses(ctx, watched.ID)
+	if err != nil {
+		t.Errorf("error listing accesses : %v", err)
+		return
+	}
+	if assert.Len(t, accesses, int(keptAccesses), "accesses are not trimmed") {
+		assert.Equal(t, "bob", accesses[0].Subject, "wrong order of accesses")
+		assert.Equal(t, "carol", accesses[1].Subject, "wrong order of accesses")
+		assert.WithinDuration(t, now.Add(2*time.Second), accesses[1].AccessedAt, time.Second, "access time differs")
+	}
+	err = repo.RecordAccess(ctx, unknownID, model.SecretAccess{Subject: "alice", AccessedAt: now}, keptAccesses)
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrong error for access of unknown secret : %v", err)
+		return
+	}
+	err = repo.DeleteByID(ctx, watched.ID)
+	if err != nil {
+		t.Errorf("error deleting watched secret : %v", err)
+		return
+	}
+	accesses, err = repo.ListAccesses(ctx, watched.ID)
+	if err != nil {
+		t.Errorf("error listing accesses : %v", err)
+		return
+	}
+	assert.Empty(t, accesses, "accesses of deleted secret are listed")
+	t.Logf("Repo %s keeps log of accesses", name)
+
+	activation := now.Add(time.Minute)
+	scheduled, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("scheduled secret from repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		CreatedAt: now,
+		ExpireAt:  now.Add(5 * time.Minute),
+		NotBefore: activation,
+		Version:   1,
+	})
+	if err != nil {
+		t.Errorf("error creating scheduled secret : %v", err)
+		return
+	}
+	peeked, err = repo.Peek(ctx, scheduled.ID)
+	if err != nil {
+		t.Errorf("error peeking scheduled secret : %v", err)
+		return
+	}
+	assert.WithinDuration(t, activation, peeked.NotBefore, time.Second, "activation time differs")
+	for _, read := range []func(context.Context, string) (model.Secret, error){repo.FindByID, repo.FindAndDeleteByID} {
+		_, err = read(ctx, scheduled.ID)
+		var notYet *model.NotYetAvailableError
+		if assert.True(t, errors.As(err, &notYet), "wrong error for scheduled secret: %v", err) {
+			assert.WithinDuration(t, activation, notYet.NotBefore, time.Second, "activation time differs")
+		}
+	}
+	peeked, err = repo.Peek(ctx, scheduled.ID)
+	if err != nil {
+		t.Errorf("scheduled secret is not kept : %v", err)
+		return
+	}
+	assert.Zero(t, peeked.Views, "view of scheduled secret is counted")
+	active, err := repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("activated secret from repo %s", name)),
+		Meta:      map[string]string{"repo": name},
+		CreatedAt: now.Add(-time.Minute),
+		ExpireAt:  now.Add(5 * time.Minute),
+		NotBefore: now.Add(-time.Second),
+		Version:   1,
+	})
+	if err != nil {
+		t.Errorf("error creating activated secret : %v", err)
+		return
+	}
+	_, err = repo.FindAndDeleteByID(ctx, active.ID)
+	if err != nil {
+		t.Errorf("error reading activated secret : %v", err)
+		return
+	}
+	err = repo.DeleteByID(ctx, scheduled.ID)
+	if err != nil {
+		t.Errorf("error deleting scheduled secret : %v", err)
+		return
+	}
+	t.Logf("Repo %s keeps scheduled secrets until they are available", name)
+
+	shareTokenHash := misc.UUID()
+	shared, err := repo.Create(ctx, model.Secret{
+		Body:           []byte(fmt.Sprintf("shared secret from repo %s", name)),
+		Meta:           map[string]string{"repo": name},
+		CreatedAt:      now,
+		ExpireAt:       now.Add(5 * time.Minute),
+		Version:        1,
+		ShareTokenHash: shareTokenHash,
+	})
+	if err != nil {
+		t.Errorf("error creating shared secret : %v", err)
+		return
+	}
+	sharedID, err := repo.FindIDByShareToken(ctx, shareTokenHash)
+	if err != nil {
+		t.Errorf("error finding secret by share token : %v", err)
+		return
+	}
+	assert.Equal(t, shared.ID, sharedID, "wrong secret found by share token")
+	peeked, err = repo.Peek(ctx, shared.ID)
+	if err != nil {
+		t.Errorf("error peeking shared secret : %v", err)
+		return
+	}
+	assert.Equal(t, shareTokenHash, peeked.ShareTokenHash, "share token hash differs")
+	_, err = repo.FindIDByShareToken(ctx, misc.UUID())
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error for unknown share token: %v", err)
+	expiredTokenHash := misc.UUID()
+	_, err = repo.Create(ctx, model.Secret{
+		Body:           []byte(fmt.Sprintf("expired shared secret from repo %s", name)),
+		CreatedAt:      now.Add(-time.Hour),
+		ExpireAt:       now.Add(-time.Minute),
+		ShareTokenHash: expiredTokenHash,
+	})
+	if err != nil {
+		t.Errorf("error creating expired shared secret : %v", err)
+		return
+	}
+	_, err = repo.FindIDByShareToken(ctx, expiredTokenHash)
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error for share token of expired secret: %v", err)
+	err = repo.DeleteByID(ctx, shared.ID)
+	if err != nil {
+		t.Errorf("error deleting shared secret : %v", err)
+		return
+	}
+	_, err = repo.FindIDByShareToken(ctx, shareTokenHash)
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error for share token of deleted secret: %v", err)
+	burnedTokenHash := misc.UUID()
+	burned, err := repo.Create(ctx, model.Secret{
+		Body:           []byte(fmt.Sprintf("burned shared secret from repo %s", name)),
+		CreatedAt:      now,
+		ExpireAt:       now.Add(5 * time.Minute),
+		ShareTokenHash: burnedTokenHash,
+	})
+	if err != nil {
+		t.Errorf("error creating shared secret to burn : %v", err)
+		return
+	}
+	_, err = repo.FindAndDeleteByID(ctx, burned.ID)
+	if err != nil {
+		t.Errorf("error burning shared secret : %v", err)
+		return
+	}
+	_, err = repo.FindIDByShareToken(ctx, burnedTokenHash)
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error for share token of burned secret: %v", err)
+	t.Logf("Repo %s finds secrets by share token", name)
+
+	opaque, err := repo.Create(ctx, model.Secret{
+		Body:        []byte{0x00, 0xff, 0x10, 0x80},
+		ContentType: "application/octet-stream",
+		CreatedAt:   now,
+		ExpireAt:    now.Add(5 * time.Minute),
+		Version:     1,
+		Opaque:      true,
+	})
+	if err != nil {
+		t.Errorf("error creating opaque secret : %v", err)
+		return
+	}
+	peeked, err = repo.Peek(ctx, opaque.ID)
+	if err != nil {
+		t.Errorf("error peeking opaque secret : %v", err)
+		return
+	}
+	assert.True(t, peeked.Opaque, "opaque mark is lost")
+	assert.Equal(t, []byte{0x00, 0xff, 0x10, 0x80}, peeked.Body, "opaque body differs")
+	err = repo.DeleteByID(ctx, opaque.ID)
+	if err != nil {
+		t.Errorf("error deleting opaque secret : %v", err)
+		return
+	}
+	t.Logf("Repo %s keeps opaque mark of secrets", name)
+
+	lister := "lister-" + misc.UUID()
+	base := now.Truncate(time.Second)
+	ownSecrets := make([]model.Secret, 0, 4)
+	for i, offset := range []time.Duration{-3 * time.Minute, -2 * time.Minute, -2 * time.Minute, -time.Minute} {
+		meta := map[string]string{"repo": name}
+		if i%2 == 0 {
+			meta["flag"] = "on"
+		}
+		created, cErr := repo.Create(ctx, model.Secret{
+			Body:      []byte(fmt.Sprintf("ownSecrets body %v from repo %s", i, name)),
+			Meta:      meta,
+			Owner:     lister,
+			CreatedAt: base.Add(offset),
+			ExpireAt:  now.Add(5 * time.Minute),
+		})
+		if cErr != nil {
+			t.Errorf("error creating ownSecrets secret : %v", cErr)
+			return
+		}
+		ownSecrets = append(ownSecrets, created)
+	}
+	// секреты, созданные одновременно, упорядочены по идентификатору
+	if ownSecrets[2].ID < ownSecrets[1].ID {
+		ownSecrets[1], ownSecrets[2] = ownSecrets[2], ownSecrets[1]
+	}
+	all := list(t, repo, repository.ListFilter{Owner: lister})
+	assert.Equal(t, []string{ownSecrets[0].ID, ownSecrets[1].ID, ownSecrets[2].ID, ownSecrets[3].ID}, ids(all), "wrong secrets ownSecrets")
+	for i := range all {
+		assert.Empty(t, all[i].Body, "body is ownSecrets")
+		assert.Equal(t, lister, all[i].Owner, "owner is not ownSecrets")
+		assert.Equal(t, name, all[i].Meta["repo"], "meta is not ownSecrets")
+	}
+	assert.Equal(t, []string{ownSecrets[1].ID, ownSecrets[2].ID}, ids(list(t, repo, repository.ListFilter{
+		Owner:         lister,
+		CreatedAfter:  base.Add(-2 * time.Minute),
+		CreatedBefore: base.Add(-time.Minute),
+	})), "wrong secrets ownSecrets in range")
+	flagged := list(t, repo, repository.ListFilter{Owner: lister, MetaKey: "flag"})
+	assert.Len(t, flagged, 2, "wrong number of secrets ownSecrets by meta key")
+	for i := range flagged {
+		assert.Equal(t, "on", flagged[i].Meta["flag"], "secret without meta key is ownSecrets")
+	}
+	_, _, err = repo.List(ctx, repository.ListFilter{Cursor: "not a cursor", Limit: 1})
+	assert.True(t, errors.Is(err, model.ErrInvalidCursor), "wrong error for malformed cursor: %v", err)
+	t.Logf("Repo %s lists secrets page by page", name)
+
+	_, err = repo.Create(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("expired secret of lister from repo %s", name)),
+		Owner:     lister,
+		CreatedAt: now.Add(-time.Hour),
+		ExpireAt:  now.Add(-time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating expired secret : %v", err)
+		return
+	}
+	count, err := repo.CountByOwner(ctx, lister)
+	if err != nil {
+		t.Errorf("error counting secrets : %v", err)
+		return
+	}
+	assert.Equal(t, int64(len(ownSecrets)), count, "wrong number of active secrets")
+	err = repo.DeleteByID(ctx, ownSecrets[0].ID)
+	if err != nil {
+		t.Errorf("error deleting secret : %v", err)
+		return
+	}
+	count, err = repo.CountByOwner(ctx, lister)
+	if err != nil {
+		t.Errorf("error counting secrets : %v", err)
+		return
+	}
+	assert.Equal(t, int64(len(ownSecrets)-1), count, "deleted secret is counted")
+	_, err = repo.FindAndDeleteByID(ctx, ownSecrets[1].ID)
+	if err != nil {
+		t.Errorf("error burning secret : %v", err)
+		return
+	}
+	count, err = repo.CountByOwner(ctx, lister)
+	if err != nil {
+		t.Errorf("error counting secrets : %v", err)
+		return
+	}
+	assert.Equal(t, int64(len(ownSecrets)-2), count, "burned secret is counted")
+	count, err = repo.CountByOwner(ctx, "nobody-"+misc.UUID())
+	if err != nil {
+		t.Errorf("error counting secrets : %v", err)
+		return
+	}
+	assert.Zero(t, count, "secrets of unknown owner are counted")
+	t.Logf("Repo %s counts active secrets of owner", name)
+
+	quoted := "quoted-" + misc.UUID()
+	_, err = repo.CreateWithinQuota(ctx, model.Secret{
+		Body:      []byte(fmt.Sprintf("expired secret of quoted owner from repo %s", name)),
+		Owner:     quoted,
+		CreatedAt: now.Add(-time.Hour),
+		ExpireAt:  now.Add(-time.Minute),
+	}, secretsQuota)
+	if err != nil {
+		t.Errorf("error creating expired secret within quota : %v", err)
+		return
+	}
+	var created, rejected int32
+	for i := 0; i < parallelCreators; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, lErr := repo.CreateWithinQuota(ctx, model.Secret{
+				Body:      []byte(fmt.Sprintf("secret of quoted owner from repo %s", name)),
+				Owner:     quoted,
+				CreatedAt: now,
+				ExpireAt:  now.Add(time.Hour),
+			}, secretsQuota)
+			if lErr != nil {
+				if errors.Is(lErr, model.ErrQuotaExceeded) {
+					atomic.AddInt32(&rejected, 1)
+					return
+				}
+				t.Errorf("error creating secret within quota : %v", lErr)
+				return
+			}
+			atomic.AddInt32(&created, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(secretsQuota), created, "wrong number of secrets created within quota")
+	assert.Equal(t, int32(parallelCreators-secretsQuota), rejected, "wrong number of secrets rejected by quota")
+	count, err = repo.CountByOwner(ctx, quoted)
+	if err != nil {
+		t.Errorf("error counting secrets : %v", err)
+		return
+	}
+	assert.Equal(t, secretsQuota, count, "quota is exceeded by parallel creators")
+	t.Logf("Repo %s allows only %v of %v parallel creators to fill quota", name, secretsQuota, parallelCreators)
+
+	auditRepo, ok := repo.(repository.AuditRepo)
+	if ok {
+		validateAudit(t, name, auditRepo)
+	}
+	webhookRepo, ok := repo.(repository.WebhookRepo)
+	if ok {
+		validateWebhooks(t, name, webhookRepo)
+	}
+	tombstoneRepo, ok := repo.(repository.TombstoneRepo)
+	if ok {
+		validateTombstones(t, name, tombstoneRepo)
+	}
+	requestRepo, ok := repo.(repository.RequestRepo)
+	if ok {
+		validateRequests(t, name, requestRepo)
+	}
+}
+
+// validateRequests проверяет запросы секретов, если репозиторий их хранит
+func validateRequests(t *testing.T, name string, repo repository.RequestRepo) {
+	ctx := context.TODO()
+	_, err := repo.FindRequest(ctx, misc.UUID())
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "wrong error for unknown request %v", err)
+	_, err = repo.FindRequestByToken(ctx, "unknown-"+misc.UUID())
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "wrong error for unknown request token %v", err)
+	createdAt := time.Now().Truncate(time.Millisecond)
+	tokenHash := "request-" + misc.UUID()
+	request, err := repo.CreateRequest(ctx, model.SecretRequest{
+		Requester:   "requester",
+		Description: "database password, please",
+		CreatedAt:   createdAt,
+		ExpireAt:    createdAt.Add(time.Hour),
+		TokenHash:   tokenHash,
+	})
+	if err != nil {
+		t.Errorf("error creating request : %v", err)
+		return
+	}
+	if request.ID == "" {
+		t.Errorf("request id is not assigned")
+		return
+	}
+	found, err := repo.FindRequestByToken(ctx, tokenHash)
+	if err != nil {
+		t.Errorf("error finding request by token : %v", err)
+		return
+	}
+	assert.Equal(t, request.ID, found.ID)
+	assert.Equal(t, "requester", found.Requester)
+	assert.Equal(t, "database password, please", found.Description)
+	assert.True(t, createdAt.Equal(found.CreatedAt.Local()), "wrong creation time %s", found.CreatedAt)
+	assert.False(t, found.Fulfilled(), "new request is fulfilled")
+
+	fulfilledAt := time.Now().Truncate(time.Millisecond)
+	err = repo.FulfilRequest(ctx, request.ID, "secret-1", fulfilledAt)
+	if err != nil {
+		t.Errorf("error fulfilling request : %v", err)
+		return
+	}
+	err = repo.FulfilRequest(ctx, request.ID, "secret-2", time.Now())
+	assert.True(t, errors.Is(err, model.ErrRequestFulfilled), "request is fulfilled twice %v", err)
+	found, err = repo.FindRequest(ctx, request.ID)
+	if err != nil {
+		t.Errorf("error finding request : %v", err)
+		return
+	}
+	assert.Equal(t, "secret-1", found.SecretID, "first secret is replaced")
+	assert.True(t, fulfilledAt.Equal(found.FulfilledAt.Local()), "wrong fulfilment time %s", found.FulfilledAt)
+	err = repo.FulfilRequest(ctx, misc.UUID(), "secret-3", time.Now())
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "unknown request is fulfilled %v", err)
+
+	err = repo.DeleteRequest(ctx, request.ID)
+	if err != nil {
+		t.Errorf("error deleting request : %v", err)
+		return
+	}
+	_, err = repo.FindRequestByToken(ctx, tokenHash)
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "deleted request is found %v", err)
+
+	expired, err := repo.CreateRequest(ctx, model.SecretRequest{
+		Requester: "requester",
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpireAt:  time.Now().Add(-time.Minute),
+		TokenHash: "expired-" + misc.UUID(),
+	})
+	if err != nil {
+		t.Errorf("error creating expired request : %v", err)
+		return
+	}
+	err = repo.FulfilRequest(ctx, expired.ID, "secret-4", time.Now())
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "expired request is fulfilled %v", err)
+	err = repo.PruneRequests(ctx)
+	if err != nil {
+		t.Errorf("error pruning requests : %v", err)
+		return
+	}
+	_, err = repo.FindRequest(ctx, expired.ID)
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "expired request is found %v", err)
+	t.Logf("Repo %s keeps secret requests", name)
+}
+
+// validateTombstones проверяет записи об исчезнувших секретах, если репозиторий их хранит
+func validateTombstones(t *testing.T, name string, repo repository.TombstoneRepo) {
+	ctx := context.TODO()
+	secretID := misc.UUID()
+	_, err := repo.FindTombstone(ctx, secretID)
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error for unknown tombstone %v", err)
+	deletedAt := time.Now().Truncate(time.Millisecond)
+	tombstone := model.Tombstone{
+		SecretID:  secretID,
+		Reason:    model.TombstoneDeleted,
+		DeletedAt: deletedAt,
+		ExpireAt:  deletedAt.Add(time.Hour),
+	}
+	err = repo.SaveTombstone(ctx, tombstone)
+	if err != nil {
+		t.Errorf("error saving tombstone : %v", err)
+		return
+	}
+	tombstone.Reason = model.TombstoneRead
+	err = repo.SaveTombstone(ctx, tombstone)
+	if err != nil {
+		t.Errorf("error replacing tombstone : %v", err)
+		return
+	}
+	found, err := repo.FindTombstone(ctx, secretID)
+	if err != nil {
+		t.Errorf("error finding tombstone : %v", err)
+		return
+	}
+	assert.Equal(t, secretID, found.SecretID)
+	assert.Equal(t, model.TombstoneRead, found.Reason, "tombstone is not replaced")
+	assert.True(t, deletedAt.Equal(found.DeletedAt.Local()), "wrong deletion time %s", found.DeletedAt)
+
+	expiredID := misc.UUID()
+	err = repo.SaveTombstone(ctx, model.Tombstone{
+		SecretID:  expiredID,
+		Reason:    model.TombstoneExpired,
+		DeletedAt: time.Now().Add(-time.Hour),
+		ExpireAt:  time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error saving expired tombstone : %v", err)
+		return
+	}
+	err = repo.PruneTombstones(ctx)
+	if err != nil {
+		t.Errorf("error pruning tombstones : %v", err)
+		return
+	}
+	_, err = repo.FindTombstone(ctx, expiredID)
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "expired tombstone is found %v", err)
+	t.Logf("Repo %s keeps tombstones of deleted secrets", name)
+}
+
+// validateWebhooks проверяет подписки на события секретов и недоставленные уведомления, если репозиторий их хранит
+func validateWebhooks(t *testing.T, name string, repo repository.WebhookRepo) {
+	ctx := context.TODO()
+	secretID := "subscribed-" + misc.UUID()
+	_, err := repo.FindSubscription(ctx, secretID)
+	assert.True(t, errors.Is(err, model.ErrSubscriptionNotFound), "wrong error for unknown subscription %v", err)
+	subscription := model.WebhookSubscription{
+		SecretID: secretID,
+		Owner:    "webhooks",
+		URL:      "https://example.org/hook",
+		ExpireAt: time.Now().Add(time.Hour).Truncate(time.Millisecond),
+	}
+	err = repo.SaveSubscription(ctx, subscription)
+	if err != nil {
+		t.Errorf("error saving subscription : %v", err)
+		return
+	}
+	subscription.Read = true
+	err = repo.SaveSubscription(ctx, subscription)
+	if err != nil {
+		t.Errorf("error replacing subscription : %v", err)
+		return
+	}
+	found, err := repo.FindSubscription(ctx, secretID)
+	if err != nil {
+		t.Errorf("error finding subscription : %v", err)
+		return
+	}
+	assert.Equal(t, subscription.Owner, found.Owner)
+	assert.Equal(t, subscription.URL, found.URL)
+	assert.True(t, found.Read, "subscription is not replaced")
+	assert.True(t, subscription.ExpireAt.Equal(found.ExpireAt.Local()), "wrong expiration %s", found.ExpireAt)
+	err = repo.DeleteSubscription(ctx, secretID)
+	if err != nil {
+		t.Errorf("error deleting subscription : %v", err)
+		return
+	}
+	_, err = repo.FindSubscription(ctx, secretID)
+	assert.True(t, errors.Is(err, model.ErrSubscriptionNotFound), "deleted subscription is found %v", err)
+
+	expiredID := "subscribed-" + misc.UUID()
+	err = repo.SaveSubscription(ctx, model.WebhookSubscription{
+		SecretID: expiredID,
+		URL:      "https://example.org/hook",
+		ExpireAt: time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error saving expired subscription : %v", err)
+		return
+	}
+	err = repo.PruneSubscriptions(ctx)
+	if err != nil {
+		t.Errorf("error pruning subscriptions : %v", err)
+		return
+	}
+	_, err = repo.FindSubscription(ctx, expiredID)
+	assert.True(t, errors.Is(err, model.ErrSubscriptionNotFound), "expired subscription is found %v", err)
+
+	delivery, err := repo.RecordDeadLetter(ctx, model.WebhookDelivery{
+		SecretID:  secretID,
+		URL:       "https://example.org/hook",
+		EventType: model.WebhookSecretRead,
+		Payload:   []byte(`{"specversion":"1.0"}`),
+		Attempts:  3,
+		LastError: "unexpected status 500 Internal Server Error",
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Errorf("error recording dead letter : %v", err)
+		return
+	}
+	assert.NotEmpty(t, delivery.ID, "dead letter has no id")
+	deadLetters, err := repo.ListDeadLetters(ctx, secretID)
+	if err != nil {
+		t.Errorf("error listing dead letters : %v", err)
+		return
+	}
+	if assert.Len(t, deadLetters, 1, "wrong dead letters") {
+		assert.Equal(t, delivery.ID, deadLetters[0].ID)
+		assert.Equal(t, model.WebhookSecretRead, deadLetters[0].EventType)
+		assert.Equal(t, delivery.Payload, deadLetters[0].Payload)
+		assert.Equal(t, int64(3), deadLetters[0].Attempts)
+		assert.Equal(t, delivery.LastError, deadLetters[0].LastError)
+	}
+	t.Logf("Repo %s keeps webhook subscriptions and dead letters", name)
+}
+
+// validateAudit проверяет журнал аудита репозитория, если репозиторий его ведёт
+func validateAudit(t *testing.T, name string, repo repository.AuditRepo) {
+	ctx := context.TODO()
+	secretID := "audited-" + misc.UUID()
+	otherSecretID := "audited-" + misc.UUID()
+	alice := "alice-" + misc.UUID()
+	bob := "bob-" + misc.UUID()
+	recorded := make([]model.AuditEvent, 0, 4)
+	for _, event := range []model.AuditEvent{
+		{SecretID: secretID, Action: model.AuditCreate, Subject: alice, Transport: "http", RemoteIP: "127.0.0.1", UserAgent: "repotest", TraceID: "trace"},
+		{SecretID: secretID, Action: model.AuditDenied, Subject: bob, Transport: "grpc", Detail: "forbidden"},
+		{SecretID: secretID, Action: model.AuditRead, Subject: alice, Transport: "http"},
+		{SecretID: otherSecretID, Action: model.AuditCreate, Subject: alice, Transport: "http"},
+	} {
+		event.CreatedAt = time.Now()
+		saved, err := repo.Record(ctx, event)
+		if err != nil {
+			t.Errorf("error recording audit event : %v", err)
+			return
+		}
+		assert.NotEmpty(t, saved.ID, "audit event has no id")
+		recorded = append(recorded, saved)
+		// события в журнале различаются по времени, чтобы проверить выборку по нему
+		time.Sleep(5 * time.Millisecond)
+	}
+	events := queryAudit(t, repo, repository.AuditFilter{SecretID: secretID})
+	if assert.Len(t, events, 3, "wrong events of secret") {
+		assert.Equal(t, []string{recorded[0].ID, recorded[1].ID, recorded[2].ID},
+			[]string{events[0].ID, events[1].ID, events[2].ID}, "wrong order of events")
+		assert.Equal(t, model.AuditCreate, events[0].Action)
+		assert.Equal(t, alice, events[0].Subject)
+		assert.Equal(t, "http", events[0].Transport)
+		assert.Equal(t, "127.0.0.1", events[0].RemoteIP)
+		assert.Equal(t, "repotest", events[0].UserAgent)
+		assert.Equal(t, "trace", events[0].TraceID)
+		assert.Equal(t, "forbidden", events[1].Detail)
+
+		// границы берутся из журнала, потому что репозитории хранят время с разной точностью
+		between := queryAudit(t, repo, repository.AuditFilter{
+			SecretID: secretID,
+			Since:    events[1].CreatedAt,
+			Until:    events[2].CreatedAt,
+		})
+		if assert.Len(t, between, 1, "wrong events in time range") {
+			assert.Equal(t, recorded[1].ID, between[0].ID)
+		}
+	}
+	events = queryAudit(t, repo, repository.AuditFilter{Subject: alice})
+	assert.Len(t, events, 3, "wrong events of subject")
+	events = queryAudit(t, repo, repository.AuditFilter{SecretID: secretID, Subject: bob})
+	if assert.Len(t, events, 1, "wrong events of subject with secret") {
+		assert.Equal(t, model.AuditDenied, events[0].Action)
+	}
+	_, _, err := repo.Query(ctx, repository.AuditFilter{Cursor: "spoiled", Limit: 1})
+	assert.True(t, errors.Is(err, model.ErrInvalidCursor), "wrong error for spoiled cursor %v", err)
+	t.Logf("Repo %s keeps audit trail", name)
+}
+
+// queryAudit обходит все страницы журнала аудита по одному событию за раз
+func queryAudit(t *testing.T, repo repository.AuditRepo, filter repository.AuditFilter) []model.AuditEvent {
+	ret := make([]model.AuditEvent, 0)
+	filter.Limit = 1
+	for {
+		page, next, err := repo.Query(context.TODO(), filter)
+		if err != nil {
+			t.Errorf("error querying audit trail : %v", err)
+			return ret
+		}
+		ret = append(ret, page...)
+		if next == "" {
+			return ret
+		}
+		filter.Cursor = next
+	}
+}
+
+// list обходит все страницы List по одному секрету за раз
+func list(t *testing.T, repo repository.SecretRepo, filter repository.ListFilter) []model.Secret {
+	ret := make([]model.Secret, 0)
+	filter.Limit = 1
+	for {
+		page, next, err := repo.List(context.TODO(), filter)
+		if err != nil {
+			t.Errorf("error listing secrets : %v", err)
+			return ret
+		}
+		assert.LessOrEqual(t, len(page), 1, "page is too long")
+		ret = append(ret, page...)
+		if next == "" {
+			return ret
+		}
+		filter.Cursor = next
+	}
+}
+
+// ids возвращает идентификаторы секретов
+func ids(secrets []model.Secret) []string {
+	ret := make([]string, 0, len(secrets))
+	for i := range secrets {
+		ret = append(ret, secrets[i].ID)
+	}
+	return ret
+}
+
+// findByRecipient возвращает идентификаторы секретов, адресованных субъекту или группам
+func findByRecipient(t *testing.T, repo repository.SecretRepo, subject string, groups []string) []string {
+	secrets, err := repo.FindByRecipient(context.TODO(), subject, groups)
+	if err != nil {
+		t.Errorf("error finding secrets by recipient : %v", err)
+		return nil
+	}
+	ret := make([]string, 0, len(secrets))
+	for i := range secrets {
+		ret = append(ret, secrets[i].ID)
+	}
+	return ret
+}
+
+// listVersionWrappedKeys обходит все страницы ListVersionWrappedKeys по одной версии за раз,
+// версии в ответе названы "<идентификатор секрета>|<номер версии>"
+func listVersionWrappedKeys(t *testing.T, repo repository.SecretRepo, exceptKeyID string) map[string]model.SecretVersion {
+	ret := make(map[string]model.SecretVersion, 0)
+	cursor := ""
+	for {
+		page, next, err := repo.ListVersionWrappedKeys(context.TODO(), exceptKeyID, cursor, 1)
+		if err != nil {
+			t.Errorf("error listing wrapped keys of versions : %v", err)
+			return ret
+		}
+		for i := range page {
+			assert.NotEqual(t, exceptKeyID, page[i].KeyID, "version with excepted key id is listed")
+			ret[fmt.Sprintf("%s|%v", page[i].SecretID, page[i].Version)] = page[i]
+		}
+		if next == "" {
+			return ret
+		}
+		cursor = next
+	}
+}
+
+// listWrappedKeys обходит все страницы ListWrappedKeys по одному секрету за раз
+func listWrappedKeys(t *testing.T, repo repository.SecretRepo, exceptKeyID string) map[string]model.Secret {
+	ret := make(map[string]model.Secret, 0)
+	cursor := ""
+	for {
+		page, next, err := repo.ListWrappedKeys(context.TODO(), exceptKeyID, cursor, 1)
+		if err != nil {
+			t.Errorf("error listing wrapped keys : %v", err)
+			return ret
+		}
+		for i := range page {
+			assert.NotEqual(t, exceptKeyID, page[i].KeyID, "secret with excepted key id is listed")
+			ret[page[i].ID] = page[i]
+		}
+		if next == "" {
+			return ret
+		}
+		cursor = next
+	}
 }
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultAdminGroup задаёт группу администраторов, которым доступен журнал аудита, по умолчанию
+const DefaultAdminGroup = "admin"
+
+type clientContextKey struct{}
+
+// WithClient сохраняет в контексте сведения о клиенте, от которого пришёл запрос, чтобы они попали в журнал аудита
+func WithClient(ctx context.Context, client model.Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// clientFromContext извлекает из контекста сведения о клиенте, сохранённые WithClient
+func clientFromContext(ctx context.Context) model.Client {
+	client, _ := ctx.Value(clientContextKey{}).(model.Client)
+	return client
+}
+
+// AuditService ведёт журнал аудита событий в жизни секретов и отдаёт его администраторам
+type AuditService struct {
+	Tracer trace.Tracer
+	Repo   repository.AuditRepo
+	// AdminGroup задаёт группу, субъектам из которой доступен журнал. Если не задана, используется DefaultAdminGroup
+	AdminGroup string
+}
+
+// Record записывает событие в журнал, дополняя его сведениями о клиенте из контекста, идентификатором трейса и временем.
+// Ошибка записи отмечается в span, но не мешает операции с секретом. Если сервис не задан, ничего не записывается
+func (as *AuditService) Record(ctx context.Context, event model.AuditEvent) {
+	if as == nil || as.Repo == nil {
+		return
+	}
+	client := clientFromContext(ctx)
+	if event.Transport == "" {
+		event.Transport = client.Transport
+	}
+	event.RemoteIP = client.RemoteIP
+	event.UserAgent = client.UserAgent
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.HasTraceID() {
+		event.TraceID = spanContext.TraceID().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	_, err := as.Repo.Record(ctx, event)
+	if err != nil {
+		span := trace.SpanFromContext(ctx)
+		span.AddEvent("Audit event is not recorded: " + err.Error())
+		span.RecordError(err)
+	}
+}
+
+// Query возвращает страницу журнала аудита и курсор следующей страницы. Журнал доступен только субъектам из группы AdminGroup
+func (as *AuditService) Query(ctx context.Context, identity model.Identity, filter repository.AuditFilter) ([]model.AuditEvent, string, error) {
+	ctxWithTracing, span := as.Tracer.Start(ctx, "service.QueryAudit")
+	defer span.End()
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	span.SetAttributes(attribute.String("cursor", filter.Cursor))
+	adminGroup := as.AdminGroup
+	if adminGroup == "" {
+		adminGroup = DefaultAdminGroup
+	}
+	if identity.Subject == "" || !identity.InGroup(adminGroup) {
+		span.SetStatus(codes.Error, model.ErrForbidden.Error())
+		return nil, "", model.ErrForbidden
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+	events, next, err := as.Repo.Query(ctxWithTracing, filter)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, "", err
+	}
+	span.SetAttributes(attribute.Int("found", len(events)))
+	span.SetAttributes(attribute.String("next", next))
+	return events, next, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+)
+
+func TestSecretService_Audit(t *testing.T) {
+	ctx := WithClient(context.TODO(), model.Client{Transport: "http", RemoteIP: "192.0.2.1", UserAgent: "unit_test"})
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	as := AuditService{
+		Tracer: otel.Tracer("unit_test_service"),
+		Repo:   repo,
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service"),
+		Repo:   repo,
+		Audit:  &as,
+	}
+	alice := model.Identity{Subject: "alice"}
+	admin := model.Identity{Subject: "root", Groups: []string{DefaultAdminGroup}}
+
+	secret, err := ss.Create(ctx, model.SecretParams{Body: []byte("audited"), Owner: "alice"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, secret.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	assert.True(t, errors.Is(err, model.ErrForbidden), "wrong error %v", err)
+	_, err = ss.FindByID(ctx, secret.ID, ReadOptions{Identity: alice, Burn: true})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+
+	expired, err := repo.Create(ctx, model.Secret{
+		Body:      []byte("expired"),
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpireAt:  time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error creating expired secret: %s", err)
+	}
+	err = ss.Prune(WithClient(context.TODO(), model.Client{Transport: "prune"}))
+	if err != nil {
+		t.Fatalf("error pruning secrets: %s", err)
+	}
+
+	_, _, err = as.Query(ctx, alice, repository.AuditFilter{})
+	assert.True(t, errors.Is(err, model.ErrForbidden), "audit trail is visible to non admin: %v", err)
+
+	events, next, err := as.Query(ctx, admin, repository.AuditFilter{SecretID: secret.ID})
+	if err != nil {
+		t.Fatalf("error querying audit trail: %s", err)
+	}
+	assert.Empty(t, next)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, []model.AuditAction{model.AuditCreate, model.AuditDenied, model.AuditRead},
+			[]model.AuditAction{events[0].Action, events[1].Action, events[2].Action})
+		assert.Equal(t, "alice", events[0].Subject)
+		assert.Equal(t, "bob", events[1].Subject)
+		assert.Equal(t, "burned", events[2].Detail)
+		for i := range events {
+			assert.Equal(t, "http", events[i].Transport)
+			assert.Equal(t, "192.0.2.1", events[i].RemoteIP)
+			assert.Equal(t, "unit_test", events[i].UserAgent)
+		}
+	}
+
+	events, _, err = as.Query(ctx, admin, repository.AuditFilter{SecretID: expired.ID})
+	if err != nil {
+		t.Fatalf("error querying audit trail: %s", err)
+	}
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, model.AuditExpire, events[0].Action)
+		assert.Equal(t, "prune", events[0].Transport)
+	}
+}
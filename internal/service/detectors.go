@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/pem"
+	"regexp"
+	"strings"
+)
+
+// PrivateKeyDetector находит в теле секрета закрытые ключи в формате PEM, не защищённые паролем
+type PrivateKeyDetector struct{}
+
+// Name возвращает название правила
+func (d PrivateKeyDetector) Name() string {
+	return "private_key"
+}
+
+// Detect ищет закрытые ключи, не защищённые паролем
+func (d PrivateKeyDetector) Detect(_ context.Context, candidate Candidate) string {
+	rest := candidate.Body
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return ""
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") || block.Type == "ENCRYPTED PRIVATE KEY" {
+			continue
+		}
+		if strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+			continue
+		}
+		if block.Type == "OPENSSH PRIVATE KEY" && openSSHKeyEncrypted(block.Bytes) {
+			continue
+		}
+		return "unencrypted " + strings.ToLower(block.Type) + " is found"
+	}
+}
+
+// openSSHMagic открывает закрытые ключи OpenSSH
+var openSSHMagic = []byte("openssh-key-v1\x00")
+
+// openSSHKeyEncrypted проверяет, что закрытый ключ OpenSSH зашифрован, то есть его шифр - не none
+func openSSHKeyEncrypted(key []byte) bool {
+	if !bytes.HasPrefix(key, openSSHMagic) {
+		return false
+	}
+	key = key[len(openSSHMagic):]
+	if len(key) < 4 {
+		return false
+	}
+	length := binary.BigEndian.Uint32(key)
+	if uint64(len(key)-4) < uint64(length) {
+		return false
+	}
+	return string(key[4:4+length]) != "none"
+}
+
+// CardNumberDetector находит в теле секрета номера платёжных карт, проходящие проверку по алгоритму Луна
+type CardNumberDetector struct{}
+
+// Name возвращает название правила
+func (d CardNumberDetector) Name() string {
+	return "card_number"
+}
+
+// cardNumberExpression находит последовательности из 13-19 цифр, возможно, разделённых пробелами или дефисами
+var cardNumberExpression = regexp.MustCompile(`(?:^|[^0-9])((?:[0-9][ -]?){12,18}[0-9])(?:[^0-9]|$)`)
+
+// Detect ищет номера платёжных карт
+func (d CardNumberDetector) Detect(_ context.Context, candidate Candidate) string {
+	for _, match := range cardNumberExpression.FindAllSubmatch(candidate.Body, -1) {
+		digits := make([]byte, 0, len(match[1]))
+		for _, c := range match[1] {
+			if c >= '0' && c <= '9' {
+				digits = append(digits, c)
+			}
+		}
+		if luhn(digits) {
+			return "payment card number is found"
+		}
+	}
+	return ""
+}
+
+// luhn проверяет контрольную цифру номера по алгоритму Луна
+func luhn(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ClientDetector отклоняет секреты от клиентов, заголовок User-Agent которых подходит под регулярное выражение Denied
+type ClientDetector struct {
+	Denied *regexp.Regexp
+}
+
+// Name возвращает название правила
+func (d ClientDetector) Name() string {
+	return "client"
+}
+
+// Detect проверяет клиента, создающего секрет
+func (d ClientDetector) Detect(_ context.Context, candidate Candidate) string {
+	if d.Denied == nil {
+		return ""
+	}
+	userAgent := candidate.Meta["User-Agent"]
+	if d.Denied.MatchString(userAgent) {
+		return "client " + userAgent + " is not allowed"
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// EnrichmentMatch задаёт условия, при которых срабатывает правило обогащения метаданных.
+// Должны выполниться все заданные условия, правило без условий срабатывает всегда
+type EnrichmentMatch struct {
+	// Body - регулярное выражение, которое должно найтись в теле секрета
+	Body string `json:"body,omitempty"`
+	// MinLength - минимальная длина тела секрета в байтах
+	MinLength int64 `json:"minLength,omitempty"`
+	// MaxLength - максимальная длина тела секрета в байтах, 0 - без ограничений
+	MaxLength int64 `json:"maxLength,omitempty"`
+	// Meta - поля метаданных, которые должны быть у секрета, с регулярными выражениями для их значений.
+	// Пустое выражение означает, что подходит любое значение
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// EnrichmentRule задаёт правило обогащения метаданных секрета. Действия сработавшего правила выполняются
+// в порядке Rename, Remove, Set, а сами правила - в порядке их перечисления, так что каждое следующее
+// правило видит метаданные, изменённые предыдущими
+type EnrichmentRule struct {
+	// Name - название правила для журналов и трейсинга
+	Name string `json:"name"`
+	// Match - условия срабатывания правила
+	Match EnrichmentMatch `json:"match"`
+	// Rename - поля метаданных, которые переименовываются, старое название => новое
+	Rename map[string]string `json:"rename,omitempty"`
+	// Remove - поля метаданных, которые удаляются
+	Remove []string `json:"remove,omitempty"`
+	// Set - поля метаданных, которые добавляются или заменяются
+	Set map[string]string `json:"set,omitempty"`
+}
+
+// DefaultEnrichmentRules задаёт правила обогащения метаданных, которые используются, если свои правила не заданы
+var DefaultEnrichmentRules = []EnrichmentRule{
+	{
+		Name:  "golang",
+		Match: EnrichmentMatch{Body: "[Gg]olang"},
+		Set:   map[string]string{"programming": "yes"},
+	},
+}
+
+// compiledRule - правило обогащения с разобранными регулярными выражениями
+type compiledRule struct {
+	EnrichmentRule
+	body *regexp.Regexp
+	meta map[string]*regexp.Regexp
+}
+
+// compileRules проверяет правила и разбирает их регулярные выражения
+func compileRules(rules []EnrichmentRule) ([]compiledRule, error) {
+	ret := make([]compiledRule, len(rules))
+	for i := range rules {
+		rule := compiledRule{EnrichmentRule: rules[i]}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("#%v", i+1)
+		}
+		if rule.Match.MaxLength > 0 && rule.Match.MinLength > rule.Match.MaxLength {
+			return nil, fmt.Errorf("rule %s: minLength is greater than maxLength", rule.Name)
+		}
+		var err error
+		if rule.Match.Body != "" {
+			rule.body, err = regexp.Compile(rule.Match.Body)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: malformed body expression: %w", rule.Name, err)
+			}
+		}
+		rule.meta = make(map[string]*regexp.Regexp, len(rule.Match.Meta))
+		for k := range rule.Match.Meta {
+			if rule.Match.Meta[k] == "" {
+				rule.meta[k] = nil
+				continue
+			}
+			rule.meta[k], err = regexp.Compile(rule.Match.Meta[k])
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: malformed expression for meta field %s: %w", rule.Name, k, err)
+			}
+		}
+		ret[i] = rule
+	}
+	return ret, nil
+}
+
+// matches проверяет, что секрет с телом body и метаданными meta подходит под условия правила
+func (r compiledRule) matches(body []byte, meta map[string]string) bool {
+	length := int64(len(body))
+	if length < r.Match.MinLength {
+		return false
+	}
+	if r.Match.MaxLength > 0 && length > r.Match.MaxLength {
+		return false
+	}
+	for k, expression := range r.meta {
+		value, found := meta[k]
+		if !found {
+			return false
+		}
+		if expression != nil && !expression.MatchString(value) {
+			return false
+		}
+	}
+	if r.body != nil && !r.body.Match(body) {
+		return false
+	}
+	return true
+}
+
+// apply выполняет действия правила над метаданными
+func (r compiledRule) apply(meta map[string]string) {
+	for from, to := range r.Rename {
+		value, found := meta[from]
+		if !found {
+			continue
+		}
+		delete(meta, from)
+		meta[to] = value
+	}
+	for _, k := range r.Remove {
+		delete(meta, k)
+	}
+	for k := range r.Set {
+		meta[k] = r.Set[k]
+	}
+}
+
+// Enricher применяет к метаданным создаваемых секретов правила обогащения.
+// Правила можно перечитать из файла, не перезапуская приложение, вызвав Reload
+type Enricher struct {
+	path  string
+	mu    sync.RWMutex
+	rules []compiledRule
+}
+
+// NewEnricher создаёт Enricher с заданными правилами
+func NewEnricher(rules []EnrichmentRule) (*Enricher, error) {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &Enricher{rules: compiled}, nil
+}
+
+// LoadEnricher создаёт Enricher с правилами из JSON файла path, в котором лежит массив EnrichmentRule
+func LoadEnricher(path string) (*Enricher, error) {
+	e := &Enricher{path: path}
+	err := e.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload перечитывает правила из файла, из которого они были загружены. Если в файле ошибка,
+// остаются прежние правила. Enricher, созданный NewEnricher, перечитывать нечего
+func (e *Enricher) Reload() error {
+	if e.path == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(e.path)
+	if err != nil {
+		return fmt.Errorf("error reading enrichment rules from %s: %w", e.path, err)
+	}
+	var rules []EnrichmentRule
+	err = json.Unmarshal(raw, &rules)
+	if err != nil {
+		return fmt.Errorf("error parsing enrichment rules from %s: %w", e.path, err)
+	}
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return fmt.Errorf("error in enrichment rules from %s: %w", e.path, err)
+	}
+	e.mu.Lock()
+	e.rules = compiled
+	e.mu.Unlock()
+	return nil
+}
+
+// Len возвращает число действующих правил
+func (e *Enricher) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.rules)
+}
+
+// Apply применяет правила к метаданным meta секрета с телом body и возвращает названия сработавших правил
+func (e *Enricher) Apply(body []byte, meta map[string]string) []string {
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+	applied := make([]string, 0)
+	for i := range rules {
+		if !rules[i].matches(body, meta) {
+			continue
+		}
+		rules[i].apply(meta)
+		applied = append(applied, rules[i].Name)
+	}
+	return applied
+}
+
+// defaultEnricher применяет DefaultEnrichmentRules
+var defaultEnricher = func() *Enricher {
+	e, err := NewEnricher(DefaultEnrichmentRules)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}()
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// Limits задаёт серверные ограничения на размер секретов и их число у одного создателя.
+// Незаданные поля заменяются значениями по умолчанию из пакета model, отрицательные - снимают ограничение
+type Limits struct {
+	// MaxBodyBytes - максимальный размер тела секрета в байтах
+	MaxBodyBytes int64
+	// MaxMetaKeys - максимальное число полей метаданных
+	MaxMetaKeys int64
+	// MaxMetaKeyLength - максимальная длина названия поля метаданных в байтах
+	MaxMetaKeyLength int64
+	// MaxMetaValueLength - максимальная длина значения поля метаданных в байтах
+	MaxMetaValueLength int64
+	// MaxSecretsPerSubject - сколько действующих секретов может быть у одного создателя
+	MaxSecretsPerSubject int64
+}
+
+// limit возвращает значение ограничения с учётом значения по умолчанию, 0 - ограничения нет
+func limit(value, def int64) int64 {
+	if value == 0 {
+		return def
+	}
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+// BodyBytes возвращает максимальный размер тела секрета в байтах, 0 - без ограничений
+func (l Limits) BodyBytes() int64 {
+	return limit(l.MaxBodyBytes, model.MaxBodyBytes)
+}
+
+// SecretsPerSubject возвращает, сколько действующих секретов может быть у одного создателя, 0 - без ограничений
+func (l Limits) SecretsPerSubject() int64 {
+	return limit(l.MaxSecretsPerSubject, model.MaxSecretsPerSubject)
+}
+
+// Check проверяет, что тело и метаданные секрета укладываются в ограничения, иначе возвращает model.ErrTooLarge
+func (l Limits) Check(body []byte, meta map[string]string) error {
+	maxBody := l.BodyBytes()
+	if maxBody > 0 && int64(len(body)) > maxBody {
+		return fmt.Errorf("%w: body is %v bytes long, maximum is %v", model.ErrTooLarge, len(body), maxBody)
+	}
+	maxKeys := limit(l.MaxMetaKeys, model.MaxMetaKeys)
+	if maxKeys > 0 && int64(len(meta)) > maxKeys {
+		return fmt.Errorf("%w: %v meta fields, maximum is %v", model.ErrTooLarge, len(meta), maxKeys)
+	}
+	maxKey := limit(l.MaxMetaKeyLength, model.MaxMetaKeyLength)
+	maxValue := limit(l.MaxMetaValueLength, model.MaxMetaValueLength)
+	for k := range meta {
+		if maxKey > 0 && int64(len(k)) > maxKey {
+			return fmt.Errorf("%w: meta field name is %v bytes long, maximum is %v", model.ErrTooLarge, len(k), maxKey)
+		}
+		if maxValue > 0 && int64(len(meta[k])) > maxValue {
+			return fmt.Errorf("%w: meta field %q is %v bytes long, maximum is %v", model.ErrTooLarge, k, len(meta[k]), maxValue)
+		}
+	}
+	return nil
+}
+
+// checkQuota проверяет, что у создателя owner хватит места ещё на count секретов, иначе возвращает model.ErrQuotaExceeded.
+// Это только ранний отказ для операций, сохраняющих сразу несколько секретов: каждый из них всё равно
+// сохраняется createWithinQuota
+func (ss *SecretService) checkQuota(ctx context.Context, owner string, count int64) error {
+	maxSecrets := ss.Limits.SecretsPerSubject()
+	if maxSecrets == 0 {
+		return nil
+	}
+	active, err := ss.Repo.CountByOwner(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if active+count > maxSecrets {
+		return fmt.Errorf("%w: %s already has %v active secrets, maximum is %v", model.ErrQuotaExceeded, owner, active, maxSecrets)
+	}
+	return nil
+}
+
+// createWithinQuota сохраняет секрет, если у его создателя меньше Limits.SecretsPerSubject действующих секретов,
+// иначе возвращает model.ErrQuotaExceeded. Проверку и сохранение репозиторий выполняет атомарно,
+// так что одновременно созданные секреты не превышают ограничение
+func (ss *SecretService) createWithinQuota(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	maxSecrets := ss.Limits.SecretsPerSubject()
+	if maxSecrets == 0 {
+		return ss.Repo.Create(ctx, secret)
+	}
+	created, err := ss.Repo.CreateWithinQuota(ctx, secret, maxSecrets)
+	if errors.Is(err, model.ErrQuotaExceeded) {
+		return model.Secret{}, fmt.Errorf("%w: %s already has %v active secrets", model.ErrQuotaExceeded, secret.Owner, maxSecrets)
+	}
+	return created, err
+}
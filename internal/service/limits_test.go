@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+)
+
+func TestLimits_Check(t *testing.T) {
+	limits := Limits{
+		MaxBodyBytes:       10,
+		MaxMetaKeys:        2,
+		MaxMetaKeyLength:   5,
+		MaxMetaValueLength: 8,
+	}
+	testCases := []struct {
+		name     string
+		limits   Limits
+		body     []byte
+		meta     map[string]string
+		tooLarge bool
+	}{
+		{"fits", limits, []byte("0123456789"), map[string]string{"a": "12345678", "b": ""}, false},
+		{"body too large", limits, []byte("0123456789A"), nil, true},
+		{"too many meta fields", limits, nil, map[string]string{"a": "", "b": "", "c": ""}, true},
+		{"meta field name too long", limits, nil, map[string]string{"abcdef": ""}, true},
+		{"meta field value too long", limits, nil, map[string]string{"a": "123456789"}, true},
+		{"empty limits", Limits{}, make([]byte, model.MaxBodyBytes), nil, false},
+		{"empty limits with body too large", Limits{}, make([]byte, model.MaxBodyBytes+1), nil, true},
+		{"unlimited", Limits{MaxBodyBytes: -1, MaxMetaValueLength: -1}, make([]byte, model.MaxBodyBytes+1),
+			map[string]string{"a": strings.Repeat("x", model.MaxMetaValueLength+1)}, false},
+	}
+	for _, tc := range testCases {
+		err := tc.limits.Check(tc.body, tc.meta)
+		if tc.tooLarge != errors.Is(err, model.ErrTooLarge) {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+	}
+}
+
+func TestSecretService_Quota(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service"),
+		Repo:   repo,
+		Limits: Limits{MaxBodyBytes: 16, MaxSecretsPerSubject: 2},
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("much too large secret"), Owner: "alice"})
+	if !errors.Is(err, model.ErrTooLarge) {
+		t.Errorf("wrong error for large secret: %v", err)
+	}
+	first, err := ss.Create(ctx, model.SecretParams{Body: []byte("first"), Owner: "alice"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("second"), Owner: "alice"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("third"), Owner: "alice"})
+	if !errors.Is(err, model.ErrQuotaExceeded) {
+		t.Errorf("wrong error for secret over quota: %v", err)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("first"), Owner: "bob"})
+	if err != nil {
+		t.Errorf("quota of alice is applied to bob: %s", err)
+	}
+	_, err = ss.Update(ctx, first.ID, UpdateParams{
+		Body:     []byte("much too large secret"),
+		Identity: model.Identity{Subject: "alice"},
+	})
+	if !errors.Is(err, model.ErrTooLarge) {
+		t.Errorf("wrong error for large update: %v", err)
+	}
+	_, err = ss.FindByID(ctx, first.ID, ReadOptions{Burn: true, Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Fatalf("error burning secret: %s", err)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("third"), Owner: "alice"})
+	if err != nil {
+		t.Errorf("quota is not released after secret is burned: %s", err)
+	}
+}
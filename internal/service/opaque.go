@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/zeroknowledge"
+)
+
+// opaqueContentType - MIME тип тела, зашифрованного клиентом, если клиент не указал другой
+const opaqueContentType = "application/octet-stream"
+
+// detectContentType определяет MIME тип тела секрета по его началу. У зашифрованного клиентом тела
+// начало случайное, поэтому ему назначается opaqueContentType
+func detectContentType(body []byte, opaque bool) string {
+	if opaque {
+		return opaqueContentType
+	}
+	return http.DetectContentType(body)
+}
+
+// opaqueRule - название правила, по которому отклоняются секреты, зашифрованные клиентом
+const opaqueRule = "opaque"
+
+// checkOpaque проверяет тело секрета, зашифрованного клиентом. Если такие секреты запрещены RefuseOpaque
+// или тело короче шифротекста zeroknowledge.Encrypt, возвращается *model.ValidationError
+func (ss *SecretService) checkOpaque(body []byte, opaque bool) error {
+	if !opaque {
+		return nil
+	}
+	if ss.RefuseOpaque {
+		return &model.ValidationError{Violations: []model.Violation{{
+			Rule:    opaqueRule,
+			Message: "client-encrypted secrets are not allowed",
+		}}}
+	}
+	if len(body) < zeroknowledge.Overhead {
+		return &model.ValidationError{Violations: []model.Violation{{
+			Rule:    opaqueRule,
+			Message: fmt.Sprintf("client-encrypted body is %v bytes long, ciphertext is at least %v", len(body), zeroknowledge.Overhead),
+		}}}
+	}
+	return nil
+}
+
+// inspectable возвращает тело секрета для проверки политикой содержимого. Зашифрованное клиентом тело
+// не проверяется, правилам достаются только метаданные, тип содержимого, имя файла и создатель,
+// а от самого тела - только его размер, проверенный Limits и checkOpaque
+func inspectable(body []byte, opaque bool) []byte {
+	if opaque {
+		return nil
+	}
+	return body
+}
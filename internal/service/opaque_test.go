@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/zeroknowledge"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSecretService_Opaque(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	validators, err := ParseContentPolicy("private_key=reject,client=reject", "^curl/")
+	if err != nil {
+		t.Fatalf("error parsing policy: %s", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ss := SecretService{
+		Tracer:     provider.Tracer("unit_test_service4opaque"),
+		Repo:       repo,
+		Validators: validators,
+	}
+	// сервер не может знать, что внутри шифротекста, поэтому тело, похожее на ключ, тоже должно сохраниться
+	body := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("golang")})) + " golang"
+
+	_, err = ss.Create(ctx, model.SecretParams{
+		Body:   []byte(body),
+		Meta:   map[string]string{"User-Agent": "curl/8.0"},
+		Owner:  "alice",
+		Opaque: true,
+	})
+	assert.True(t, errors.Is(err, model.ErrPolicyViolation), "rules on metadata are skipped: %v", err)
+
+	secret, err := ss.Create(ctx, model.SecretParams{
+		Body:   []byte(body),
+		Owner:  "alice",
+		TTL:    time.Minute,
+		Opaque: true,
+	})
+	if err != nil {
+		t.Fatalf("error creating opaque secret: %s", err)
+	}
+	assert.True(t, secret.Opaque, "secret is not marked opaque")
+	assert.Equal(t, opaqueContentType, secret.ContentType, "content type is detected from ciphertext")
+	_, found := secret.Meta["programming"]
+	assert.False(t, found, "opaque secret is enriched")
+
+	_, err = ss.Update(ctx, secret.ID, UpdateParams{
+		Body:     []byte(body + " again"),
+		Identity: model.Identity{Subject: "alice"},
+	})
+	if err != nil {
+		t.Fatalf("error updating opaque secret: %s", err)
+	}
+	found1, err := ss.FindByID(ctx, secret.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Fatalf("error reading opaque secret: %s", err)
+	}
+	assert.True(t, found1.Opaque, "opaque mark is lost")
+	assert.Equal(t, body+" again", string(found1.Body))
+	assert.Equal(t, opaqueContentType, found1.ContentType)
+
+	spans := recorder.Ended()
+	assert.NotEmpty(t, spans, "no spans are recorded")
+	for i := range spans {
+		for _, attr := range spans[i].Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "golang", "body is leaked into span %s", spans[i].Name())
+		}
+	}
+}
+
+func TestSecretService_OpaquePolicy(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service4opaque"),
+		Repo:   repo,
+		Limits: Limits{MaxBodyBytes: 64},
+	}
+	sealed, _, err := zeroknowledge.Encrypt([]byte("top secret"))
+	if err != nil {
+		t.Fatalf("error encrypting: %s", err)
+	}
+	var validationError *model.ValidationError
+
+	// тело, которое не может быть шифротекстом, не выдаётся за зашифрованное клиентом
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("password"), Owner: "alice", Opaque: true})
+	if assert.True(t, errors.As(err, &validationError), "short opaque body is accepted: %v", err) {
+		assert.Equal(t, opaqueRule, validationError.Violations[0].Rule)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: make([]byte, 65), Owner: "alice", Opaque: true})
+	assert.True(t, errors.Is(err, model.ErrTooLarge), "size of opaque body is not checked: %v", err)
+
+	secret, err := ss.Create(ctx, model.SecretParams{Body: sealed, Owner: "alice", Opaque: true})
+	if err != nil {
+		t.Fatalf("error creating opaque secret: %s", err)
+	}
+	_, err = ss.Update(ctx, secret.ID, UpdateParams{
+		Body:     []byte("password"),
+		Identity: model.Identity{Subject: "alice"},
+	})
+	assert.True(t, errors.As(err, &validationError), "short opaque body is accepted on update: %v", err)
+
+	// оператор может запретить секреты, зашифрованные клиентом
+	ss.RefuseOpaque = true
+	_, err = ss.Create(ctx, model.SecretParams{Body: sealed, Owner: "alice", Opaque: true})
+	if assert.True(t, errors.As(err, &validationError), "opaque secret is accepted: %v", err) {
+		assert.Equal(t, opaqueRule, validationError.Violations[0].Rule)
+	}
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("password"), Owner: "alice"})
+	assert.NoError(t, err, "plain secret is refused")
+}
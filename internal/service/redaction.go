@@ -0,0 +1,132 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RedactionAction задаёт, в каком виде значение поля метаданных попадает в трассировку и журналы
+type RedactionAction string
+
+const (
+	// RedactAllow - записывать значение как есть
+	RedactAllow RedactionAction = "allow"
+	// RedactHash - записывать отпечаток значения, по которому можно сопоставить одинаковые значения, не зная их
+	RedactHash RedactionAction = "hash"
+	// RedactMask - записывать вместо значения RedactedValue
+	RedactMask RedactionAction = "mask"
+)
+
+// RedactedValue записывается вместо скрытых значений
+const RedactedValue = "***"
+
+// redactAny задаёт в описании политики действие для полей, которые не перечислены явно
+const redactAny = "*"
+
+// DefaultRedactionPolicy задаёт политику скрытия, которая используется, если своя политика не задана.
+// Записываются только поля, которые заполняет сам сервис, остальные скрываются
+const DefaultRedactionPolicy = "User-Agent=allow,Subject=allow,Policy-Flags=allow,*=mask"
+
+// Redaction - политика скрытия данных секретов в трассировке и журналах. Тело секрета не записывается никогда,
+// а поля метаданных записываются как есть, отпечатком или скрываются, в зависимости от названия поля.
+// Методы можно вызывать и у nil, тогда действует DefaultRedactionPolicy
+type Redaction struct {
+	// actions - действия для полей метаданных, названия полей приведены к нижнему регистру
+	actions map[string]RedactionAction
+	// fallback - действие для полей, которые не перечислены в actions
+	fallback RedactionAction
+}
+
+// ParseRedaction собирает политику скрытия из описания вида "User-Agent=allow,Subject=hash,*=mask".
+// Названия полей не зависят от регистра, * задаёт действие для остальных полей, по умолчанию - mask.
+// Пустое описание означает DefaultRedactionPolicy
+func ParseRedaction(spec string) (*Redaction, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultRedactionPolicy
+	}
+	ret := Redaction{actions: make(map[string]RedactionAction, 0), fallback: RedactMask}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, action, found := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("malformed redaction rule %q, should be field=action", item)
+		}
+		redactionAction := RedactionAction(strings.TrimSpace(action))
+		if redactionAction != RedactAllow && redactionAction != RedactHash && redactionAction != RedactMask {
+			return nil, fmt.Errorf("unknown action %q for field %s, should be %s, %s or %s",
+				action, key, RedactAllow, RedactHash, RedactMask)
+		}
+		if key == redactAny {
+			ret.fallback = redactionAction
+			continue
+		}
+		ret.actions[strings.ToLower(key)] = redactionAction
+	}
+	return &ret, nil
+}
+
+// defaultRedaction применяет DefaultRedactionPolicy
+var defaultRedaction = func() *Redaction {
+	r, err := ParseRedaction(DefaultRedactionPolicy)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}()
+
+// policy возвращает саму политику или политику по умолчанию, если она не задана
+func (r *Redaction) policy() *Redaction {
+	if r == nil {
+		return defaultRedaction
+	}
+	return r
+}
+
+// Action возвращает действие для поля метаданных key
+func (r *Redaction) Action(key string) RedactionAction {
+	p := r.policy()
+	action, found := p.actions[strings.ToLower(key)]
+	if !found {
+		return p.fallback
+	}
+	return action
+}
+
+// Value возвращает значение value поля метаданных key в том виде, в котором его можно записать
+func (r *Redaction) Value(key, value string) string {
+	switch r.Action(key) {
+	case RedactAllow:
+		return value
+	case RedactHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	default:
+		return RedactedValue
+	}
+}
+
+// Meta возвращает копию метаданных, в которой значения полей приведены к виду, в котором их можно записать
+func (r *Redaction) Meta(meta map[string]string) map[string]string {
+	ret := make(map[string]string, len(meta))
+	for k := range meta {
+		ret[k] = r.Value(k, meta[k])
+	}
+	return ret
+}
+
+// MetaAttributes возвращает атрибуты span с полями метаданных, приведёнными к виду, в котором их можно записать
+func (r *Redaction) MetaAttributes(meta map[string]string) []attribute.KeyValue {
+	ret := make([]attribute.KeyValue, 0, len(meta))
+	for k := range meta {
+		ret = append(ret, attribute.String("meta_"+k, r.Value(k, meta[k])))
+	}
+	return ret
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestParseRedaction(t *testing.T) {
+	redaction, err := ParseRedaction("User-Agent=allow, subject=hash,*=allow")
+	if err != nil {
+		t.Fatalf("error parsing redaction policy: %s", err)
+	}
+	assert.Equal(t, "curl/8.0", redaction.Value("user-agent", "curl/8.0"))
+	assert.Equal(t, "anything", redaction.Value("Comment", "anything"), "fallback action is ignored")
+	hashed := redaction.Value("Subject", "alice")
+	assert.True(t, strings.HasPrefix(hashed, "sha256:"), "value is not hashed: %s", hashed)
+	assert.NotContains(t, hashed, "alice")
+	assert.Equal(t, hashed, redaction.Value("Subject", "alice"), "hash is not stable")
+	assert.NotEqual(t, hashed, redaction.Value("Subject", "bob"))
+
+	var nilRedaction *Redaction
+	assert.Equal(t, RedactedValue, nilRedaction.Value("password", "hunter2"), "default policy shows unknown fields")
+	assert.Equal(t, "curl/8.0", nilRedaction.Value("User-Agent", "curl/8.0"))
+	empty, err := ParseRedaction("")
+	if err != nil {
+		t.Fatalf("error parsing empty redaction policy: %s", err)
+	}
+	assert.Equal(t, RedactedValue, empty.Value("password", "hunter2"), "empty policy shows unknown fields")
+	onlyAllowed, err := ParseRedaction("User-Agent=allow")
+	if err != nil {
+		t.Fatalf("error parsing redaction policy: %s", err)
+	}
+	assert.Equal(t, RedactedValue, onlyAllowed.Value("password", "hunter2"), "fields are shown by default")
+
+	for _, spec := range []string{"User-Agent", "=allow", "User-Agent=show"} {
+		_, err = ParseRedaction(spec)
+		assert.Error(t, err, "malformed policy %q is accepted", spec)
+	}
+}
+
+func TestSecretService_Redaction(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	redaction, err := ParseRedaction("User-Agent=allow,Subject=hash")
+	if err != nil {
+		t.Fatalf("error parsing redaction policy: %s", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ss := SecretService{
+		Tracer:    provider.Tracer("unit_test_service4redaction"),
+		Repo:      repo,
+		Redaction: redaction,
+	}
+	secret, err := ss.Create(ctx, model.SecretParams{
+		Body: []byte("correct horse battery staple"),
+		Meta: map[string]string{
+			"User-Agent": "purser-grpc-cli",
+			"Subject":    "alice",
+			"Database":   "postgres://root:hunter2@db",
+		},
+		Owner: "alice",
+		TTL:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, secret.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+
+	spans := recorder.Ended()
+	attributes := make(map[string]attribute.Value, 0)
+	for i := range spans {
+		for _, attr := range spans[i].Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "horse battery", "body is leaked into span %s", spans[i].Name())
+			assert.NotContains(t, attr.Value.Emit(), "hunter2", "masked meta is leaked into span %s", spans[i].Name())
+			attributes[string(attr.Key)] = attr.Value
+		}
+	}
+	assert.Equal(t, "purser-grpc-cli", attributes["meta_User-Agent"].AsString(), "allowed meta is not recorded")
+	assert.Equal(t, redaction.Value("Subject", "alice"), attributes["meta_Subject"].AsString(), "meta is not hashed")
+	assert.Equal(t, RedactedValue, attributes["meta_Database"].AsString(), "meta is not masked")
+	assert.Equal(t, int64(len("correct horse battery staple")), attributes["body_size"].AsInt64())
+}
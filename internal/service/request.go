@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDKey задаёт ключ метаданных, в котором у присланного по запросу секрета хранится идентификатор запроса
+const RequestIDKey = "Request-ID"
+
+// errRequestsDisabled возвращается, если репозиторий запросов секретов не задан
+var errRequestsDisabled = errors.New("secret requests are not supported")
+
+// traceRequestError отмечает ошибку работы с запросом секрета в span, отличая ожидаемые ошибки от неожиданных
+func traceRequestError(span trace.Span, err error) {
+	if errors.Is(err, model.ErrRequestNotFound) || errors.Is(err, model.ErrRequestFulfilled) ||
+		errors.Is(err, model.ErrForbidden) || errors.Is(err, model.ErrTooLarge) {
+		span.AddEvent("Secret request is rejected: " + err.Error())
+		return
+	}
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+}
+
+// CreateRequest создаёт запрос секрета, срок действия которого укладывается в ограничения TTL.
+// Если описание превышает ограничения Limits на значения метаданных, возвращается model.ErrTooLarge.
+// У созданного запроса заполнен model.SecretRequest.Token - токен одноразовой ссылки для FulfilRequest,
+// который больше нигде не узнать
+func (ss *SecretService) CreateRequest(ctx context.Context, params model.SecretRequestParams) (model.SecretRequest, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.CreateRequest")
+	defer span.End()
+	if ss.Requests == nil {
+		return model.SecretRequest{}, errRequestsDisabled
+	}
+	span.SetAttributes(attribute.String("requester", params.Requester))
+	err := ss.Limits.Check(nil, map[string]string{"description": params.Description})
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	now := time.Now()
+	ttl := ss.TTL.Clamp(now, params.TTL, params.ExpireAt)
+	span.SetAttributes(attribute.String("ttl", ttl.String()))
+	token, err := newShareToken()
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	request, err := ss.Requests.CreateRequest(ctxWithTracing, model.SecretRequest{
+		Requester:   params.Requester,
+		Description: params.Description,
+		CreatedAt:   now,
+		ExpireAt:    now.Add(ttl),
+		TokenHash:   hashShareToken(token),
+	})
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	request.Token = token
+	span.SetAttributes(attribute.String("request_id", request.ID))
+	span.AddEvent("Secret request is created")
+	return request, nil
+}
+
+// GetRequest возвращает запрос секрета его запрашивающему, остальным - model.ErrForbidden.
+// Если запроса нет или он устарел, возвращается model.ErrRequestNotFound
+func (ss *SecretService) GetRequest(ctx context.Context, id string, identity model.Identity) (model.SecretRequest, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.GetRequest")
+	defer span.End()
+	if ss.Requests == nil {
+		return model.SecretRequest{}, errRequestsDisabled
+	}
+	span.SetAttributes(attribute.String("request_id", id))
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	request, err := ss.Requests.FindRequest(ctxWithTracing, id)
+	if err == nil && request.Requester != identity.Subject {
+		err = model.ErrForbidden
+	}
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	return request, nil
+}
+
+// DeleteRequest удаляет запрос секрета по просьбе запрашивающего, так что ссылка на него перестаёт действовать.
+// Присланный по запросу секрет не удаляется
+func (ss *SecretService) DeleteRequest(ctx context.Context, id string, identity model.Identity) error {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.DeleteRequest")
+	defer span.End()
+	_, err := ss.GetRequest(ctxWithTracing, id, identity)
+	if err == nil {
+		err = ss.Requests.DeleteRequest(ctxWithTracing, id)
+	}
+	if err != nil {
+		traceRequestError(span, err)
+		return err
+	}
+	span.AddEvent("Secret request is deleted")
+	return nil
+}
+
+// DescribeRequest ищет запрос секрета по токену одноразовой ссылки, чтобы показать описание тому, кто пришлёт секрет.
+// Токен сам даёт право на это, поэтому JWT токен не нужен. Если токен неизвестен или запрос устарел,
+// возвращается model.ErrRequestNotFound, а если секрет по нему уже прислали - model.ErrRequestFulfilled
+func (ss *SecretService) DescribeRequest(ctx context.Context, token string) (model.SecretRequest, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.DescribeRequest")
+	defer span.End()
+	if ss.Requests == nil {
+		return model.SecretRequest{}, errRequestsDisabled
+	}
+	if token == "" {
+		return model.SecretRequest{}, model.ErrRequestNotFound
+	}
+	request, err := ss.Requests.FindRequestByToken(ctxWithTracing, hashShareToken(token))
+	if err == nil && request.Fulfilled() {
+		err = model.ErrRequestFulfilled
+	}
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	span.SetAttributes(attribute.String("request_id", request.ID))
+	return request, nil
+}
+
+// FulfilRequest создаёт секрет по токену одноразовой ссылки на запрос. Создателем секрета становится запрашивающий,
+// так что прочитать секрет может только он, а адресаты, группы и адрес для уведомлений из params не учитываются.
+// Из нескольких одновременно присланных секретов запрос принимает только один, остальные удаляются
+// и получают model.ErrRequestFulfilled. Ошибки создания секрета те же, что у Create
+func (ss *SecretService) FulfilRequest(ctx context.Context, token string, params model.SecretParams) (model.SecretRequest, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.FulfilRequest")
+	defer span.End()
+	request, err := ss.DescribeRequest(ctxWithTracing, token)
+	if err != nil {
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	span.SetAttributes(attribute.String("request_id", request.ID))
+	params.Owner = request.Requester
+	params.Recipients = nil
+	params.Groups = nil
+	params.CallbackURL = ""
+	if params.Meta == nil {
+		params.Meta = make(map[string]string, 0)
+	}
+	params.Meta["Subject"] = request.Requester
+	params.Meta[RequestIDKey] = request.ID
+	secret, err := ss.Create(ctxWithTracing, params)
+	if err != nil {
+		span.AddEvent("Secret is not created: " + err.Error())
+		return model.SecretRequest{}, err
+	}
+	span.SetAttributes(attribute.String("secret_id", secret.ID))
+	now := time.Now()
+	err = ss.Requests.FulfilRequest(ctxWithTracing, request.ID, secret.ID, now)
+	if err != nil {
+		// секрет, который запрос не принял, никто не прочитает, поэтому он удаляется
+		ss.discard(ctxWithTracing, secret.ID, request.Requester, "request "+request.ID+" is not fulfilled")
+		traceRequestError(span, err)
+		return model.SecretRequest{}, err
+	}
+	request.SecretID = secret.ID
+	request.FulfilledAt = now
+	span.AddEvent("Secret request is fulfilled")
+	return request, nil
+}
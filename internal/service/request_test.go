@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSecretService_FulfilRequest(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer:   otel.Tracer("unit_test_service4request"),
+		Repo:     &repo,
+		Requests: &repo,
+	}
+	request, err := ss.CreateRequest(ctx, model.SecretRequestParams{
+		Requester:   "alice",
+		Description: "staging database password",
+		TTL:         10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	if !assert.NotEmpty(t, request.Token, "fulfilment token is not issued") {
+		return
+	}
+
+	alice := model.Identity{Subject: "alice"}
+	mallory := model.Identity{Subject: "mallory"}
+	_, err = ss.GetRequest(ctx, request.ID, mallory)
+	assert.True(t, errors.Is(err, model.ErrForbidden), "wrong error %v", err)
+	err = ss.DeleteRequest(ctx, request.ID, mallory)
+	assert.True(t, errors.Is(err, model.ErrForbidden), "wrong error %v", err)
+
+	described, err := ss.DescribeRequest(ctx, request.Token)
+	if err != nil {
+		t.Fatalf("error describing request: %s", err)
+	}
+	assert.Equal(t, "staging database password", described.Description)
+
+	fulfilled, err := ss.FulfilRequest(ctx, request.Token, model.SecretParams{
+		Body:       []byte("hunter2"),
+		Recipients: []string{"mallory"},
+		Groups:     []string{"everyone"},
+	})
+	if err != nil {
+		t.Fatalf("error fulfilling request: %s", err)
+	}
+	_, err = ss.FulfilRequest(ctx, request.Token, model.SecretParams{Body: []byte("second")})
+	assert.True(t, errors.Is(err, model.ErrRequestFulfilled), "request is fulfilled twice %v", err)
+	_, err = ss.DescribeRequest(ctx, request.Token)
+	assert.True(t, errors.Is(err, model.ErrRequestFulfilled), "wrong error %v", err)
+
+	found, err := ss.GetRequest(ctx, request.ID, alice)
+	if err != nil {
+		t.Fatalf("error getting request: %s", err)
+	}
+	assert.Equal(t, fulfilled.SecretID, found.SecretID)
+	_, err = ss.FindByID(ctx, found.SecretID, ReadOptions{Identity: mallory})
+	assert.True(t, errors.Is(err, model.ErrForbidden), "secret is readable by stranger %v", err)
+	secret, err := ss.FindByID(ctx, found.SecretID, ReadOptions{Identity: alice})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+	assert.Equal(t, "hunter2", string(secret.Body))
+	assert.Equal(t, "alice", secret.Owner)
+	assert.Equal(t, request.ID, secret.Meta[RequestIDKey])
+
+	for _, token := range []string{"", "bogus"} {
+		_, err = ss.DescribeRequest(ctx, token)
+		assert.True(t, errors.Is(err, model.ErrRequestNotFound), "wrong error %v for token %q", err, token)
+	}
+	err = ss.DeleteRequest(ctx, request.ID, alice)
+	if err != nil {
+		t.Fatalf("error deleting request: %s", err)
+	}
+	_, err = ss.GetRequest(ctx, request.ID, alice)
+	assert.True(t, errors.Is(err, model.ErrRequestNotFound), "wrong error %v", err)
+}
+
+// racingRequestRepo делает вид, что каждый запрос уже принял секрет, присланный одновременно с проверяемым
+type racingRequestRepo struct {
+	repository.RequestRepo
+}
+
+func (r racingRequestRepo) FulfilRequest(context.Context, string, string, time.Time) error {
+	return model.ErrRequestFulfilled
+}
+
+func TestSecretService_FulfilRequestRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer:     otel.Tracer("unit_test_service4request"),
+		Repo:       &repo,
+		Requests:   racingRequestRepo{&repo},
+		Audit:      &AuditService{Tracer: otel.Tracer("unit_test_service4request"), Repo: &repo},
+		Tombstones: &TombstoneService{Repo: &repo, Retention: time.Hour},
+		Webhooks: &WebhookNotifier{
+			Repo:            &repo,
+			Secret:          []byte("webhook secret"),
+			Subjects:        map[string]string{"alice": "http://127.0.0.1/hook"},
+			AllowedNetworks: mustParseNetworks("127.0.0.0/8"),
+		},
+	}
+	request, err := ss.CreateRequest(ctx, model.SecretRequestParams{Requester: "alice", TTL: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	_, err = ss.FulfilRequest(ctx, request.Token, model.SecretParams{Body: []byte("hunter2")})
+	assert.True(t, errors.Is(err, model.ErrRequestFulfilled), "wrong error %v", err)
+	assertDiscardedAll(t, &ss, &repo, 1, "alice")
+}
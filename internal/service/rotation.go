@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/envelope"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultRewrapBatchSize задаёт, сколько секретов перешифровывается за один вызов Rewrap по умолчанию
+const DefaultRewrapBatchSize = 100
+
+// versionsCursorPrefix отмечает курсор, с которого перешифровываются уже не секреты, а их прежние версии
+const versionsCursorPrefix = "versions:"
+
+// KeyRotationService перешифровывает ключи данных секретов и их прежних версий текущим мастер-ключом после ротации.
+// Тела секретов при этом не перешифровываются, так что работа идёт быстро и не мешает чтению секретов.
+// Когда перешифровка закончена, старый мастер-ключ можно убрать из связки
+type KeyRotationService struct {
+	Tracer trace.Tracer
+	Repo   repository.SecretRepo
+	KMS    envelope.KMS
+	// BatchSize задаёт, сколько секретов перешифровывается за один вызов Rewrap
+	BatchSize int64
+}
+
+// RewrapProgress описывает, сколько секретов перешифровано
+type RewrapProgress struct {
+	// Cursor задаёт, с какого места продолжать перешифровку, пустой курсор - с начала
+	Cursor string
+	// Scanned - сколько секретов и прежних версий с устаревшим мастер-ключом найдено
+	Scanned int64
+	// Rewrapped - сколько секретов и прежних версий перешифровано
+	Rewrapped int64
+	// Skipped - сколько секретов и прежних версий было удалено или перешифровано кем-то ещё в процессе
+	Skipped int64
+	// Failed - сколько секретов и прежних версий не удалось перешифровать, например, потому что их мастер-ключа нет в связке
+	Failed int64
+	// Done - истина, если все секреты и их прежние версии просмотрены
+	Done bool
+}
+
+// Rewrap перешифровывает очередную пачку секретов, начиная с progress.Cursor, и возвращает обновлённый прогресс.
+// Когда секреты кончаются, так же перешифровываются их прежние версии. Так как выбираются только секреты и версии
+// с устаревшим мастер-ключом, перешифровку можно прервать в любой момент и продолжить с сохранённого курсора
+// или вообще с начала
+func (krs *KeyRotationService) Rewrap(ctx context.Context, progress RewrapProgress) (RewrapProgress, error) {
+	ctxWithTracing, span := krs.Tracer.Start(ctx, "service.Rewrap")
+	defer span.End()
+	batchSize := krs.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultRewrapBatchSize
+	}
+	currentKeyID := krs.KMS.KeyID()
+	span.SetAttributes(attribute.String("key_id", currentKeyID))
+	span.SetAttributes(attribute.String("cursor", progress.Cursor))
+	var err error
+	if !strings.HasPrefix(progress.Cursor, versionsCursorPrefix) {
+		progress, err = krs.rewrapSecrets(ctxWithTracing, currentKeyID, batchSize, progress)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return progress, err
+		}
+		if progress.Cursor != "" {
+			span.SetAttributes(attribute.Int64("rewrapped", progress.Rewrapped))
+			return progress, nil
+		}
+		// секреты кончились, дальше перешифровываются прежние версии
+		progress.Cursor = versionsCursorPrefix
+	}
+	progress, err = krs.rewrapVersions(ctxWithTracing, currentKeyID, batchSize, progress)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return progress, err
+	}
+	progress.Done = progress.Cursor == ""
+	span.SetAttributes(attribute.Int64("rewrapped", progress.Rewrapped))
+	span.SetAttributes(attribute.Bool("done", progress.Done))
+	return progress, nil
+}
+
+// count учитывает в прогрессе результат перешифровки одного секрета или версии
+func (progress *RewrapProgress) count(ctx context.Context, what string, err error) error {
+	progress.Scanned++
+	switch {
+	case err == nil:
+		progress.Rewrapped++
+	case errors.Is(err, model.ErrSecretNotFound):
+		progress.Skipped++
+	case errors.Is(err, envelope.ErrKeyNotFound):
+		progress.Failed++
+		trace.SpanFromContext(ctx).AddEvent("Master key not found for " + what)
+	default:
+		return err
+	}
+	return nil
+}
+
+// rewrapSecrets перешифровывает очередную пачку секретов
+func (krs *KeyRotationService) rewrapSecrets(ctx context.Context, currentKeyID string, batchSize int64, progress RewrapProgress) (RewrapProgress, error) {
+	secrets, next, err := krs.Repo.ListWrappedKeys(ctx, currentKeyID, progress.Cursor, batchSize)
+	if err != nil {
+		return progress, err
+	}
+	for i := range secrets {
+		err = progress.count(ctx, "secret "+secrets[i].ID, krs.rewrap(ctx, secrets[i]))
+		if err != nil {
+			return progress, err
+		}
+	}
+	progress.Cursor = next
+	return progress, nil
+}
+
+// rewrapVersions перешифровывает очередную пачку прежних версий секретов
+func (krs *KeyRotationService) rewrapVersions(ctx context.Context, currentKeyID string, batchSize int64, progress RewrapProgress) (RewrapProgress, error) {
+	cursor := strings.TrimPrefix(progress.Cursor, versionsCursorPrefix)
+	versions, next, err := krs.Repo.ListVersionWrappedKeys(ctx, currentKeyID, cursor, batchSize)
+	if err != nil {
+		return progress, err
+	}
+	for i := range versions {
+		what := fmt.Sprintf("version %v of secret %s", versions[i].Version, versions[i].SecretID)
+		err = progress.count(ctx, what, krs.rewrapVersion(ctx, versions[i]))
+		if err != nil {
+			return progress, err
+		}
+	}
+	progress.Cursor = ""
+	if next != "" {
+		progress.Cursor = versionsCursorPrefix + next
+	}
+	return progress, nil
+}
+
+// rewrap перешифровывает ключ данных одного секрета текущим мастер-ключом
+func (krs *KeyRotationService) rewrap(ctx context.Context, secret model.Secret) error {
+	dataKey, err := krs.KMS.Unwrap(ctx, secret.KeyID, secret.WrappedKey)
+	if err != nil {
+		return err
+	}
+	keyID, wrapped, err := krs.KMS.Wrap(ctx, dataKey)
+	if err != nil {
+		return err
+	}
+	return krs.Repo.UpdateWrappedKey(ctx, secret.ID, secret.KeyID, keyID, wrapped)
+}
+
+// rewrapVersion перешифровывает ключ данных одной прежней версии секрета текущим мастер-ключом
+func (krs *KeyRotationService) rewrapVersion(ctx context.Context, version model.SecretVersion) error {
+	dataKey, err := krs.KMS.Unwrap(ctx, version.KeyID, version.WrappedKey)
+	if err != nil {
+		return err
+	}
+	keyID, wrapped, err := krs.KMS.Wrap(ctx, dataKey)
+	if err != nil {
+		return err
+	}
+	return krs.Repo.UpdateVersionWrappedKey(ctx, version.SecretID, version.Version, version.KeyID, keyID, wrapped)
+}
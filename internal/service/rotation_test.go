@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository/encrypted"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/envelope"
+)
+
+func TestKeyRotationService_Rewrap(t *testing.T) {
+	ctx := context.TODO()
+	oldKey, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	newKey, err := envelope.NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	oldKMS, err := envelope.NewLocalKMS(oldKey)
+	if err != nil {
+		t.Fatalf("error creating kms: %s", err)
+	}
+	keyring, err := envelope.NewLocalKMS(oldKey, newKey)
+	if err != nil {
+		t.Fatalf("error creating keyring: %s", err)
+	}
+
+	storage := &memory.Repository{}
+	err = storage.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service"),
+		Repo:   &encrypted.Repository{Repo: storage, KMS: oldKMS},
+	}
+	ids := make([]string, 0)
+	for i := 0; i < 5; i++ {
+		secret, cErr := ss.Create(ctx, model.SecretParams{Body: []byte("rotated secret"), Owner: "alice"})
+		if cErr != nil {
+			t.Fatalf("error creating secret: %s", cErr)
+		}
+		ids = append(ids, secret.ID)
+	}
+	// у части секретов есть прежние версии, зашифрованные старым ключом
+	updated := ids[:2]
+	for i := range updated {
+		_, uErr := ss.Update(ctx, updated[i], UpdateParams{Body: []byte("updated secret"), Identity: model.Identity{Subject: "alice"}})
+		if uErr != nil {
+			t.Fatalf("error updating secret: %s", uErr)
+		}
+	}
+
+	// после ротации секреты читаются старым ключом связки, пока идёт перешифровка
+	ss.Repo = &encrypted.Repository{Repo: storage, KMS: keyring}
+	krs := KeyRotationService{
+		Tracer:    otel.Tracer("unit_test_service"),
+		Repo:      ss.Repo,
+		KMS:       keyring,
+		BatchSize: 2,
+	}
+	progress := RewrapProgress{}
+	for batches := 0; !progress.Done; batches++ {
+		if batches > len(ids) {
+			t.Fatalf("rewrap is not finished after %v batches", batches)
+		}
+		progress, err = krs.Rewrap(ctx, progress)
+		if err != nil {
+			t.Fatalf("error rewrapping: %s", err)
+		}
+	}
+	assert.Equal(t, int64(len(ids)+len(updated)), progress.Rewrapped, "wrong number of secrets and versions rewrapped")
+	assert.Equal(t, int64(0), progress.Failed, "some secrets failed")
+
+	for i := range ids {
+		stored, pErr := storage.Peek(ctx, ids[i])
+		if pErr != nil {
+			t.Fatalf("error peeking secret: %s", pErr)
+		}
+		assert.Equal(t, keyring.KeyID(), stored.KeyID, "secret is not rewrapped with new key")
+		found, fErr := ss.FindByID(ctx, ids[i], ReadOptions{Identity: model.Identity{Subject: "alice"}})
+		if fErr != nil {
+			t.Fatalf("error finding rewrapped secret: %s", fErr)
+		}
+		if i < len(updated) {
+			assert.Equal(t, "updated secret", string(found.Body))
+		} else {
+			assert.Equal(t, "rotated secret", string(found.Body))
+		}
+	}
+	for i := range updated {
+		stored, gErr := storage.GetVersion(ctx, updated[i], 1)
+		if gErr != nil {
+			t.Fatalf("error getting version: %s", gErr)
+		}
+		assert.Equal(t, keyring.KeyID(), stored.KeyID, "version is not rewrapped with new key")
+	}
+
+	// после перешифровки старый ключ больше не нужен ни секретам, ни их прежним версиям
+	newKMS, err := envelope.NewLocalKMS(newKey)
+	if err != nil {
+		t.Fatalf("error creating kms: %s", err)
+	}
+	ss.Repo = &encrypted.Repository{Repo: storage, KMS: newKMS}
+	for i := range updated {
+		version, gErr := ss.GetVersion(ctx, updated[i], 1, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+		if gErr != nil {
+			t.Fatalf("error reading rewrapped version without old key: %s", gErr)
+		}
+		assert.Equal(t, "rotated secret", string(version.Body))
+	}
+
+	// повторный запуск ничего не делает
+	progress, err = krs.Rewrap(ctx, RewrapProgress{})
+	if err != nil {
+		t.Fatalf("error rewrapping: %s", err)
+	}
+	assert.True(t, progress.Done)
+	assert.Equal(t, int64(0), progress.Scanned, "rewrapped secrets are scanned again")
+}
@@ -3,13 +3,18 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/model"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // SecretService реализует всю бизнес логику работы с сущностью model.Secret
@@ -18,6 +23,63 @@ type SecretService struct {
 	// Repo - интерфейс, под который должен подходить репозиторий, чтобы его можно было использовать в сервисе.
 	// На данный момент в программе реализованы репозитории для memory, redis, mysql и postgresql.
 	Repo repository.SecretRepo
+	// TTL задаёт ограничения на срок жизни создаваемых секретов
+	TTL TTLPolicy
+	// MaxPassphraseAttempts задаёт, после скольких неверных попыток ввода кодовой фразы секрет уничтожается.
+	// Если не задано, используется model.MaxPassphraseAttempts
+	MaxPassphraseAttempts int64
+	// KeepVersions задаёт, сколько прежних версий секрета хранится при изменении.
+	// Если не задано, используется model.KeepVersions
+	KeepVersions int64
+	// KeepAccesses задаёт, сколько последних прочтений секрета хранится в его журнале прочтений.
+	// Если не задано, используется model.KeepAccesses
+	KeepAccesses int64
+	// Limits задаёт ограничения на размер секретов и их число у одного создателя
+	Limits Limits
+	// Validators задаёт правила политики содержимого, которыми проверяется секрет перед сохранением
+	Validators []Validator
+	// RefuseOpaque запрещает секреты, зашифрованные клиентом, тела которых Validators проверить не могут
+	RefuseOpaque bool
+	// Enricher задаёт правила обогащения метаданных создаваемых секретов.
+	// Если не задан, используются DefaultEnrichmentRules
+	Enricher *Enricher
+	// Redaction задаёт, в каком виде метаданные секретов попадают в трассировку и журналы.
+	// Если не задана, используется DefaultRedactionPolicy
+	Redaction *Redaction
+	// Audit - журнал аудита, в который записываются события в жизни секретов. Если не задан, события не записываются
+	Audit *AuditService
+	// Webhooks сообщает создателям о событиях их секретов. Если не задан, адрес для уведомлений указать нельзя
+	Webhooks *WebhookNotifier
+	// Tombstones хранит записи об исчезнувших секретах. Если не задан, исчезнувший секрет не отличается от несуществующего
+	Tombstones *TombstoneService
+	// Requests хранит запросы секретов. Если не задан, запросить секрет нельзя
+	Requests repository.RequestRepo
+}
+
+// audit записывает в журнал аудита событие с секретом
+func (ss *SecretService) audit(ctx context.Context, id string, action model.AuditAction, subject, detail string) {
+	ss.Audit.Record(ctx, model.AuditEvent{
+		SecretID: id,
+		Action:   action,
+		Subject:  subject,
+		Detail:   detail,
+	})
+}
+
+// auditDenied записывает в журнал аудита отказ в доступе к секрету, если ошибка - это отказ
+func (ss *SecretService) auditDenied(ctx context.Context, id, subject string, err error) {
+	if errors.Is(err, model.ErrForbidden) ||
+		errors.Is(err, model.ErrPassphraseRequired) || errors.Is(err, model.ErrWrongPassphrase) {
+		ss.audit(ctx, id, model.AuditDenied, subject, err.Error())
+	}
+}
+
+// enricher возвращает правила обогащения метаданных
+func (ss *SecretService) enricher() *Enricher {
+	if ss.Enricher == nil {
+		return defaultEnricher
+	}
+	return ss.Enricher
 }
 
 // Ping проверяет, что репозиторий, а также все другие ресурсы\системы, от которых зависит сервис, работоспособны
@@ -34,45 +96,286 @@ func (ss *SecretService) Ping(ctx context.Context) error {
 	return nil
 }
 
-// Create создаёт новый секрет
-func (ss *SecretService) Create(ctx context.Context, body string, meta map[string]string) (model.Secret, error) {
+// Create создаёт новый секрет, срок жизни которого укладывается в ограничения TTL.
+// Если секрет превышает ограничения Limits, возвращается model.ErrTooLarge,
+// а если у создателя уже слишком много действующих секретов - model.ErrQuotaExceeded.
+// Если секрет нарушает политику содержимого Validators, возвращается *model.ValidationError,
+// а если адрес для уведомлений не годится или указывает не на публичный адрес - model.ErrInvalidCallbackURL. Если адрес годится, но подписку
+// на события сохранить не удалось, секрет всё равно считается созданным.
+// Тело секрета, зашифрованного клиентом (model.SecretParams.Opaque), не обогащается и не проверяется политикой
+// содержимого, кроме размера. Такой секрет отклоняется с *model.ValidationError, если они запрещены RefuseOpaque
+// или тело короче шифротекста.
+// У созданного секрета заполнен model.Secret.ShareToken - токен ссылки для Redeem, который больше нигде не узнать
+func (ss *SecretService) Create(ctx context.Context, params model.SecretParams) (model.Secret, error) {
 	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Create")
 	defer span.End()
-	span.SetAttributes(attribute.String("body", body))
-	for k := range meta {
-		span.SetAttributes(attribute.String("meta_"+k, meta[k]))
+	body := params.Body
+	meta := params.Meta
+	if meta == nil {
+		meta = make(map[string]string, 0)
+	}
+	// тело секрета в трассировку не попадает, только его размер
+	span.SetAttributes(attribute.Int("body_size", len(body)))
+	if params.Opaque {
+		span.SetAttributes(attribute.Bool("opaque", true))
+	}
+	span.SetAttributes(ss.Redaction.MetaAttributes(meta)...)
+	if params.Owner == "" {
+		span.AddEvent("Secret is rejected: creator is unknown")
+		return model.Secret{}, fmt.Errorf("%w: creator is unknown", model.ErrForbidden)
 	}
+	err := ss.Limits.Check(body, meta)
+	if err == nil {
+		err = ss.checkOpaque(body, params.Opaque)
+	}
+	if err != nil {
+		span.AddEvent("Secret is rejected: " + err.Error())
+		return model.Secret{}, err
+	}
+	err = ss.Webhooks.checkCallback(ctxWithTracing, params.CallbackURL)
+	if err != nil {
+		span.AddEvent("Secret is rejected: " + err.Error())
+		return model.Secret{}, err
+	}
+	now := time.Now()
+	// срок жизни секрета, который станет доступен позже, отсчитывается с момента его доступности
+	start := now
+	var notBefore time.Time
+	if params.NotBefore.After(now) {
+		start = params.NotBefore
+		notBefore = params.NotBefore
+		span.SetAttributes(attribute.String("not_before", notBefore.Format(time.RFC3339)))
+	}
+	ttl := ss.TTL.Clamp(start, params.TTL, params.ExpireAt)
+	span.SetAttributes(attribute.String("ttl", ttl.String()))
 
-	// тут можно сделать всякую хитрую бизнес логику, допустим, если
-	// в секрете встречается слово golang, то ему добавляем мету programming,
-	// и такое поведение сохраниться при любых вызовах сервиса,
-	// и из HTTP транспорта, и из GRPC транспорта и т.д.
-	if strings.Contains(body, "golang") || strings.Contains(body, "Golang") {
-		meta["programming"] = "yes"
-		span.SetAttributes(attribute.Bool("programming", true))
+	// метаданные обогащаются правилами, и такое поведение сохраняется при любых вызовах сервиса,
+	// и из HTTP транспорта, и из GRPC транспорта и т.д. Правила смотрят на тело, поэтому зашифрованные
+	// клиентом секреты не обогащаются
+	if !params.Opaque {
+		applied := ss.enricher().Apply(body, meta)
+		if len(applied) > 0 {
+			span.SetAttributes(attribute.StringSlice("enrichment_rules", applied))
+		}
+	}
+
+	contentType := params.ContentType
+	if contentType == "" {
+		contentType = detectContentType(body, params.Opaque)
+	}
+	span.SetAttributes(attribute.String("content_type", contentType))
+	filename := cleanFilename(params.Filename)
+	if filename != "" {
+		span.SetAttributes(attribute.String("filename", filename))
+	}
+
+	// отметки о нарушениях ставит только политика содержимого
+	delete(meta, PolicyFlagsKey)
+	flagged, err := validate(ctxWithTracing, ss.Validators, Candidate{
+		Body:        inspectable(body, params.Opaque),
+		Meta:        meta,
+		ContentType: contentType,
+		Filename:    filename,
+		Owner:       params.Owner,
+	})
+	if len(flagged) > 0 {
+		meta[PolicyFlagsKey] = strings.Join(flagged, ",")
+		span.SetAttributes(attribute.StringSlice("policy_flags", flagged))
+	}
+	if err != nil {
+		span.AddEvent("Secret is rejected: " + err.Error())
+		return model.Secret{}, err
+	}
+
+	if len(params.Recipients) > 0 {
+		span.SetAttributes(attribute.StringSlice("recipients", params.Recipients))
+	}
+	if len(params.Groups) > 0 {
+		span.SetAttributes(attribute.StringSlice("groups", params.Groups))
+	}
+
+	if params.MaxViews < 0 {
+		params.MaxViews = 0
+	}
+	if params.MaxViews > 0 {
+		span.SetAttributes(attribute.Int64("max_views", params.MaxViews))
+	}
+
+	var passphraseHash string
+	if params.Passphrase != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(params.Passphrase), bcrypt.DefaultCost)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return model.Secret{}, err
+		}
+		passphraseHash = string(hash)
+		span.SetAttributes(attribute.Bool("protected", true))
 	}
 
-	secret, err := ss.Repo.Create(ctxWithTracing, body, meta)
+	shareToken, err := newShareToken()
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
 		return model.Secret{}, err
 	}
+
+	secret, err := ss.createWithinQuota(ctxWithTracing, model.Secret{
+		Body:           body,
+		Meta:           meta,
+		ContentType:    contentType,
+		Filename:       filename,
+		Owner:          params.Owner,
+		Recipients:     uniqueNonEmpty(params.Recipients),
+		Groups:         uniqueNonEmpty(params.Groups),
+		CreatedAt:      now,
+		ExpireAt:       start.Add(ttl),
+		NotBefore:      notBefore,
+		MaxViews:       params.MaxViews,
+		Version:        1,
+		PassphraseHash: passphraseHash,
+		ShareTokenHash: hashShareToken(shareToken),
+		Opaque:         params.Opaque,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrQuotaExceeded) {
+			span.AddEvent("Secret is rejected: " + err.Error())
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.Secret{}, err
+	}
+	secret.ShareToken = shareToken
 	span.AddEvent("Secret is created")
 	span.SetAttributes(attribute.String("secret_id", secret.ID))
-	return secret, err
+	ss.audit(ctxWithTracing, secret.ID, model.AuditCreate, params.Owner, "")
+	// адрес для уведомлений проверен до сохранения секрета, а секрет уже создан,
+	// поэтому ошибка сохранения подписки только отмечается
+	err = ss.Webhooks.Subscribe(ctxWithTracing, secret, params.CallbackURL)
+	if err != nil {
+		span.AddEvent("Webhook subscription is not saved: " + err.Error())
+		span.RecordError(err)
+	}
+	return secret, nil
+}
+
+// discard удаляет только что созданный секрет, если операция, ради которой он создан, не удалась.
+// Удаление записывается в журнал аудита от имени subject с причиной reason, на месте секрета остаётся запись
+// о том, что он удалён, а подписка на его события удаляется без уведомления, так как о секрете ещё никто не узнал
+func (ss *SecretService) discard(ctx context.Context, id, subject, reason string) {
+	err := ss.Repo.DeleteByID(ctx, id)
+	if err != nil {
+		span := trace.SpanFromContext(ctx)
+		span.AddEvent("Secret is not discarded: " + err.Error())
+		span.RecordError(err)
+		return
+	}
+	ss.Tombstones.Bury(ctx, id, model.TombstoneDeleted)
+	ss.audit(ctx, id, model.AuditDelete, subject, reason)
+	ss.Webhooks.Unsubscribe(ctx, id)
+}
+
+// ReadOptions задаёт параметры чтения секрета
+type ReadOptions struct {
+	// Burn - сжечь секрет после прочтения, при этом из нескольких одновременных читателей секрет получит только один
+	Burn bool
+	// Passphrase - кодовая фраза, если секрет ей защищён
+	Passphrase string
+	// Identity - субъект JWT токена читателя и его группы
+	Identity model.Identity
+	// shared - секрет читается по токену ссылки, который сам даёт право на чтение
+	shared bool
 }
 
-// FindByID ищет секрет по идентификатору, если не нашёл, то возвращает ошибку model.ErrSecretNotFound
-func (ss *SecretService) FindByID(ctx context.Context, id string) (model.Secret, error) {
+// authorize проверяет, что субъект - создатель или адресат секрета.
+// Секреты, у которых создатель неизвестен, и секреты с долями разделённого секрета не доступны никому, кроме адресатов
+func authorize(secret model.Secret, identity model.Identity) error {
+	if identity.Subject == "" {
+		return model.ErrForbidden
+	}
+	if (secret.Owner == identity.Subject && !isShare(secret)) || secret.AddressedTo(identity) {
+		return nil
+	}
+	return model.ErrForbidden
+}
+
+// checkPassphrase проверяет кодовую фразу уже найденного секрета, не засчитывая его прочтение.
+// Неверная попытка засчитывается, и после MaxPassphraseAttempts таких попыток секрет уничтожается
+func (ss *SecretService) checkPassphrase(ctx context.Context, secret model.Secret, passphrase string) error {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.checkPassphrase")
+	defer span.End()
+	if !secret.Protected() {
+		return nil
+	}
+	if passphrase == "" {
+		span.AddEvent("Passphrase is required")
+		return model.ErrPassphraseRequired
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(secret.PassphraseHash), []byte(passphrase))
+	if err == nil {
+		span.AddEvent("Passphrase is correct")
+		return nil
+	}
+	if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return err
+	}
+	maxAttempts := ss.MaxPassphraseAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = model.MaxPassphraseAttempts
+	}
+	left, err := ss.Repo.RegisterFailedAttempt(ctxWithTracing, secret.ID, maxAttempts)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int64("attempts_left", left))
+	if left == 0 {
+		span.AddEvent("Secret is destroyed after too many wrong passphrases")
+		ss.Tombstones.Bury(ctxWithTracing, secret.ID, model.TombstoneDestroyed)
+	}
+	return fmt.Errorf("%w: %v attempts left", model.ErrWrongPassphrase, left)
+}
+
+// FindByID ищет секрет по идентификатору, если не нашёл, то возвращает ошибку model.ErrSecretNotFound,
+// а если секрет уже прочитали, удалили или он устарел - *model.GoneError, которая тоже сравнивается с model.ErrSecretNotFound.
+// Если читатель не имеет прав на секрет, возвращается model.ErrForbidden, а если секрет ещё не стал доступен -
+// *model.NotYetAvailableError с моментом, начиная с которого его можно будет прочитать
+func (ss *SecretService) FindByID(ctx context.Context, id string, opts ReadOptions) (secret model.Secret, err error) {
 	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.FindByID")
 	defer span.End()
 	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.String("subject", opts.Identity.Subject))
+	span.SetAttributes(attribute.Bool("burn", opts.Burn))
+	span.SetAttributes(attribute.Bool("shared", opts.shared))
 	span.AddEvent("Searching for secret by id...")
-	secret, err := ss.Repo.FindByID(ctxWithTracing, id)
+	// права и кодовая фраза проверяются до того, как будет засчитано прочтение
+	secret, err = ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil && !opts.shared {
+		err = authorize(secret, opts.Identity)
+	}
+	if err == nil && !secret.Active(time.Now()) {
+		err = &model.NotYetAvailableError{NotBefore: secret.NotBefore}
+	}
+	if err == nil {
+		err = ss.checkPassphrase(ctxWithTracing, secret, opts.Passphrase)
+	}
+	if err == nil {
+		if opts.Burn {
+			secret, err = ss.Repo.FindAndDeleteByID(ctxWithTracing, id)
+		} else {
+			secret, err = ss.Repo.FindByID(ctxWithTracing, id)
+		}
+	}
+	err = ss.Tombstones.Explain(ctxWithTracing, id, err)
 	if err != nil {
 		if errors.Is(err, model.ErrSecretNotFound) {
 			span.AddEvent("Secret not found")
+		} else if errors.Is(err, model.ErrNotYetAvailable) {
+			span.AddEvent("Secret is not yet available: " + err.Error())
+		} else if errors.Is(err, model.ErrForbidden) ||
+			errors.Is(err, model.ErrPassphraseRequired) || errors.Is(err, model.ErrWrongPassphrase) {
+			span.AddEvent("Access denied: " + err.Error())
+			ss.auditDenied(ctxWithTracing, id, opts.Identity.Subject, err)
 		} else { // unexpected error
 			span.SetStatus(codes.Error, err.Error())
 			span.RecordError(err)
@@ -80,42 +383,243 @@ func (ss *SecretService) FindByID(ctx context.Context, id string) (model.Secret,
 		return model.Secret{}, err
 	}
 	span.AddEvent("Secret is found!")
-	span.SetAttributes(attribute.String("body", secret.Body))
-	for k := range secret.Meta {
-		span.SetAttributes(attribute.String("meta_"+k, secret.Meta[k]))
+	span.SetAttributes(attribute.Int64("views_left", secret.ViewsLeft()))
+	detail := ""
+	if opts.Burn {
+		span.AddEvent("Secret is burned")
+		detail = "burned"
+	}
+	ss.audit(ctxWithTracing, id, model.AuditRead, opts.Identity.Subject, detail)
+	if opts.Burn || secret.ViewsLeft() == 0 {
+		ss.Tombstones.Bury(ctxWithTracing, id, model.TombstoneRead)
+	} else {
+		ss.recordAccess(ctxWithTracing, id, opts.Identity.Subject)
 	}
+	ss.Webhooks.Notify(ctxWithTracing, id, model.WebhookSecretRead, opts.Identity.Subject,
+		opts.Burn || secret.ViewsLeft() == 0)
+	span.SetAttributes(attribute.Int("body_size", len(secret.Body)))
+	span.SetAttributes(ss.Redaction.MetaAttributes(secret.Meta)...)
 	return secret, nil
 }
 
-// DeleteByID удаляет секрет по идентификатору
-func (ss *SecretService) DeleteByID(ctx context.Context, id string) error {
+// recordAccess добавляет прочтение в журнал прочтений секрета. Секрет могли удалить сразу после прочтения,
+// поэтому ошибки только отмечаются в span
+func (ss *SecretService) recordAccess(ctx context.Context, id, subject string) {
+	keepAccesses := ss.KeepAccesses
+	if keepAccesses <= 0 {
+		keepAccesses = model.KeepAccesses
+	}
+	err := ss.Repo.RecordAccess(ctx, id, model.SecretAccess{
+		Subject:    subject,
+		AccessedAt: time.Now(),
+	}, keepAccesses)
+	if err != nil && !errors.Is(err, model.ErrSecretNotFound) {
+		span := trace.SpanFromContext(ctx)
+		span.AddEvent("Access is not recorded: " + err.Error())
+		span.RecordError(err)
+	}
+}
+
+// Status возвращает состояние секрета с журналом его прочтений, не засчитывая прочтение и не раскрывая тело.
+// Состояние секрета может узнать только его создатель, остальным возвращается model.ErrForbidden
+func (ss *SecretService) Status(ctx context.Context, id string, identity model.Identity) (model.SecretStatus, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Status")
+	defer span.End()
+	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorizeOwner(secret, identity)
+	}
+	var accesses []model.SecretAccess
+	if err == nil {
+		accesses, err = ss.Repo.ListAccesses(ctxWithTracing, id)
+	}
+	err = ss.Tombstones.Explain(ctxWithTracing, id, err)
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			span.AddEvent("Secret not found")
+		} else if errors.Is(err, model.ErrForbidden) {
+			span.AddEvent("Access denied: " + err.Error())
+			ss.auditDenied(ctxWithTracing, id, identity.Subject, err)
+		} else { // unexpected error
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.SecretStatus{}, err
+	}
+	status := secret.Status(time.Now(), accesses)
+	span.SetAttributes(attribute.Int64("views", status.Views))
+	span.SetAttributes(attribute.Int("accesses", len(status.Accesses)))
+	return status, nil
+}
+
+// DeleteByID удаляет секрет по идентификатору, удалить секрет может только его создатель или адресат
+func (ss *SecretService) DeleteByID(ctx context.Context, id string, identity model.Identity) error {
 	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.DeleteByID")
 	defer span.End()
 	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.String("subject", identity.Subject))
 	span.AddEvent("Deleting secret by id...")
-	err := ss.Repo.DeleteByID(ctxWithTracing, id)
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorize(secret, identity)
+	}
+	if err == nil {
+		err = ss.Repo.DeleteByID(ctxWithTracing, id)
+	}
+	err = ss.Tombstones.Explain(ctxWithTracing, id, err)
 	if err != nil {
 		if errors.Is(err, model.ErrSecretNotFound) {
 			span.AddEvent("Secret not found")
+		} else if errors.Is(err, model.ErrForbidden) {
+			span.AddEvent("Access denied: " + err.Error())
+			ss.auditDenied(ctxWithTracing, id, identity.Subject, err)
 		} else { // unexpected error
 			span.SetStatus(codes.Error, err.Error())
 			span.RecordError(err)
 		}
+		return err
 	}
 	span.AddEvent("Secret is deleted")
-	return err
+	ss.Tombstones.Bury(ctxWithTracing, id, model.TombstoneDeleted)
+	ss.audit(ctxWithTracing, id, model.AuditDelete, identity.Subject, "")
+	ss.Webhooks.Notify(ctxWithTracing, id, model.WebhookSecretDeleted, identity.Subject, true)
+	return nil
+}
+
+// Inbox возвращает секреты, адресованные субъекту или его группам. Тела секретов не возвращаются,
+// чтобы их прочтение засчитывалось только при вызове FindByID
+func (ss *SecretService) Inbox(ctx context.Context, identity model.Identity) ([]model.Secret, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Inbox")
+	defer span.End()
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	span.SetAttributes(attribute.StringSlice("groups", identity.Groups))
+	secrets, err := ss.Repo.FindByRecipient(ctxWithTracing, identity.Subject, identity.Groups)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, err
+	}
+	for i := range secrets {
+		secrets[i] = metadataOnly(secrets[i])
+	}
+	span.SetAttributes(attribute.Int("found", len(secrets)))
+	return secrets, nil
 }
 
-// Prune удаляет устаревшие секреты
+// DefaultListLimit задаёт, сколько секретов на странице списка по умолчанию
+const DefaultListLimit = 20
+
+// MaxListLimit задаёт, сколько секретов на странице списка можно запросить максимум
+const MaxListLimit = 100
+
+// List возвращает страницу секретов субъекта без тел и курсор следующей страницы.
+// Субъект видит только созданные им секреты, поэтому фильтр по создателю либо пустой, либо совпадает с субъектом
+func (ss *SecretService) List(ctx context.Context, identity model.Identity, filter repository.ListFilter) ([]model.Secret, string, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.List")
+	defer span.End()
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	span.SetAttributes(attribute.String("cursor", filter.Cursor))
+	if identity.Subject == "" || (filter.Owner != "" && filter.Owner != identity.Subject) {
+		span.SetStatus(codes.Error, model.ErrForbidden.Error())
+		return nil, "", model.ErrForbidden
+	}
+	filter.Owner = identity.Subject
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if filter.Limit > MaxListLimit {
+		filter.Limit = MaxListLimit
+	}
+	_, _, err := repository.DecodeCursor(filter.Cursor)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, "", err
+	}
+	secrets, next, err := ss.Repo.List(ctxWithTracing, filter)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return nil, "", err
+	}
+	for i := range secrets {
+		secrets[i] = metadataOnly(secrets[i])
+	}
+	span.SetAttributes(attribute.Int("found", len(secrets)))
+	span.SetAttributes(attribute.String("next", next))
+	return secrets, next, nil
+}
+
+// metadataOnly убирает из секрета тело и служебные поля
+func metadataOnly(secret model.Secret) model.Secret {
+	secret.Body = nil
+	secret.PassphraseHash = ""
+	secret.KeyID = ""
+	secret.WrappedKey = nil
+	return secret
+}
+
+// cleanFilename оставляет от имени файла только последний элемент пути без управляющих символов,
+// чтобы имя можно было безопасно подставить в заголовок Content-Disposition
+func cleanFilename(filename string) string {
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "." || filename == "/" || filename == ".." {
+		return ""
+	}
+	return strings.TrimSpace(filename)
+}
+
+// uniqueNonEmpty убирает из списка пустые строки и повторы
+func uniqueNonEmpty(list []string) []string {
+	ret := make([]string, 0, len(list))
+	seen := make(map[string]bool, len(list))
+	for i := range list {
+		item := strings.TrimSpace(list[i])
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+// Prune удаляет устаревшие секреты, записывает их устаревание в журнал аудита и сообщает о нём создателям,
+// а также удаляет устаревшие подписки на события секретов, записи об исчезнувших секретах и запросы секретов
 func (ss *SecretService) Prune(ctx context.Context) error {
 	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Prune")
 	defer span.End()
-	err := ss.Repo.Prune(ctxWithTracing)
+	pruned, err := ss.Repo.Prune(ctxWithTracing)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		span.RecordError(err)
-	} else {
-		span.AddEvent("Secrets pruned")
+		return err
 	}
-	return err
+	span.AddEvent("Secrets pruned")
+	span.SetAttributes(attribute.Int("pruned", len(pruned)))
+	for i := range pruned {
+		ss.Tombstones.Bury(ctxWithTracing, pruned[i], model.TombstoneExpired)
+		ss.audit(ctxWithTracing, pruned[i], model.AuditExpire, "", "")
+		ss.Webhooks.Notify(ctxWithTracing, pruned[i], model.WebhookSecretExpired, "", true)
+	}
+	err = ss.Webhooks.Prune(ctxWithTracing)
+	if err == nil {
+		err = ss.Tombstones.Prune(ctxWithTracing)
+	}
+	if err == nil && ss.Requests != nil {
+		err = ss.Requests.PruneRequests(ctxWithTracing)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return err
+	}
+	return nil
 }
@@ -42,14 +42,19 @@ func secretServiceTester(t *testing.T, repo repository.SecretRepo) {
 		t.Errorf("error pinging repo: %s", err)
 		return
 	}
-	secret, err := ss.Create(ctx, "test secret", map[string]string{
-		"a": "b",
+	alice := model.Identity{Subject: "alice"}
+	secret, err := ss.Create(ctx, model.SecretParams{
+		Owner: "alice",
+		Body:  []byte("test secret"),
+		Meta: map[string]string{
+			"a": "b",
+		},
 	})
 	if err != nil {
 		t.Errorf("error creating secret: %s", err)
 		return
 	}
-	found, err := ss.FindByID(ctx, secret.ID)
+	found, err := ss.FindByID(ctx, secret.ID, ReadOptions{Identity: alice})
 	if err != nil {
 		t.Errorf("error creating secret: %s", err)
 		return
@@ -58,7 +63,7 @@ func secretServiceTester(t *testing.T, repo repository.SecretRepo) {
 	assert.Equal(t, secret.Body, found.Body, "body differs")
 	assert.Equal(t, secret.Meta, found.Meta, "meta differs")
 
-	empty, err := ss.FindByID(ctx, misc.UUID())
+	empty, err := ss.FindByID(ctx, misc.UUID(), ReadOptions{Identity: alice})
 	if err != nil {
 		if err != model.ErrSecretNotFound {
 			t.Errorf("wrong error: %s", err)
@@ -66,16 +71,16 @@ func secretServiceTester(t *testing.T, repo repository.SecretRepo) {
 		}
 	}
 	assert.Equal(t, empty.ID, "", "id differs")
-	assert.Equal(t, empty.Body, "", "body differs")
+	assert.Empty(t, empty.Body, "body differs")
 	assert.Equal(t, len(empty.Meta), 0, "meta differs")
 
-	err = ss.DeleteByID(ctx, secret.ID)
+	err = ss.DeleteByID(ctx, secret.ID, alice)
 	if err != nil {
 		t.Errorf("error deleting: %s", err)
 		return
 	}
 
-	empty, err = ss.FindByID(ctx, secret.ID)
+	empty, err = ss.FindByID(ctx, secret.ID, ReadOptions{Identity: alice})
 	if err != nil {
 		if err != model.ErrSecretNotFound {
 			t.Errorf("wrong error: %s", err)
@@ -85,18 +90,22 @@ func secretServiceTester(t *testing.T, repo repository.SecretRepo) {
 		t.Error("no error thrown for record not found")
 	}
 	assert.Equal(t, empty.ID, "", "id differs")
-	assert.Equal(t, empty.Body, "", "body differs")
+	assert.Empty(t, empty.Body, "body differs")
 	assert.Equal(t, len(empty.Meta), 0, "meta differs")
 
 	// проверяем хитрую бизнес логику
-	programmersSecret, err := ss.Create(ctx, "Мне нравится язык программирования Golang", map[string]string{
-		"a": "b",
+	programmersSecret, err := ss.Create(ctx, model.SecretParams{
+		Owner: "alice",
+		Body:  []byte("Мне нравится язык программирования Golang"),
+		Meta: map[string]string{
+			"a": "b",
+		},
 	})
 	if err != nil {
 		t.Errorf("error creating secret: %s", err)
 		return
 	}
-	found, err = ss.FindByID(ctx, programmersSecret.ID)
+	found, err = ss.FindByID(ctx, programmersSecret.ID, ReadOptions{Identity: alice})
 	if err != nil {
 		t.Errorf("error creating secret: %s", err)
 		return
@@ -108,6 +117,411 @@ func secretServiceTester(t *testing.T, repo repository.SecretRepo) {
 	assert.Equal(t, programmersSecret.Meta["programming"], found.Meta["programming"], "meta differs")
 	assert.Equal(t, "yes", programmersSecret.Meta["programming"], "meta differs")
 	assert.Equal(t, "yes", found.Meta["programming"], "meta differs")
+
+	// проверяем, что срок жизни секрета задаётся создателем
+	shortLived, err := ss.Create(ctx, model.SecretParams{
+		Owner: "alice",
+		Body:  []byte("одноразовый пароль"),
+		TTL:   5 * time.Minute,
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	found, err = ss.FindByID(ctx, shortLived.ID, ReadOptions{Identity: alice})
+	if err != nil {
+		t.Errorf("error finding secret: %s", err)
+		return
+	}
+	assert.WithinDuration(t, found.CreatedAt.Add(5*time.Minute), found.ExpireAt, time.Second, "ttl is not honoured")
+
+	// проверяем, что секрет сгорает после прочтения
+	burned, err := ss.FindByID(ctx, shortLived.ID, ReadOptions{Identity: alice, Burn: true})
+	if err != nil {
+		t.Errorf("error burning secret: %s", err)
+		return
+	}
+	assert.Equal(t, shortLived.Body, burned.Body, "body differs")
+	_, err = ss.FindByID(ctx, shortLived.ID, ReadOptions{Identity: alice})
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("burned secret is still readable: %v", err)
+	}
+
+	// проверяем секреты, защищённые кодовой фразой
+	protected, err := ss.Create(ctx, model.SecretParams{
+		Owner:      "alice",
+		Body:       []byte("пароль от сервера"),
+		MaxViews:   1,
+		Passphrase: "открой, это я",
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	assert.NotEqual(t, "открой, это я", protected.PassphraseHash, "passphrase is stored as is")
+	_, err = ss.FindByID(ctx, protected.ID, ReadOptions{Identity: alice})
+	if !errors.Is(err, model.ErrPassphraseRequired) {
+		t.Errorf("wrong error for missing passphrase: %v", err)
+	}
+	_, err = ss.FindByID(ctx, protected.ID, ReadOptions{Identity: alice, Passphrase: "это не я"})
+	if !errors.Is(err, model.ErrWrongPassphrase) {
+		t.Errorf("wrong error for wrong passphrase: %v", err)
+	}
+	found, err = ss.FindByID(ctx, protected.ID, ReadOptions{Identity: alice, Passphrase: "открой, это я"})
+	if err != nil {
+		t.Errorf("error reading secret with correct passphrase: %s", err)
+		return
+	}
+	assert.Equal(t, protected.Body, found.Body, "body differs")
+
+	// после исчерпания попыток секрет уничтожается
+	locked, err := ss.Create(ctx, model.SecretParams{
+		Owner:      "alice",
+		Body:       []byte("пароль от базы данных"),
+		Passphrase: "открой, это я",
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	for i := 0; i < model.MaxPassphraseAttempts; i++ {
+		_, err = ss.FindByID(ctx, locked.ID, ReadOptions{Identity: alice, Passphrase: "это не я"})
+		if !errors.Is(err, model.ErrWrongPassphrase) {
+			t.Errorf("wrong error for wrong passphrase: %v", err)
+		}
+	}
+	_, err = ss.FindByID(ctx, locked.ID, ReadOptions{Identity: alice, Passphrase: "открой, это я"})
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("secret is not destroyed after too many wrong passphrases: %v", err)
+	}
+
+	// секрет без известного создателя не создаётся, а уже сохранённый никому, кроме адресатов, не выдаётся
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("ничей пароль")})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for secret without creator: %v", err)
+	}
+	orphan, err := repo.Create(ctx, model.Secret{
+		Body:     []byte("ничей пароль"),
+		Meta:     map[string]string{"Subject": "bob"},
+		ExpireAt: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Errorf("error creating secret directly: %s", err)
+		return
+	}
+	_, err = ss.FindByID(ctx, orphan.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for secret without creator: %v", err)
+	}
+	_, err = ss.Update(ctx, orphan.ID, UpdateParams{Body: []byte("мой пароль"), Identity: model.Identity{Subject: "bob"}})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for updating secret without creator: %v", err)
+	}
+
+	// прочитать и удалить секрет может только его создатель
+	owned, err := ss.Create(ctx, model.SecretParams{
+		Body:       []byte("пароль от почты"),
+		Owner:      "alice",
+		Passphrase: "открой, это я",
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	assert.Equal(t, "alice", owned.Owner, "owner differs")
+	_, err = ss.FindByID(ctx, owned.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}, Passphrase: "это не я"})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for secret of another subject: %v", err)
+	}
+	err = ss.DeleteByID(ctx, owned.ID, model.Identity{Subject: "bob"})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for deleting secret of another subject: %v", err)
+	}
+	found, err = ss.FindByID(ctx, owned.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}, Passphrase: "открой, это я"})
+	if err != nil {
+		t.Errorf("error reading own secret: %s", err)
+		return
+	}
+	assert.Equal(t, owned.Body, found.Body, "body differs")
+	assert.Equal(t, int64(0), found.FailedAttempts, "failed attempt of another subject is counted")
+	err = ss.DeleteByID(ctx, owned.ID, model.Identity{Subject: "alice"})
+	if err != nil {
+		t.Errorf("error deleting own secret: %s", err)
+	}
+
+	// секрет, адресованный пользователю и группе, могут прочитать только создатель и адресаты
+	addressed, err := ss.Create(ctx, model.SecretParams{
+		Body:       []byte("пароль от мониторинга"),
+		Owner:      "alice",
+		Recipients: []string{"bob", "", "bob"},
+		Groups:     []string{"sre"},
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	assert.Equal(t, []string{"bob"}, addressed.Recipients, "recipients are not cleaned up")
+	inbox, err := ss.Inbox(ctx, model.Identity{Subject: "carol", Groups: []string{"sre"}})
+	if err != nil {
+		t.Errorf("error listing inbox: %s", err)
+		return
+	}
+	if assert.Equal(t, 1, len(inbox), "wrong inbox size") {
+		assert.Equal(t, addressed.ID, inbox[0].ID, "wrong secret in inbox")
+		assert.Empty(t, inbox[0].Body, "inbox exposes secret body")
+	}
+	for _, reader := range []model.Identity{
+		{Subject: "alice"},
+		{Subject: "bob"},
+		{Subject: "carol", Groups: []string{"dba", "sre"}},
+	} {
+		found, err = ss.FindByID(ctx, addressed.ID, ReadOptions{Identity: reader})
+		if err != nil {
+			t.Errorf("error reading addressed secret by %s: %s", reader.Subject, err)
+			return
+		}
+		assert.Equal(t, addressed.Body, found.Body, "body differs")
+	}
+	_, err = ss.FindByID(ctx, addressed.ID, ReadOptions{Identity: model.Identity{Subject: "dave", Groups: []string{"qa"}}})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for secret not addressed to subject: %v", err)
+	}
+	inbox, err = ss.Inbox(ctx, model.Identity{Subject: "dave", Groups: []string{"qa"}})
+	if err != nil {
+		t.Errorf("error listing inbox: %s", err)
+		return
+	}
+	assert.Empty(t, inbox, "secret not addressed to subject is in inbox")
+
+	// изменить секрет может только создатель и только последнюю версию
+	editable, err := ss.Create(ctx, model.SecretParams{
+		Body:       []byte("старый пароль"),
+		Meta:       map[string]string{"host": "db1"},
+		Owner:      "alice",
+		Recipients: []string{"bob"},
+	})
+	if err != nil {
+		t.Errorf("error creating secret: %s", err)
+		return
+	}
+	assert.Equal(t, int64(1), editable.Version, "new secret has wrong version")
+	newBody := []byte("новый пароль")
+	_, err = ss.Update(ctx, editable.ID, UpdateParams{
+		Body:     newBody,
+		Identity: model.Identity{Subject: "bob"},
+	})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for updating secret by recipient: %v", err)
+	}
+	expireAt := time.Now().Add(2 * time.Hour)
+	updated, err := ss.Update(ctx, editable.ID, UpdateParams{
+		Body:     newBody,
+		Meta:     map[string]string{"port": "5432"},
+		ExpireAt: expireAt,
+		Version:  1,
+		Identity: model.Identity{Subject: "alice"},
+	})
+	if err != nil {
+		t.Errorf("error updating secret: %s", err)
+		return
+	}
+	assert.Equal(t, int64(2), updated.Version, "version is not increased")
+	assert.Empty(t, updated.Body, "update exposes secret body")
+	assert.Equal(t, map[string]string{"host": "db1", "port": "5432"}, updated.Meta, "meta is not merged")
+	assert.WithinDuration(t, expireAt, updated.ExpireAt, time.Second, "expiration is not extended")
+	_, err = ss.Update(ctx, editable.ID, UpdateParams{
+		Body:     newBody,
+		Version:  1,
+		Identity: model.Identity{Subject: "alice"},
+	})
+	if !errors.Is(err, model.ErrVersionConflict) {
+		t.Errorf("wrong error for updating stale version: %v", err)
+	}
+	updated, err = ss.Update(ctx, editable.ID, UpdateParams{
+		Meta:        map[string]string{"host": "db2"},
+		ReplaceMeta: true,
+		Identity:    model.Identity{Subject: "alice"},
+	})
+	if err != nil {
+		t.Errorf("error updating secret: %s", err)
+		return
+	}
+	assert.Equal(t, int64(3), updated.Version, "version is not increased")
+	assert.Equal(t, map[string]string{"host": "db2"}, updated.Meta, "meta is not replaced")
+	found, err = ss.FindByID(ctx, editable.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	if err != nil {
+		t.Errorf("error reading updated secret: %s", err)
+		return
+	}
+	assert.Equal(t, newBody, found.Body, "body is not updated")
+	assert.Equal(t, int64(3), found.Version, "wrong version is read")
+
+	// создатель видит историю секрета и может откатить его к прежней версии
+	_, err = ss.ListVersions(ctx, editable.ID, model.Identity{Subject: "bob"})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for listing versions by recipient: %v", err)
+	}
+	versions, err := ss.ListVersions(ctx, editable.ID, model.Identity{Subject: "alice"})
+	if err != nil {
+		t.Errorf("error listing versions: %s", err)
+		return
+	}
+	if assert.Len(t, versions, 2, "wrong number of versions") {
+		assert.Equal(t, int64(2), versions[0].Version, "newest version is not first")
+		assert.Empty(t, versions[0].Body, "list of versions exposes body")
+	}
+	first, err := ss.GetVersion(ctx, editable.ID, 1, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Errorf("error getting version: %s", err)
+		return
+	}
+	assert.Equal(t, "старый пароль", string(first.Body), "wrong body of version")
+	rolledBack, err := ss.Rollback(ctx, editable.ID, 1, 3, model.Identity{Subject: "alice"})
+	if err != nil {
+		t.Errorf("error rolling back secret: %s", err)
+		return
+	}
+	assert.Equal(t, int64(4), rolledBack.Version, "rollback does not create new version")
+	assert.Equal(t, map[string]string{"host": "db1"}, rolledBack.Meta, "meta is not rolled back")
+	found, err = ss.FindByID(ctx, editable.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Errorf("error reading rolled back secret: %s", err)
+		return
+	}
+	assert.Equal(t, "старый пароль", string(found.Body), "body is not rolled back")
+	_, err = ss.Rollback(ctx, editable.ID, 1, 3, model.Identity{Subject: "alice"})
+	if !errors.Is(err, model.ErrVersionConflict) {
+		t.Errorf("wrong error for rolling back stale version: %v", err)
+	}
+
+	// субъект видит в списке только созданные им секреты и без тел
+	lister := model.Identity{Subject: "lister-" + misc.UUID()}
+	for i := 0; i < 3; i++ {
+		_, err = ss.Create(ctx, model.SecretParams{Body: []byte("секрет из списка"), Owner: lister.Subject})
+		if err != nil {
+			t.Errorf("error creating secret: %s", err)
+			return
+		}
+	}
+	page, next, err := ss.List(ctx, lister, repository.ListFilter{Limit: 2})
+	if err != nil {
+		t.Errorf("error listing secrets: %s", err)
+		return
+	}
+	assert.Len(t, page, 2, "wrong page size")
+	assert.NotEmpty(t, next, "next page is not found")
+	for i := range page {
+		assert.Equal(t, lister.Subject, page[i].Owner, "secret of another subject is listed")
+		assert.Empty(t, page[i].Body, "list exposes secret body")
+	}
+	page, next, err = ss.List(ctx, lister, repository.ListFilter{Limit: 2, Cursor: next})
+	if err != nil {
+		t.Errorf("error listing secrets: %s", err)
+		return
+	}
+	assert.Len(t, page, 1, "wrong last page size")
+	assert.Empty(t, next, "next page is found after last page")
+	_, _, err = ss.List(ctx, lister, repository.ListFilter{Owner: "alice"})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for listing secrets of another subject: %v", err)
+	}
+	_, _, err = ss.List(ctx, model.Identity{}, repository.ListFilter{})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for listing secrets anonymously: %v", err)
+	}
+	_, _, err = ss.List(ctx, lister, repository.ListFilter{Cursor: "!"})
+	if !errors.Is(err, model.ErrInvalidCursor) {
+		t.Errorf("wrong error for malformed cursor: %v", err)
+	}
+
+	// двоичный секрет сохраняет тело байт в байт, тип содержимого определяется по телу,
+	// а от имени файла остаётся только последний элемент пути
+	binaryBody := []byte{0x30, 0x82, 0x00, 0xff, 0xfe, 0x00}
+	binary, err := ss.Create(ctx, model.SecretParams{
+		Body:     binaryBody,
+		Filename: "../../etc/client\nkey.p12",
+		Owner:    "alice",
+	})
+	if err != nil {
+		t.Errorf("error creating binary secret: %s", err)
+		return
+	}
+	found, err = ss.FindByID(ctx, binary.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Errorf("error finding binary secret: %s", err)
+		return
+	}
+	assert.Equal(t, binaryBody, found.Body, "binary body differs")
+	assert.Equal(t, "application/octet-stream", found.ContentType, "content type is not detected")
+	assert.Equal(t, "clientkey.p12", found.Filename, "filename is not cleaned")
+	_, err = ss.Update(ctx, binary.ID, UpdateParams{
+		Body:     []byte("apiVersion: v1"),
+		Filename: "kubeconfig",
+		Identity: model.Identity{Subject: "alice"},
+	})
+	if err != nil {
+		t.Errorf("error updating binary secret: %s", err)
+		return
+	}
+	found, err = ss.FindByID(ctx, binary.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Errorf("error finding updated secret: %s", err)
+		return
+	}
+	assert.Equal(t, "text/plain; charset=utf-8", found.ContentType, "content type is not detected on update")
+	assert.Equal(t, "kubeconfig", found.Filename, "filename is not updated")
+
+	// создатель узнаёт, кто и когда читал секрет, не засчитывая прочтение
+	watched, err := ss.Create(ctx, model.SecretParams{
+		Body:       []byte("watched"),
+		Owner:      "alice",
+		Recipients: []string{"bob"},
+		MaxViews:   3,
+	})
+	if err != nil {
+		t.Errorf("error creating watched secret: %s", err)
+		return
+	}
+	status, err := ss.Status(ctx, watched.ID, model.Identity{Subject: "alice"})
+	if err != nil {
+		t.Errorf("error getting status: %s", err)
+		return
+	}
+	assert.False(t, status.Opened, "unread secret is opened")
+	assert.Empty(t, status.Accesses, "unread secret has accesses")
+	for i := 0; i < 2; i++ {
+		_, err = ss.FindByID(ctx, watched.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+		if err != nil {
+			t.Errorf("error reading watched secret: %s", err)
+			return
+		}
+	}
+	_, err = ss.Status(ctx, watched.ID, model.Identity{Subject: "bob"})
+	if !errors.Is(err, model.ErrForbidden) {
+		t.Errorf("wrong error for status requested by recipient: %v", err)
+	}
+	status, err = ss.Status(ctx, watched.ID, model.Identity{Subject: "alice"})
+	if err != nil {
+		t.Errorf("error getting status: %s", err)
+		return
+	}
+	assert.True(t, status.Opened, "read secret is not opened")
+	assert.Equal(t, int64(2), status.Views, "wrong number of views")
+	assert.Equal(t, int64(1), status.ViewsLeft, "status is counted as view")
+	assert.InDelta(t, time.Until(watched.ExpireAt).Seconds(), status.Remaining.Seconds(), 1, "wrong remaining lifetime")
+	if assert.Len(t, status.Accesses, 2, "accesses are not recorded") {
+		assert.Equal(t, "bob", status.Accesses[0].Subject, "reader is not recorded")
+		assert.WithinDuration(t, time.Now(), status.Accesses[1].AccessedAt, time.Minute, "wrong access time")
+	}
+	_, err = ss.FindByID(ctx, watched.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	if err != nil {
+		t.Errorf("error reading watched secret last time: %s", err)
+		return
+	}
+	_, err = ss.Status(ctx, watched.ID, model.Identity{Subject: "alice"})
+	if !errors.Is(err, model.ErrSecretNotFound) {
+		t.Errorf("wrong error for status of destroyed secret: %v", err)
+	}
 }
 
 func TestSecretServiceMemory(t *testing.T) {
@@ -121,7 +535,7 @@ func TestSecretService_Prune(t *testing.T) {
 
 	err := repo.PutSecret(ctx, model.Secret{
 		ID:        "a",
-		Body:      "aa",
+		Body:      []byte("aa"),
 		Meta:      map[string]string{"aaa": "aaaa"},
 		CreatedAt: time.Now().Add(-model.TTL),
 		ExpireAt:  time.Now(),
@@ -148,6 +562,65 @@ func TestSecretService_Prune(t *testing.T) {
 	}
 }
 
+func TestSecretService_NotBefore(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service4not_before"),
+		Repo:   &repo,
+	}
+	alice := model.Identity{Subject: "alice"}
+	activation := time.Now().Add(time.Hour)
+	scheduled, err := ss.Create(ctx, model.SecretParams{
+		Body:      []byte("maintenance window credentials"),
+		Owner:     "alice",
+		TTL:       10 * time.Minute,
+		NotBefore: activation,
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	assert.True(t, scheduled.NotBefore.Equal(activation), "activation time is not saved")
+	assert.WithinDuration(t, activation.Add(10*time.Minute), scheduled.ExpireAt, time.Second,
+		"lifetime is not counted from activation")
+
+	_, err = ss.FindByID(ctx, scheduled.ID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	assert.True(t, errors.Is(err, model.ErrForbidden), "wrong error %v", err)
+	for _, burn := range []bool{false, true} {
+		_, err = ss.FindByID(ctx, scheduled.ID, ReadOptions{Identity: alice, Burn: burn})
+		var notYet *model.NotYetAvailableError
+		if assert.True(t, errors.As(err, &notYet), "wrong error %v", err) {
+			assert.True(t, notYet.NotBefore.Equal(activation), "wrong activation time in error")
+			assert.True(t, errors.Is(err, model.ErrNotYetAvailable))
+		}
+	}
+	err = ss.Prune(ctx)
+	if err != nil {
+		t.Fatalf("error pruning secrets: %s", err)
+	}
+	peeked, err := repo.Peek(ctx, scheduled.ID)
+	if err != nil {
+		t.Fatalf("scheduled secret is pruned: %s", err)
+	}
+	assert.Zero(t, peeked.Views, "read of scheduled secret is counted")
+
+	past, err := ss.Create(ctx, model.SecretParams{
+		Body:      []byte("already available"),
+		Owner:     "alice",
+		NotBefore: time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	assert.True(t, past.NotBefore.IsZero(), "activation time in the past is saved")
+	_, err = ss.FindByID(ctx, past.ID, ReadOptions{Identity: alice})
+	assert.NoError(t, err, "secret available right away is not read")
+}
+
 func TestSecretServiceMysql(t *testing.T) {
 	mysqlConnectionString := os.Getenv("MARIADB_DB_URL")
 	if mysqlConnectionString != "" {
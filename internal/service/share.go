@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// shareTokenBytes задаёт, сколько случайных байт в токене ссылки на секрет
+const shareTokenBytes = 32
+
+// newShareToken создаёт случайный токен ссылки на секрет, пригодный для вставки в адрес
+func newShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashShareToken возвращает хэш токена ссылки, под которым он хранится. В токене достаточно случайных байт,
+// чтобы его нельзя было подобрать, поэтому медленный хэш, как для кодовых фраз, не нужен
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Redeem читает секрет по токену ссылки, выданному при создании секрета. Токен сам даёт право на чтение,
+// поэтому читателю не нужен JWT токен, а создатель и адресаты секрета не проверяются. Кодовая фраза,
+// момент доступности и ограничение числа прочтений действуют так же, как при чтении по идентификатору.
+// Если токен неизвестен или секрет устарел, возвращается model.ErrSecretNotFound
+func (ss *SecretService) Redeem(ctx context.Context, token string, opts ReadOptions) (model.Secret, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Redeem")
+	defer span.End()
+	if token == "" {
+		return model.Secret{}, model.ErrSecretNotFound
+	}
+	id, err := ss.Repo.FindIDByShareToken(ctxWithTracing, hashShareToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			span.AddEvent("Share token is unknown")
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.Secret{}, err
+	}
+	span.SetAttributes(attribute.String("secret_id", id))
+	opts.shared = true
+	return ss.FindByID(ctxWithTracing, id, opts)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSecretService_Redeem(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service4share"),
+		Repo:   &repo,
+	}
+	secret, err := ss.Create(ctx, model.SecretParams{
+		Body:       []byte("wifi password"),
+		Owner:      "alice",
+		Recipients: []string{"bob"},
+		TTL:        10 * time.Minute,
+		Passphrase: "open sesame",
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	if !assert.NotEmpty(t, secret.ShareToken, "share token is not issued") {
+		return
+	}
+	peeked, err := repo.Peek(ctx, secret.ID)
+	if err != nil {
+		t.Fatalf("error peeking secret: %s", err)
+	}
+	assert.Equal(t, hashShareToken(secret.ShareToken), peeked.ShareTokenHash, "share token is not stored hashed")
+	assert.Empty(t, peeked.ShareToken, "share token is stored in clear")
+
+	anonymous := model.Identity{}
+	_, err = ss.FindByID(ctx, secret.ID, ReadOptions{Identity: anonymous, Passphrase: "open sesame"})
+	assert.True(t, errors.Is(err, model.ErrForbidden), "wrong error %v", err)
+	_, err = ss.Redeem(ctx, secret.ShareToken, ReadOptions{Identity: anonymous})
+	assert.True(t, errors.Is(err, model.ErrPassphraseRequired), "wrong error %v", err)
+	redeemed, err := ss.Redeem(ctx, secret.ShareToken, ReadOptions{Identity: anonymous, Passphrase: "open sesame"})
+	if err != nil {
+		t.Fatalf("error redeeming share token: %s", err)
+	}
+	assert.Equal(t, "wifi password", string(redeemed.Body))
+
+	for _, token := range []string{"", "bogus", secret.ShareToken + "x"} {
+		_, err = ss.Redeem(ctx, token, ReadOptions{Identity: anonymous})
+		assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error %v for token %q", err, token)
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+	"github.com/vodolaz095/purser/pkg/shamir"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Ключи метаданных, которые есть у каждого секрета с долей разделённого секрета
+const (
+	// SplitIDKey - идентификатор разделённого секрета, общий для всех его долей
+	SplitIDKey = "Split-ID"
+	// SplitThresholdKey - сколько долей нужно, чтобы восстановить секрет
+	SplitThresholdKey = "Split-Threshold"
+	// SplitSharesKey - на сколько долей разделён секрет
+	SplitSharesKey = "Split-Shares"
+	// SplitCreatorKey - субъект JWT токена того, кто разделил секрет
+	SplitCreatorKey = "Split-Creator"
+)
+
+// shareSeparator отделяет в тексте доли идентификатор разделённого секрета от самой доли
+const shareSeparator = "."
+
+// encodeShare делает из доли текст, который хранитель может скопировать и прислать для восстановления секрета
+func encodeShare(splitID string, share []byte) string {
+	return splitID + shareSeparator + base64.RawURLEncoding.EncodeToString(share)
+}
+
+// decodeShare разбирает текст доли, сделанный encodeShare
+func decodeShare(text string) (splitID string, share []byte, err error) {
+	splitID, encoded, found := strings.Cut(strings.TrimSpace(text), shareSeparator)
+	if !found || splitID == "" {
+		return "", nil, fmt.Errorf("%w: malformed share", model.ErrInvalidShares)
+	}
+	share, err = base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: malformed share", model.ErrInvalidShares)
+	}
+	return splitID, share, nil
+}
+
+// CreateSplit делит тело секрета на доли по схеме Шамира, так что любые params.Threshold долей восстанавливают его,
+// и сохраняет каждую долю отдельным секретом, адресованным её хранителю, который прочитает его только один раз.
+// Создателем секретов с долями остаётся тот, кто разделил секрет, и они занимают место в его ограничении
+// на число секретов, но ни прочитать, ни изменить их он не может, как и никто, кроме хранителя, поэтому ни одна запись
+// в репозитории и ни один читатель, кроме собравших порог хранителей, не узнает секрет.
+// Токены ссылок на секреты с долями не выдаются. Если тело пустое, хранителей меньше двух, порог меньше двух
+// или больше числа хранителей, возвращается model.ErrInvalidSplit, а если у создателя не хватает места на все доли -
+// model.ErrQuotaExceeded. Тело секрета проверяется ограничениями Limits и политикой содержимого
+// Validators до разделения, остальные ошибки те же, что у Create. Если сохранить какую-то долю не удалось,
+// уже сохранённые удаляются
+func (ss *SecretService) CreateSplit(ctx context.Context, params model.SplitParams) (model.Split, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.CreateSplit")
+	defer span.End()
+	holders := uniqueNonEmpty(params.Holders)
+	span.SetAttributes(attribute.Int("body_size", len(params.Body)))
+	span.SetAttributes(attribute.Int("threshold", params.Threshold))
+	span.SetAttributes(attribute.StringSlice("holders", holders))
+	if len(params.Body) == 0 {
+		err := fmt.Errorf("%w: body is empty", model.ErrInvalidSplit)
+		span.AddEvent("Split is rejected: " + err.Error())
+		return model.Split{}, err
+	}
+	if len(holders) < 2 || len(holders) > shamir.MaxShares || params.Threshold < 2 || params.Threshold > len(holders) {
+		err := fmt.Errorf("%w: threshold %v of %v holders", model.ErrInvalidSplit, params.Threshold, len(holders))
+		span.AddEvent("Split is rejected: " + err.Error())
+		return model.Split{}, err
+	}
+	err := ss.Limits.Check(params.Body, params.Meta)
+	if err == nil {
+		err = ss.checkOpaque(params.Body, params.Opaque)
+	}
+	if err != nil {
+		span.AddEvent("Split is rejected: " + err.Error())
+		return model.Split{}, err
+	}
+	_, err = validate(ctxWithTracing, ss.Validators, Candidate{
+		Body:        inspectable(params.Body, params.Opaque),
+		Meta:        params.Meta,
+		ContentType: params.ContentType,
+		Filename:    params.Filename,
+		Owner:       params.Owner,
+	})
+	if err != nil {
+		span.AddEvent("Split is rejected: " + err.Error())
+		return model.Split{}, err
+	}
+	err = ss.checkQuota(ctxWithTracing, params.Owner, int64(len(holders)))
+	if err != nil {
+		if errors.Is(err, model.ErrQuotaExceeded) {
+			span.AddEvent("Split is rejected: " + err.Error())
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.Split{}, err
+	}
+	// к телу добавляется его хэш, чтобы при восстановлении отличить секрет от мусора,
+	// который получается из чужих долей или из долей меньше порога
+	checksum := sha256.Sum256(params.Body)
+	shares, err := shamir.Split(append(append([]byte{}, params.Body...), checksum[:]...), len(holders), params.Threshold)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		return model.Split{}, err
+	}
+	split := model.Split{
+		ID:        misc.UUID(),
+		Threshold: params.Threshold,
+		Shares:    make([]model.SplitShare, 0, len(holders)),
+	}
+	span.SetAttributes(attribute.String("split_id", split.ID))
+	for i := range holders {
+		meta := make(map[string]string, len(params.Meta)+4)
+		for k := range params.Meta {
+			meta[k] = params.Meta[k]
+		}
+		meta["Subject"] = holders[i]
+		meta[SplitIDKey] = split.ID
+		meta[SplitThresholdKey] = strconv.Itoa(params.Threshold)
+		meta[SplitSharesKey] = strconv.Itoa(len(holders))
+		meta[SplitCreatorKey] = params.Owner
+		secret, cErr := ss.Create(ctxWithTracing, model.SecretParams{
+			Body:        []byte(encodeShare(split.ID, shares[i])),
+			Meta:        meta,
+			ContentType: "text/plain; charset=utf-8",
+			Owner:       params.Owner,
+			Recipients:  []string{holders[i]},
+			TTL:         params.TTL,
+			ExpireAt:    params.ExpireAt,
+			NotBefore:   params.NotBefore,
+			MaxViews:    1,
+			Passphrase:  params.Passphrase,
+			CallbackURL: params.CallbackURL,
+		})
+		if cErr != nil {
+			ss.dropSplit(ctxWithTracing, split, params.Owner)
+			span.AddEvent("Split is not created: " + cErr.Error())
+			return model.Split{}, cErr
+		}
+		split.Shares = append(split.Shares, model.SplitShare{
+			Holder:   holders[i],
+			SecretID: secret.ID,
+		})
+	}
+	span.AddEvent("Split is created")
+	return split, nil
+}
+
+// dropSplit удаляет уже сохранённые секреты с долями от имени создателя owner, если разделить секрет не удалось
+func (ss *SecretService) dropSplit(ctx context.Context, split model.Split, owner string) {
+	for i := range split.Shares {
+		ss.discard(ctx, split.Shares[i].SecretID, owner, "split "+split.ID+" is not created")
+	}
+}
+
+// isShare возвращает истину, если в секрете хранится доля разделённого секрета
+func isShare(secret model.Secret) bool {
+	return secret.Meta[SplitIDKey] != ""
+}
+
+// Combine восстанавливает секрет из присланных текстов долей, которые хранители прочитали из своих секретов.
+// Если доли испорчены, относятся к разным секретам или их меньше порога, возвращается model.ErrInvalidShares.
+// Восстановление записывается в журнал аудита под идентификатором разделённого секрета
+func (ss *SecretService) Combine(ctx context.Context, texts []string, identity model.Identity) ([]byte, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Combine")
+	defer span.End()
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	span.SetAttributes(attribute.Int("shares", len(texts)))
+	var splitID string
+	shares := make([][]byte, 0, len(texts))
+	for i := range texts {
+		id, share, err := decodeShare(texts[i])
+		if err != nil {
+			span.AddEvent("Shares are rejected: " + err.Error())
+			return nil, err
+		}
+		if splitID != "" && id != splitID {
+			err = fmt.Errorf("%w: shares belong to different secrets", model.ErrInvalidShares)
+			span.AddEvent("Shares are rejected: " + err.Error())
+			return nil, err
+		}
+		splitID = id
+		shares = append(shares, share)
+	}
+	span.SetAttributes(attribute.String("split_id", splitID))
+	payload, err := shamir.Combine(shares)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", model.ErrInvalidShares, err)
+		span.AddEvent("Shares are rejected: " + err.Error())
+		return nil, err
+	}
+	if len(payload) < sha256.Size {
+		err = fmt.Errorf("%w: shares are too short", model.ErrInvalidShares)
+		span.AddEvent("Shares are rejected: " + err.Error())
+		return nil, err
+	}
+	body, checksum := payload[:len(payload)-sha256.Size], payload[len(payload)-sha256.Size:]
+	expected := sha256.Sum256(body)
+	if !bytes.Equal(checksum, expected[:]) {
+		err = fmt.Errorf("%w: checksum mismatch", model.ErrInvalidShares)
+		span.AddEvent("Shares are rejected: " + err.Error())
+		ss.audit(ctxWithTracing, splitID, model.AuditDenied, identity.Subject, err.Error())
+		return nil, err
+	}
+	span.AddEvent("Secret is reconstructed")
+	ss.audit(ctxWithTracing, splitID, model.AuditRead, identity.Subject,
+		fmt.Sprintf("reconstructed from %v shares", len(shares)))
+	return body, nil
+}
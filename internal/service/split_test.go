@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSecretService_Split(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service4split"),
+		Repo:   &repo,
+	}
+	params := model.SplitParams{
+		SecretParams: model.SecretParams{
+			Body:       []byte("root password"),
+			Owner:      "alice",
+			Recipients: []string{"mallory"},
+			TTL:        10 * time.Minute,
+		},
+		Holders:   []string{"bob", "carol", "dave"},
+		Threshold: 2,
+	}
+	split, err := ss.CreateSplit(ctx, params)
+	if err != nil {
+		t.Fatalf("error splitting secret: %s", err)
+	}
+	if !assert.Len(t, split.Shares, 3) {
+		return
+	}
+	assert.Equal(t, 2, split.Threshold)
+
+	texts := make([]string, 0, len(split.Shares))
+	for i := range split.Shares {
+		id := split.Shares[i].SecretID
+		peeked, err := repo.Peek(ctx, id)
+		if err != nil {
+			t.Fatalf("error peeking share: %s", err)
+		}
+		assert.NotContains(t, string(peeked.Body), "root password", "share discloses secret")
+		assert.Equal(t, split.ID, peeked.Meta[SplitIDKey])
+		assert.Equal(t, "alice", peeked.Meta[SplitCreatorKey])
+		for _, stranger := range []string{"alice", "mallory", split.Shares[(i+1)%3].Holder} {
+			_, err = ss.FindByID(ctx, id, ReadOptions{Identity: model.Identity{Subject: stranger}})
+			assert.True(t, errors.Is(err, model.ErrForbidden), "share is readable by %s: %v", stranger, err)
+		}
+		holder := model.Identity{Subject: split.Shares[i].Holder}
+		share, err := ss.FindByID(ctx, id, ReadOptions{Identity: holder})
+		if err != nil {
+			t.Fatalf("error reading share: %s", err)
+		}
+		_, err = ss.FindByID(ctx, id, ReadOptions{Identity: holder})
+		assert.True(t, errors.Is(err, model.ErrSecretNotFound), "share is read twice %v", err)
+		texts = append(texts, string(share.Body))
+	}
+
+	operator := model.Identity{Subject: "operator"}
+	body, err := ss.Combine(ctx, texts[1:], operator)
+	if err != nil {
+		t.Fatalf("error combining shares: %s", err)
+	}
+	assert.Equal(t, "root password", string(body))
+	_, err = ss.Combine(ctx, texts[:1], operator)
+	assert.True(t, errors.Is(err, model.ErrInvalidShares), "secret is reconstructed from one share %v", err)
+
+	other, err := ss.CreateSplit(ctx, model.SplitParams{
+		SecretParams: model.SecretParams{Body: []byte("other password"), Owner: "alice"},
+		Holders:      []string{"bob", "carol"},
+		Threshold:    2,
+	})
+	if err != nil {
+		t.Fatalf("error splitting other secret: %s", err)
+	}
+	otherShare, err := ss.FindByID(ctx, other.Shares[0].SecretID, ReadOptions{Identity: model.Identity{Subject: "bob"}})
+	if err != nil {
+		t.Fatalf("error reading other share: %s", err)
+	}
+	_, err = ss.Combine(ctx, []string{texts[0], string(otherShare.Body)}, operator)
+	assert.True(t, errors.Is(err, model.ErrInvalidShares), "shares of different secrets are combined %v", err)
+	tampered := []byte(texts[1])
+	tampered[len(tampered)-1] ^= 1
+	_, err = ss.Combine(ctx, []string{texts[0], string(tampered)}, operator)
+	assert.True(t, errors.Is(err, model.ErrInvalidShares), "tampered share is accepted %v", err)
+	_, err = ss.Combine(ctx, []string{"garbage", texts[0]}, operator)
+	assert.True(t, errors.Is(err, model.ErrInvalidShares), "malformed share is accepted %v", err)
+
+	for _, invalid := range []model.SplitParams{
+		{SecretParams: params.SecretParams, Holders: []string{"bob"}, Threshold: 1},
+		{SecretParams: params.SecretParams, Holders: []string{"bob", "carol"}, Threshold: 3},
+		{SecretParams: params.SecretParams, Holders: []string{"bob", "bob", ""}, Threshold: 2},
+		{SecretParams: model.SecretParams{Owner: "alice"}, Holders: []string{"bob", "carol"}, Threshold: 2},
+	} {
+		_, err = ss.CreateSplit(ctx, invalid)
+		assert.True(t, errors.Is(err, model.ErrInvalidSplit), "wrong error %v for %v", err, invalid.Holders)
+	}
+}
+
+func TestSecretService_SplitQuota(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service4split"),
+		Repo:   &repo,
+		Limits: Limits{MaxSecretsPerSubject: 3},
+	}
+	// у хранителя уже нет места на новые секреты, но доли занимают место в ограничении того, кто разделил секрет
+	for i := 0; i < 3; i++ {
+		_, err = ss.Create(ctx, model.SecretParams{Body: []byte("own secret of bob"), Owner: "bob"})
+		if err != nil {
+			t.Fatalf("error creating secret: %s", err)
+		}
+	}
+	params := model.SplitParams{
+		SecretParams: model.SecretParams{Body: []byte("root password"), Owner: "alice"},
+		Holders:      []string{"bob", "carol"},
+		Threshold:    2,
+	}
+	split, err := ss.CreateSplit(ctx, params)
+	if err != nil {
+		t.Fatalf("error splitting secret: %s", err)
+	}
+	count, err := repo.CountByOwner(ctx, "alice")
+	if err != nil {
+		t.Fatalf("error counting secrets: %s", err)
+	}
+	assert.Equal(t, int64(2), count, "shares are not charged to creator")
+	count, err = repo.CountByOwner(ctx, "bob")
+	if err != nil {
+		t.Fatalf("error counting secrets: %s", err)
+	}
+	assert.Equal(t, int64(3), count, "share is charged to holder")
+
+	// ни создатель, ни хранитель не распоряжаются долей как её создатель
+	for _, subject := range []string{"alice", "bob"} {
+		_, err = ss.Update(ctx, split.Shares[0].SecretID, UpdateParams{
+			Body:     []byte("forged share"),
+			Identity: model.Identity{Subject: subject},
+		})
+		assert.True(t, errors.Is(err, model.ErrForbidden), "share is updated by %s: %v", subject, err)
+		_, err = ss.Status(ctx, split.Shares[0].SecretID, model.Identity{Subject: subject})
+		assert.True(t, errors.Is(err, model.ErrForbidden), "status of share is shown to %s: %v", subject, err)
+	}
+
+	_, err = ss.CreateSplit(ctx, params)
+	assert.True(t, errors.Is(err, model.ErrQuotaExceeded), "wrong error %v", err)
+	count, err = repo.CountByOwner(ctx, "alice")
+	if err != nil {
+		t.Fatalf("error counting secrets: %s", err)
+	}
+	assert.Equal(t, int64(2), count, "shares of rejected split are saved")
+}
+
+// failingSecretRepo сохраняет только left секретов, а остальные отклоняет ошибкой
+type failingSecretRepo struct {
+	repository.SecretRepo
+	left int
+}
+
+func (r *failingSecretRepo) Create(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	return r.CreateWithinQuota(ctx, secret, 0)
+}
+
+func (r *failingSecretRepo) CreateWithinQuota(ctx context.Context, secret model.Secret, maxSecrets int64) (model.Secret, error) {
+	if r.left == 0 {
+		return model.Secret{}, errors.New("repo is broken")
+	}
+	r.left--
+	if maxSecrets == 0 {
+		return r.SecretRepo.Create(ctx, secret)
+	}
+	return r.SecretRepo.CreateWithinQuota(ctx, secret, maxSecrets)
+}
+
+// assertDiscarded проверяет, что созданный и удалённый откатом секрет id не оставил подписки на события
+// и записи в журнале аудита о создании без записи об удалении
+func assertDiscarded(t *testing.T, ss *SecretService, repo *memory.Repository, id, reader string) {
+	ctx := context.Background()
+	_, err := ss.FindByID(ctx, id, ReadOptions{Identity: model.Identity{Subject: reader}})
+	assertGone(t, err, model.TombstoneDeleted)
+	_, err = repo.FindSubscription(ctx, id)
+	assert.True(t, errors.Is(err, model.ErrSubscriptionNotFound), "subscription of discarded secret is left: %v", err)
+	events, _, err := ss.Audit.Query(ctx, model.Identity{Subject: "root", Groups: []string{DefaultAdminGroup}},
+		repository.AuditFilter{SecretID: id})
+	if err != nil {
+		t.Fatalf("error querying audit trail: %s", err)
+	}
+	if assert.Len(t, events, 2, "wrong audit trail of discarded secret") {
+		assert.Equal(t, model.AuditCreate, events[0].Action)
+		assert.Equal(t, model.AuditDelete, events[1].Action)
+	}
+}
+
+// assertDiscardedAll проверяет, что все created созданных секретов удалены откатом
+func assertDiscardedAll(t *testing.T, ss *SecretService, repo *memory.Repository, created int, reader string) {
+	events, _, err := ss.Audit.Query(context.Background(), model.Identity{Subject: "root", Groups: []string{DefaultAdminGroup}},
+		repository.AuditFilter{})
+	if err != nil {
+		t.Fatalf("error querying audit trail: %s", err)
+	}
+	ids := make([]string, 0)
+	for i := range events {
+		if events[i].Action == model.AuditCreate {
+			ids = append(ids, events[i].SecretID)
+		}
+	}
+	if assert.Len(t, ids, created, "wrong number of created secrets") {
+		for i := range ids {
+			assertDiscarded(t, ss, repo, ids[i], reader)
+		}
+	}
+}
+
+func TestSecretService_SplitRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer:     otel.Tracer("unit_test_service4split"),
+		Repo:       &failingSecretRepo{SecretRepo: &repo, left: 1},
+		Audit:      &AuditService{Tracer: otel.Tracer("unit_test_service4split"), Repo: &repo},
+		Tombstones: &TombstoneService{Repo: &repo, Retention: time.Hour},
+		Webhooks: &WebhookNotifier{
+			Repo:            &repo,
+			Secret:          []byte("webhook secret"),
+			AllowedNetworks: mustParseNetworks("127.0.0.0/8"),
+		},
+	}
+	_, err = ss.CreateSplit(ctx, model.SplitParams{
+		SecretParams: model.SecretParams{
+			Body:        []byte("root password"),
+			Owner:       "alice",
+			CallbackURL: "http://127.0.0.1/hook",
+		},
+		Holders:   []string{"bob", "carol"},
+		Threshold: 2,
+	})
+	assert.Error(t, err, "split is created with broken repo")
+	assertDiscardedAll(t, &ss, &repo, 1, "bob")
+}
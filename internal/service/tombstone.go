@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TombstoneService хранит записи о том, почему и когда исчезли секреты, чтобы отличать прочитанный, удалённый
+// или устаревший секрет от никогда не существовавшего. Тел и метаданных секретов в записях нет
+type TombstoneService struct {
+	Repo repository.TombstoneRepo
+	// Retention задаёт, сколько хранится запись об исчезнувшем секрете, если не задано - model.TombstoneRetention
+	Retention time.Duration
+}
+
+// Bury сохраняет запись о том, что секрет исчез по причине reason.
+// Ошибки отмечаются в span, но не мешают операции с секретом
+func (ts *TombstoneService) Bury(ctx context.Context, id string, reason model.TombstoneReason) {
+	if ts == nil {
+		return
+	}
+	retention := ts.Retention
+	if retention <= 0 {
+		retention = model.TombstoneRetention
+	}
+	now := time.Now()
+	err := ts.Repo.SaveTombstone(ctx, model.Tombstone{
+		SecretID:  id,
+		Reason:    reason,
+		DeletedAt: now,
+		ExpireAt:  now.Add(retention),
+	})
+	ts.traceError(ctx, err)
+}
+
+// Explain заменяет ошибку model.ErrSecretNotFound на *model.GoneError, если о секрете есть запись,
+// а остальные ошибки возвращает как есть
+func (ts *TombstoneService) Explain(ctx context.Context, id string, err error) error {
+	if ts == nil || !errors.Is(err, model.ErrSecretNotFound) || errors.Is(err, model.ErrSecretGone) {
+		return err
+	}
+	tombstone, tErr := ts.Repo.FindTombstone(ctx, id)
+	if tErr != nil {
+		if !errors.Is(tErr, model.ErrSecretNotFound) {
+			ts.traceError(ctx, tErr)
+		}
+		return err
+	}
+	trace.SpanFromContext(ctx).AddEvent("Secret is gone: " + string(tombstone.Reason))
+	return &model.GoneError{Tombstone: tombstone}
+}
+
+// Prune удаляет устаревшие записи об исчезнувших секретах
+func (ts *TombstoneService) Prune(ctx context.Context) error {
+	if ts == nil {
+		return nil
+	}
+	return ts.Repo.PruneTombstones(ctx)
+}
+
+// traceError отмечает в span ошибку работы с записями об исчезнувших секретах
+func (ts *TombstoneService) traceError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("Tombstone is not processed: " + err.Error())
+	span.RecordError(err)
+}
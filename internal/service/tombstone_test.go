@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+)
+
+// assertGone проверяет, что секрет исчез по причине reason
+func assertGone(t *testing.T, err error, reason model.TombstoneReason) {
+	var gone *model.GoneError
+	if assert.True(t, errors.As(err, &gone), "wrong error %v", err) {
+		assert.Equal(t, reason, gone.Tombstone.Reason)
+		assert.WithinDuration(t, time.Now(), gone.Tombstone.DeletedAt, time.Minute)
+		assert.True(t, errors.Is(err, model.ErrSecretGone))
+		assert.True(t, errors.Is(err, model.ErrSecretNotFound), "gone secret is not treated as not found")
+	}
+}
+
+func TestSecretService_Tombstones(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer:     otel.Tracer("unit_test_service"),
+		Repo:       repo,
+		Tombstones: &TombstoneService{Repo: repo, Retention: time.Hour},
+	}
+	alice := model.Identity{Subject: "alice"}
+
+	_, err = ss.FindByID(ctx, misc.UUID(), ReadOptions{Identity: alice})
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error %v", err)
+	assert.False(t, errors.Is(err, model.ErrSecretGone), "unknown secret is gone")
+
+	burned, err := ss.Create(ctx, model.SecretParams{Body: []byte("burned"), Owner: "alice"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, burned.ID, ReadOptions{Identity: alice, Burn: true})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, burned.ID, ReadOptions{Identity: alice})
+	assertGone(t, err, model.TombstoneRead)
+
+	viewed, err := ss.Create(ctx, model.SecretParams{Body: []byte("viewed"), Owner: "alice", MaxViews: 1})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, viewed.ID, ReadOptions{Identity: alice})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+	_, err = ss.Status(ctx, viewed.ID, alice)
+	assertGone(t, err, model.TombstoneRead)
+
+	deleted, err := ss.Create(ctx, model.SecretParams{Body: []byte("deleted"), Owner: "alice"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	err = ss.DeleteByID(ctx, deleted.ID, alice)
+	if err != nil {
+		t.Fatalf("error deleting secret: %s", err)
+	}
+	err = ss.DeleteByID(ctx, deleted.ID, alice)
+	assertGone(t, err, model.TombstoneDeleted)
+
+	protected, err := ss.Create(ctx, model.SecretParams{Body: []byte("protected"), Owner: "alice", Passphrase: "right"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	for i := int64(0); i < model.MaxPassphraseAttempts; i++ {
+		_, err = ss.FindByID(ctx, protected.ID, ReadOptions{Identity: alice, Passphrase: "wrong"})
+		assert.True(t, errors.Is(err, model.ErrWrongPassphrase), "wrong error %v", err)
+	}
+	_, err = ss.FindByID(ctx, protected.ID, ReadOptions{Identity: alice, Passphrase: "right"})
+	assertGone(t, err, model.TombstoneDestroyed)
+
+	expired, err := repo.Create(ctx, model.Secret{
+		Body:      []byte("expired"),
+		Owner:     "alice",
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpireAt:  time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error creating expired secret: %s", err)
+	}
+	err = ss.Prune(ctx)
+	if err != nil {
+		t.Fatalf("error pruning secrets: %s", err)
+	}
+	_, err = ss.FindByID(ctx, expired.ID, ReadOptions{Identity: alice})
+	assertGone(t, err, model.TombstoneExpired)
+
+	// без записей об исчезнувших секретах они не отличаются от несуществующих
+	ss.Tombstones = nil
+	_, err = ss.FindByID(ctx, burned.ID, ReadOptions{Identity: alice})
+	assert.True(t, errors.Is(err, model.ErrSecretNotFound), "wrong error %v", err)
+	assert.False(t, errors.Is(err, model.ErrSecretGone), "tombstone is used when disabled")
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// TTLPolicy задаёт серверные ограничения на срок жизни секретов. Незаданные поля заменяются
+// значениями по умолчанию из пакета model
+type TTLPolicy struct {
+	// Default - срок жизни секрета, если создатель его не указал
+	Default time.Duration
+	// Min - минимально допустимый срок жизни секрета
+	Min time.Duration
+	// Max - максимально допустимый срок жизни секрета
+	Max time.Duration
+}
+
+// Clamp вычисляет срок жизни секрета по запрошенным параметрам, укладывая его в границы политики
+func (p TTLPolicy) Clamp(now time.Time, ttl time.Duration, expireAt time.Time) time.Duration {
+	def, lower, upper := p.Default, p.Min, p.Max
+	if def <= 0 {
+		def = model.TTL
+	}
+	if lower <= 0 {
+		lower = model.MinTTL
+	}
+	if upper <= 0 {
+		upper = model.MaxTTL
+	}
+	if !expireAt.IsZero() {
+		ttl = expireAt.Sub(now)
+		if ttl <= 0 { // момент устаревания в прошлом - отдаём минимально возможный срок
+			return lower
+		}
+	}
+	if ttl <= 0 {
+		ttl = def
+	}
+	if ttl < lower {
+		return lower
+	}
+	if ttl > upper {
+		return upper
+	}
+	return ttl
+}
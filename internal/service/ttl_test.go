@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+func TestTTLPolicy_Clamp(t *testing.T) {
+	now := time.Now()
+	policy := TTLPolicy{
+		Default: time.Hour,
+		Min:     5 * time.Minute,
+		Max:     7 * 24 * time.Hour,
+	}
+	testCases := []struct {
+		name     string
+		policy   TTLPolicy
+		ttl      time.Duration
+		expireAt time.Time
+		expected time.Duration
+	}{
+		{"default", policy, 0, time.Time{}, time.Hour},
+		{"as requested", policy, 10 * time.Minute, time.Time{}, 10 * time.Minute},
+		{"too short", policy, time.Second, time.Time{}, 5 * time.Minute},
+		{"too long", policy, 30 * 24 * time.Hour, time.Time{}, 7 * 24 * time.Hour},
+		{"absolute expiry", policy, 0, now.Add(2 * time.Hour), 2 * time.Hour},
+		{"absolute expiry wins", policy, time.Minute, now.Add(2 * time.Hour), 2 * time.Hour},
+		{"absolute expiry in past", policy, 0, now.Add(-time.Hour), 5 * time.Minute},
+		{"empty policy", TTLPolicy{}, 0, time.Time{}, model.TTL},
+		{"empty policy with too long ttl", TTLPolicy{}, 365 * 24 * time.Hour, time.Time{}, model.MaxTTL},
+	}
+	for _, tc := range testCases {
+		got := tc.policy.Clamp(now, tc.ttl, tc.expireAt)
+		if got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, got)
+		}
+	}
+}
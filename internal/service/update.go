@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// UpdateParams задаёт изменения секрета
+type UpdateParams struct {
+	// Body - новое тело секрета, nil - оставить прежнее
+	Body []byte
+	// ContentType и Filename - MIME тип и имя файла нового тела, учитываются, только если тело заменяется.
+	// Если ContentType не задан, он определяется по содержимому
+	ContentType string
+	Filename    string
+	// Meta - поля метаданных, которые добавляются к прежним или заменяют одноимённые
+	Meta map[string]string
+	// ReplaceMeta - заменить метаданные целиком, а не дополнить
+	ReplaceMeta bool
+	// TTL - новый срок жизни секрета, отсчитываемый от момента изменения, укладывается в ограничения TTL сервиса
+	TTL time.Duration
+	// ExpireAt - новый момент устаревания секрета, имеет приоритет перед TTL. Если оба не заданы, срок жизни не меняется
+	ExpireAt time.Time
+	// Version - версия секрета, которую ожидается изменить, 0 - текущая
+	Version int64
+	// Identity - субъект JWT токена и его группы
+	Identity model.Identity
+}
+
+// authorizeOwner проверяет, что субъект - создатель секрета. Секреты, у которых создатель неизвестен,
+// и секреты с долями разделённого секрета не доступны никому
+func authorizeOwner(secret model.Secret, identity model.Identity) error {
+	if secret.Owner != "" && secret.Owner == identity.Subject && !isShare(secret) {
+		return nil
+	}
+	return model.ErrForbidden
+}
+
+// Update изменяет тело, метаданные или срок жизни секрета и возвращает его метаданные с новой версией.
+// Изменить секрет может только его создатель, адресаты могут его только читать. Прочтение при этом не засчитывается.
+// Если секрет был изменён кем-то ещё, возвращается model.ErrVersionConflict, а если новый секрет
+// превышает ограничения Limits - model.ErrTooLarge, а если нарушает политику содержимого - *model.ValidationError
+func (ss *SecretService) Update(ctx context.Context, id string, params UpdateParams) (model.Secret, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Update")
+	defer span.End()
+	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.String("subject", params.Identity.Subject))
+	span.SetAttributes(attribute.Int64("version", params.Version))
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorizeOwner(secret, params.Identity)
+	}
+	if err == nil && params.Version != 0 && params.Version != secret.Version {
+		err = model.ErrVersionConflict
+	}
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			span.AddEvent("Secret not found")
+		} else if errors.Is(err, model.ErrForbidden) || errors.Is(err, model.ErrVersionConflict) {
+			span.AddEvent("Update denied: " + err.Error())
+			ss.auditDenied(ctxWithTracing, id, params.Identity.Subject, err)
+		} else { // unexpected error
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.Secret{}, err
+	}
+	version := secret.Version
+	if params.Body != nil {
+		secret.Body = params.Body
+		secret.ContentType = params.ContentType
+		if secret.ContentType == "" {
+			secret.ContentType = detectContentType(params.Body, secret.Opaque)
+		}
+		secret.Filename = cleanFilename(params.Filename)
+		span.AddEvent("Body is replaced")
+	}
+	// метаданные собираются в новый словарь, чтобы не менять словарь, который мог вернуть репозиторий
+	meta := make(map[string]string, len(secret.Meta)+len(params.Meta))
+	if !params.ReplaceMeta {
+		for k := range secret.Meta {
+			meta[k] = secret.Meta[k]
+		}
+	}
+	for k := range params.Meta {
+		meta[k] = params.Meta[k]
+	}
+	secret.Meta = meta
+	err = ss.Limits.Check(secret.Body, secret.Meta)
+	if err == nil && params.Body != nil {
+		err = ss.checkOpaque(secret.Body, secret.Opaque)
+	}
+	if err != nil {
+		span.AddEvent("Update is rejected: " + err.Error())
+		return model.Secret{}, err
+	}
+	// изменённый секрет проверяется политикой содержимого заново, прежние отметки о нарушениях снимаются
+	delete(secret.Meta, PolicyFlagsKey)
+	flagged, err := validate(ctxWithTracing, ss.Validators, Candidate{
+		Body:        inspectable(secret.Body, secret.Opaque),
+		Meta:        secret.Meta,
+		ContentType: secret.ContentType,
+		Filename:    secret.Filename,
+		Owner:       secret.Owner,
+	})
+	if len(flagged) > 0 {
+		secret.Meta[PolicyFlagsKey] = strings.Join(flagged, ",")
+		span.SetAttributes(attribute.StringSlice("policy_flags", flagged))
+	}
+	if err != nil {
+		span.AddEvent("Update is rejected: " + err.Error())
+		return model.Secret{}, err
+	}
+	if params.TTL > 0 || !params.ExpireAt.IsZero() {
+		now := time.Now()
+		ttl := ss.TTL.Clamp(now, params.TTL, params.ExpireAt)
+		secret.ExpireAt = now.Add(ttl)
+		span.SetAttributes(attribute.String("ttl", ttl.String()))
+	}
+	updated, err := ss.Repo.Update(ctxWithTracing, secret, version, ss.keepVersions())
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			span.AddEvent("Secret not found")
+		} else if errors.Is(err, model.ErrVersionConflict) {
+			span.AddEvent("Secret is updated concurrently")
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		return model.Secret{}, err
+	}
+	span.AddEvent("Secret is updated")
+	span.SetAttributes(attribute.Int64("new_version", updated.Version))
+	if params.TTL > 0 || !params.ExpireAt.IsZero() {
+		ss.Webhooks.Extend(ctxWithTracing, updated)
+	}
+	ss.audit(ctxWithTracing, id, model.AuditUpdate, params.Identity.Subject, fmt.Sprintf("version %v", updated.Version))
+	return metadataOnly(updated), nil
+}
+
+// keepVersions возвращает, сколько прежних версий секрета хранить
+func (ss *SecretService) keepVersions() int64 {
+	if ss.KeepVersions <= 0 {
+		return model.KeepVersions
+	}
+	return ss.KeepVersions
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vodolaz095/purser/model"
+)
+
+// PolicyFlagsKey задаёт поле метаданных, в котором через запятую перечисляются правила политики содержимого,
+// нарушение которых отмечено, но не помешало сохранить секрет
+const PolicyFlagsKey = "Policy-Flags"
+
+// PolicyAction задаёт, что делать с секретом, нарушившим правило политики содержимого
+type PolicyAction string
+
+const (
+	// PolicyReject - отклонить секрет
+	PolicyReject PolicyAction = "reject"
+	// PolicyFlag - сохранить секрет, отметив нарушение в метаданных
+	PolicyFlag PolicyAction = "flag"
+)
+
+// Candidate - секрет, который проверяется политикой содержимого перед сохранением
+type Candidate struct {
+	// Body - тело секрета, nil, если тело зашифровано клиентом и проверить его нельзя
+	Body        []byte
+	Meta        map[string]string
+	ContentType string
+	Filename    string
+	Owner       string
+}
+
+// Detector находит в секрете нарушение правила политики содержимого
+type Detector interface {
+	// Name возвращает название правила
+	Name() string
+	// Detect возвращает описание нарушения или пустую строку, если нарушения нет.
+	// Описание попадает в ответы и журналы, поэтому в нём не должно быть содержимого секрета
+	Detect(ctx context.Context, candidate Candidate) string
+}
+
+// Validator - правило политики содержимого, проверяемое перед сохранением секрета
+type Validator struct {
+	Detector Detector
+	Action   PolicyAction
+}
+
+// validate проверяет секрет всеми правилами политики содержимого. Если нарушены правила с действием PolicyReject,
+// возвращается *model.ValidationError со всеми такими нарушениями, иначе - названия нарушенных правил с действием PolicyFlag
+func validate(ctx context.Context, validators []Validator, candidate Candidate) (flagged []string, err error) {
+	rejected := make([]model.Violation, 0)
+	flagged = make([]string, 0)
+	for i := range validators {
+		message := validators[i].Detector.Detect(ctx, candidate)
+		if message == "" {
+			continue
+		}
+		if validators[i].Action == PolicyFlag {
+			flagged = append(flagged, validators[i].Detector.Name())
+			continue
+		}
+		rejected = append(rejected, model.Violation{Rule: validators[i].Detector.Name(), Message: message})
+	}
+	if len(rejected) > 0 {
+		return flagged, &model.ValidationError{Violations: rejected}
+	}
+	return flagged, nil
+}
+
+// ParseContentPolicy собирает правила политики содержимого из описания вида "private_key=reject,card_number=flag".
+// Доступны правила private_key, card_number и client, последнему нужно регулярное выражение deniedClients
+// для запрещённых значений заголовка User-Agent. Пустое описание означает, что политики нет
+func ParseContentPolicy(spec, deniedClients string) ([]Validator, error) {
+	ret := make([]Validator, 0)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, action, found := strings.Cut(item, "=")
+		if !found {
+			action = string(PolicyReject)
+		}
+		validator := Validator{Action: PolicyAction(strings.TrimSpace(action))}
+		if validator.Action != PolicyReject && validator.Action != PolicyFlag {
+			return nil, fmt.Errorf("unknown action %q for rule %s, should be %s or %s", action, name, PolicyReject, PolicyFlag)
+		}
+		switch strings.TrimSpace(name) {
+		case PrivateKeyDetector{}.Name():
+			validator.Detector = PrivateKeyDetector{}
+		case CardNumberDetector{}.Name():
+			validator.Detector = CardNumberDetector{}
+		case ClientDetector{}.Name():
+			if deniedClients == "" {
+				return nil, fmt.Errorf("rule %s requires expression for denied clients", name)
+			}
+			denied, err := regexp.Compile(deniedClients)
+			if err != nil {
+				return nil, fmt.Errorf("malformed expression for denied clients: %w", err)
+			}
+			validator.Detector = ClientDetector{Denied: denied}
+		default:
+			return nil, fmt.Errorf("unknown content policy rule %s", name)
+		}
+		ret = append(ret, validator)
+	}
+	return ret, nil
+}
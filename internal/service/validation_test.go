@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+)
+
+// openSSHKey делает закрытый ключ OpenSSH в формате PEM, зашифрованный шифром cipher
+func openSSHKey(cipher string) string {
+	raw := append([]byte("openssh-key-v1\x00"), 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(raw[len(raw)-4:], uint32(len(cipher)))
+	raw = append(raw, cipher...)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: raw}))
+}
+
+func TestDetectors(t *testing.T) {
+	rsaKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("not really a key")}))
+	testCases := []struct {
+		name     string
+		detector Detector
+		body     string
+		meta     map[string]string
+		detected bool
+	}{
+		{"plain text", PrivateKeyDetector{}, "just a password", nil, false},
+		{"unencrypted rsa key", PrivateKeyDetector{}, "my key:\n" + rsaKey, nil, true},
+		{"legacy encrypted rsa key", PrivateKeyDetector{}, string(pem.EncodeToMemory(&pem.Block{
+			Type:    "RSA PRIVATE KEY",
+			Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-256-CBC,00"},
+			Bytes:   []byte("not really a key"),
+		})), nil, false},
+		{"encrypted pkcs8 key", PrivateKeyDetector{}, string(pem.EncodeToMemory(&pem.Block{
+			Type:  "ENCRYPTED PRIVATE KEY",
+			Bytes: []byte("not really a key"),
+		})), nil, false},
+		{"certificate", PrivateKeyDetector{}, string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: []byte("not really a certificate"),
+		})), nil, false},
+		{"certificate with key", PrivateKeyDetector{}, string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: []byte("not really a certificate"),
+		})) + rsaKey, nil, true},
+		{"unencrypted openssh key", PrivateKeyDetector{}, openSSHKey("none"), nil, true},
+		{"encrypted openssh key", PrivateKeyDetector{}, openSSHKey("aes256-ctr"), nil, false},
+		{"card number", CardNumberDetector{}, "card 4111111111111111 exp 12/30", nil, true},
+		{"card number with spaces", CardNumberDetector{}, "4111 1111 1111 1111", nil, true},
+		{"card number with dashes", CardNumberDetector{}, "5500-0000-0000-0004", nil, true},
+		{"wrong check digit", CardNumberDetector{}, "4111111111111112", nil, false},
+		{"too short", CardNumberDetector{}, "411111111111", nil, false},
+		{"part of longer number", CardNumberDetector{}, "94111111111111111111", nil, false},
+		{"phone number", CardNumberDetector{}, "+7 (999) 123-45-67", nil, false},
+		{"denied client", ClientDetector{Denied: regexp.MustCompile("^curl/")}, "", map[string]string{"User-Agent": "curl/8.0"}, true},
+		{"allowed client", ClientDetector{Denied: regexp.MustCompile("^curl/")}, "", map[string]string{"User-Agent": "purser/1.0"}, false},
+		{"no denied clients", ClientDetector{}, "", map[string]string{"User-Agent": "curl/8.0"}, false},
+	}
+	for _, tc := range testCases {
+		message := tc.detector.Detect(context.TODO(), Candidate{Body: []byte(tc.body), Meta: tc.meta})
+		if tc.detected != (message != "") {
+			t.Errorf("%s: unexpected result %q", tc.name, message)
+		}
+	}
+}
+
+func TestParseContentPolicy(t *testing.T) {
+	validators, err := ParseContentPolicy(" private_key=reject, card_number=flag,client", "^curl/")
+	if err != nil {
+		t.Fatalf("error parsing policy: %s", err)
+	}
+	if assert.Len(t, validators, 3) {
+		assert.Equal(t, "private_key", validators[0].Detector.Name())
+		assert.Equal(t, PolicyReject, validators[0].Action)
+		assert.Equal(t, "card_number", validators[1].Detector.Name())
+		assert.Equal(t, PolicyFlag, validators[1].Action)
+		assert.Equal(t, "client", validators[2].Detector.Name())
+		assert.Equal(t, PolicyReject, validators[2].Action)
+	}
+	validators, err = ParseContentPolicy("", "")
+	assert.NoError(t, err)
+	assert.Empty(t, validators)
+
+	for _, spec := range []string{"unknown=reject", "card_number=ignore", "client=reject"} {
+		_, err = ParseContentPolicy(spec, "")
+		assert.Error(t, err, "policy %s is accepted", spec)
+	}
+	_, err = ParseContentPolicy("client", "(")
+	assert.Error(t, err, "malformed denied clients expression is accepted")
+}
+
+func TestSecretService_ContentPolicy(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	validators, err := ParseContentPolicy("private_key=reject,card_number=flag,client=reject", "^curl/")
+	if err != nil {
+		t.Fatalf("error parsing policy: %s", err)
+	}
+	ss := SecretService{
+		Tracer:     otel.Tracer("unit_test_service"),
+		Repo:       repo,
+		Validators: validators,
+	}
+	rsaKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("not really a key")}))
+
+	_, err = ss.Create(ctx, model.SecretParams{
+		Body:  []byte(rsaKey + "card 4111111111111111"),
+		Meta:  map[string]string{"User-Agent": "curl/8.0"},
+		Owner: "alice",
+	})
+	var validationError *model.ValidationError
+	if assert.True(t, errors.As(err, &validationError), "wrong error %v", err) {
+		assert.True(t, errors.Is(err, model.ErrPolicyViolation))
+		assert.Equal(t, []string{"private_key", "client"}, []string{
+			validationError.Violations[0].Rule, validationError.Violations[1].Rule,
+		}, "wrong rules are violated")
+		assert.NotContains(t, err.Error(), "not really a key", "secret is leaked into error")
+	}
+
+	flagged, err := ss.Create(ctx, model.SecretParams{
+		Body:  []byte("card 4111111111111111"),
+		Meta:  map[string]string{PolicyFlagsKey: "spoofed"},
+		Owner: "alice",
+	})
+	if err != nil {
+		t.Fatalf("error creating flagged secret: %s", err)
+	}
+	assert.Equal(t, "card_number", flagged.Meta[PolicyFlagsKey], "violation is not flagged")
+
+	clean, err := ss.Create(ctx, model.SecretParams{
+		Body:  []byte("just a password"),
+		Meta:  map[string]string{PolicyFlagsKey: "spoofed"},
+		Owner: "alice",
+	})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, found := clean.Meta[PolicyFlagsKey]
+	assert.False(t, found, "flags are spoofed")
+
+	_, err = ss.Update(ctx, clean.ID, UpdateParams{
+		Body:     []byte(rsaKey),
+		Identity: model.Identity{Subject: "alice"},
+	})
+	assert.True(t, errors.As(err, &validationError), "policy is not checked on update: %v", err)
+}
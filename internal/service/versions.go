@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceVersionError записывает в span ошибку работы с версиями секрета, ожидаемые ошибки записываются как события
+func traceVersionError(span trace.Span, err error) {
+	switch {
+	case errors.Is(err, model.ErrSecretNotFound):
+		span.AddEvent("Secret or version not found")
+	case errors.Is(err, model.ErrForbidden), errors.Is(err, model.ErrVersionConflict),
+		errors.Is(err, model.ErrPassphraseRequired), errors.Is(err, model.ErrWrongPassphrase):
+		span.AddEvent("Access denied: " + err.Error())
+	default: // unexpected error
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+	}
+}
+
+// ListVersions возвращает прежние версии секрета без тел, начиная с самой новой.
+// Историю секрета видит только его создатель
+func (ss *SecretService) ListVersions(ctx context.Context, id string, identity model.Identity) ([]model.SecretVersion, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.ListVersions")
+	defer span.End()
+	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorizeOwner(secret, identity)
+	}
+	var versions []model.SecretVersion
+	if err == nil {
+		versions, err = ss.Repo.ListVersions(ctxWithTracing, id)
+	}
+	if err != nil {
+		traceVersionError(span, err)
+		return nil, err
+	}
+	for i := range versions {
+		versions[i].Body = nil
+		versions[i].KeyID = ""
+		versions[i].WrappedKey = nil
+	}
+	span.SetAttributes(attribute.Int("found", len(versions)))
+	return versions, nil
+}
+
+// GetVersion возвращает прежнюю версию секрета. Её может прочитать только создатель секрета,
+// предъявив кодовую фразу, если секрет ей защищён. Прочтение прежней версии не засчитывается
+func (ss *SecretService) GetVersion(ctx context.Context, id string, version int64, opts ReadOptions) (model.SecretVersion, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.GetVersion")
+	defer span.End()
+	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.Int64("version", version))
+	span.SetAttributes(attribute.String("subject", opts.Identity.Subject))
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorizeOwner(secret, opts.Identity)
+	}
+	if err == nil {
+		err = ss.checkPassphrase(ctxWithTracing, secret, opts.Passphrase)
+	}
+	var archived model.SecretVersion
+	if err == nil {
+		archived, err = ss.Repo.GetVersion(ctxWithTracing, id, version)
+	}
+	if err != nil {
+		traceVersionError(span, err)
+		ss.auditDenied(ctxWithTracing, id, opts.Identity.Subject, err)
+		return model.SecretVersion{}, err
+	}
+	archived.KeyID = ""
+	archived.WrappedKey = nil
+	span.AddEvent("Version is found")
+	ss.audit(ctxWithTracing, id, model.AuditRead, opts.Identity.Subject, fmt.Sprintf("version %v", version))
+	return archived, nil
+}
+
+// Rollback делает прежнюю версию секрета текущей: её тело и метаданные сохраняются как новая версия,
+// так что сам откат тоже попадает в историю. Срок жизни секрета не меняется.
+// Откатить секрет может только его создатель, current - ожидаемая текущая версия, 0 - любая
+func (ss *SecretService) Rollback(ctx context.Context, id string, version, current int64, identity model.Identity) (model.Secret, error) {
+	ctxWithTracing, span := ss.Tracer.Start(ctx, "service.Rollback")
+	defer span.End()
+	span.SetAttributes(attribute.String("secret_id", id))
+	span.SetAttributes(attribute.Int64("version", version))
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	secret, err := ss.Repo.Peek(ctxWithTracing, id)
+	if err == nil {
+		err = authorizeOwner(secret, identity)
+	}
+	var archived model.SecretVersion
+	if err == nil {
+		archived, err = ss.Repo.GetVersion(ctxWithTracing, id, version)
+	}
+	if err != nil {
+		traceVersionError(span, err)
+		return model.Secret{}, err
+	}
+	return ss.Update(ctxWithTracing, id, UpdateParams{
+		Body:        archived.Body,
+		ContentType: archived.ContentType,
+		Filename:    archived.Filename,
+		Meta:        archived.Meta,
+		ReplaceMeta: true,
+		Version:     current,
+		Identity:    identity,
+	})
+}
@@ -0,0 +1,530 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/model"
+	"github.com/vodolaz095/purser/pkg/misc"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WebhookSignatureHeader задаёт заголовок уведомления с подписью его тела вида sha256=<hex HMAC-SHA256>
+const WebhookSignatureHeader = "X-Purser-Signature"
+
+// DefaultWebhookAttempts задаёт, сколько раз по умолчанию пытаться доставить уведомление
+const DefaultWebhookAttempts = 5
+
+// DefaultWebhookBackoff задаёт паузу перед второй попыткой доставки по умолчанию, дальше пауза удваивается
+const DefaultWebhookBackoff = time.Second
+
+// DefaultWebhookWorkers задаёт, сколько уведомлений по умолчанию доставляется одновременно
+const DefaultWebhookWorkers = 8
+
+// DefaultWebhookTimeout задаёт, сколько по умолчанию ждать ответа на одну попытку доставки
+const DefaultWebhookTimeout = 10 * time.Second
+
+// webhookQueueSize задаёт, сколько уведомлений может ждать доставки
+const webhookQueueSize = 1024
+
+// reservedNetworks - служебные сети, которые не являются публичными, помимо loopback, частных и link-local адресов,
+// которые распознаёт пакет net
+var reservedNetworks = mustParseNetworks("0.0.0.0/8,100.64.0.0/10,192.0.0.0/24,198.18.0.0/15,240.0.0.0/4,64:ff9b::/96")
+
+// webhookEvent - уведомление в формате CloudEvents, https://cloudevents.io
+type webhookEvent struct {
+	SpecVersion     string                 `json:"specversion"`
+	ID              string                 `json:"id"`
+	Source          string                 `json:"source"`
+	Type            model.WebhookEventType `json:"type"`
+	Subject         string                 `json:"subject"`
+	Time            time.Time              `json:"time"`
+	DataContentType string                 `json:"datacontenttype"`
+	Data            webhookEventData       `json:"data"`
+}
+
+// webhookEventData - подробности события, тела секрета в них нет
+type webhookEventData struct {
+	SecretID string `json:"secretId"`
+	// Owner - создатель секрета
+	Owner string `json:"owner"`
+	// Actor - субъект, прочитавший или удаливший секрет, пустой для устаревания
+	Actor string `json:"actor"`
+}
+
+// webhookJob - попытка доставки уведомления
+type webhookJob struct {
+	delivery model.WebhookDelivery
+}
+
+// WebhookNotifier сообщает создателям секретов об их прочтении, удалении или устаревании без прочтения.
+// Уведомления подписываются HMAC-SHA256 и доставляются в фоне с повторами через экспоненциально растущие паузы,
+// а не доставленные за MaxAttempts попыток или до остановки сохраняются в репозиторий как недоставленные
+type WebhookNotifier struct {
+	Repo repository.WebhookRepo
+	// Secret - ключ, которым подписываются уведомления
+	Secret []byte
+	// Source - источник событий в уведомлениях, например, имя хоста
+	Source string
+	// Subjects задаёт адреса для уведомлений о секретах создателей, не указавших адрес при создании секрета
+	Subjects map[string]string
+	// AllowedNetworks задаёт сети, в которые можно доставлять уведомления, хотя их адреса не публичные,
+	// например, сеть с внутренними получателями уведомлений. Адреса из остальных не публичных сетей запрещены
+	AllowedNetworks []*net.IPNet
+	// Client - HTTP клиент для доставки. Если не задан, используется клиент с таймаутом DefaultWebhookTimeout,
+	// который соединяется только с разрешёнными адресами. Заданный клиент должен проверять адреса сам
+	Client *http.Client
+	// MaxAttempts задаёт, сколько раз пытаться доставить уведомление, если не задано - DefaultWebhookAttempts
+	MaxAttempts int64
+	// Backoff задаёт паузу перед второй попыткой, если не задана - DefaultWebhookBackoff
+	Backoff time.Duration
+	// Workers задаёт, сколько уведомлений доставляется одновременно, если не задано - DefaultWebhookWorkers
+	Workers int64
+	// Counter - счётчики доставки уведомлений, если не задан, они не считаются
+	Counter *CounterService
+
+	once    sync.Once
+	queue   chan webhookJob
+	guarded *http.Client
+	// mu защищает retries и stopped
+	mu      sync.Mutex
+	retries map[*time.Timer]webhookJob
+	stopped bool
+}
+
+// CheckCallbackURL проверяет адрес для уведомлений
+func CheckCallbackURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %s", model.ErrInvalidCallbackURL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: absolute http or https url is required", model.ErrInvalidCallbackURL)
+	}
+	return nil
+}
+
+// ParseSubjectWebhooks разбирает адреса для уведомлений создателей секретов из описания вида
+// "alice=https://example.org/hook,bob=https://example.com/purser". Пустое описание означает, что адресов нет
+func ParseSubjectWebhooks(spec string) (map[string]string, error) {
+	ret := make(map[string]string, 0)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		subject, callback, found := strings.Cut(item, "=")
+		if !found || strings.TrimSpace(subject) == "" {
+			return nil, fmt.Errorf("malformed subject webhook %q, should be subject=url", item)
+		}
+		callback = strings.TrimSpace(callback)
+		err := CheckCallbackURL(callback)
+		if err != nil {
+			return nil, fmt.Errorf("webhook of %s: %w", subject, err)
+		}
+		ret[strings.TrimSpace(subject)] = callback
+	}
+	return ret, nil
+}
+
+// ParseAllowedNetworks разбирает сети, в которые можно доставлять уведомления, из описания вида
+// "10.0.0.0/8,fd00::/8". Пустое описание означает, что уведомления доставляются только на публичные адреса
+func ParseAllowedNetworks(spec string) ([]*net.IPNet, error) {
+	ret := make([]*net.IPNet, 0)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("malformed network %q: %w", item, err)
+		}
+		ret = append(ret, network)
+	}
+	return ret, nil
+}
+
+// mustParseNetworks разбирает сети, заданные в коде
+func mustParseNetworks(spec string) []*net.IPNet {
+	ret, err := ParseAllowedNetworks(spec)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// init готовит очередь уведомлений и клиент для их доставки
+func (wn *WebhookNotifier) init() {
+	wn.once.Do(func() {
+		wn.queue = make(chan webhookJob, webhookQueueSize)
+		wn.retries = make(map[*time.Timer]webhookJob, 0)
+		wn.guarded = wn.newGuardedClient()
+	})
+}
+
+// allowed проверяет, что на адрес можно доставлять уведомления: он публичный или входит в AllowedNetworks
+func (wn *WebhookNotifier) allowed(ip net.IP) bool {
+	for _, network := range wn.AllowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// newGuardedClient создаёт HTTP клиент, который соединяется только с разрешёнными адресами. Адрес проверяется
+// непосредственно перед соединением, поэтому сменить ответ DNS после проверки адреса при создании секрета бесполезно
+func (wn *WebhookNotifier) newGuardedClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: DefaultWebhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !wn.allowed(ip) {
+				return fmt.Errorf("%w: %s is not a public address", model.ErrInvalidCallbackURL, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// через прокси проверялся бы адрес прокси, а не получателя
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: DefaultWebhookTimeout, Transport: transport}
+}
+
+// checkCallback проверяет адрес для уведомлений, который указан при создании секрета.
+// Все адреса, в которые разрешается имя хоста, должны быть разрешены
+func (wn *WebhookNotifier) checkCallback(ctx context.Context, callback string) error {
+	if callback == "" {
+		return nil
+	}
+	if wn == nil {
+		return fmt.Errorf("%w: webhooks are disabled", model.ErrInvalidCallbackURL)
+	}
+	err := CheckCallbackURL(callback)
+	if err != nil {
+		return err
+	}
+	parsed, _ := url.Parse(callback)
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: %s", model.ErrInvalidCallbackURL, err)
+	}
+	for i := range addrs {
+		if !wn.allowed(addrs[i].IP) {
+			return fmt.Errorf("%w: %s is not a public address", model.ErrInvalidCallbackURL, addrs[i].IP)
+		}
+	}
+	return nil
+}
+
+// Subscribe подписывает создателя на события секрета, если он указал адрес для уведомлений
+// при создании секрета или для всех своих секретов
+func (wn *WebhookNotifier) Subscribe(ctx context.Context, secret model.Secret, callback string) error {
+	if wn == nil {
+		return nil
+	}
+	if callback == "" {
+		callback = wn.Subjects[secret.Owner]
+	}
+	if callback == "" {
+		return nil
+	}
+	return wn.Repo.SaveSubscription(ctx, model.WebhookSubscription{
+		SecretID: secret.ID,
+		Owner:    secret.Owner,
+		URL:      callback,
+		ExpireAt: secret.ExpireAt.Add(model.WebhookGrace),
+	})
+}
+
+// Unsubscribe удаляет подписку на события секрета, не отправляя уведомлений
+func (wn *WebhookNotifier) Unsubscribe(ctx context.Context, secretID string) {
+	if wn == nil {
+		return
+	}
+	wn.traceError(ctx, wn.Repo.DeleteSubscription(ctx, secretID))
+}
+
+// Extend продлевает подписку на события секрета, срок жизни которого изменился
+func (wn *WebhookNotifier) Extend(ctx context.Context, secret model.Secret) {
+	if wn == nil {
+		return
+	}
+	subscription, err := wn.Repo.FindSubscription(ctx, secret.ID)
+	if err == nil {
+		subscription.ExpireAt = secret.ExpireAt.Add(model.WebhookGrace)
+		err = wn.Repo.SaveSubscription(ctx, subscription)
+	}
+	wn.traceError(ctx, err)
+}
+
+// Notify ставит в очередь уведомление о событии секрета, если на его события есть подписка.
+// О секрете, который уже читали, не сообщается, когда он устаревает. Если секрета больше нет, подписка удаляется.
+// Ошибки отмечаются в span, но не мешают операции с секретом
+func (wn *WebhookNotifier) Notify(ctx context.Context, secretID string, eventType model.WebhookEventType, actor string, gone bool) {
+	if wn == nil {
+		return
+	}
+	subscription, err := wn.Repo.FindSubscription(ctx, secretID)
+	if err != nil {
+		wn.traceError(ctx, err)
+		return
+	}
+	if eventType != model.WebhookSecretExpired || !subscription.Read {
+		wn.enqueue(ctx, subscription, eventType, actor)
+	}
+	switch {
+	case gone:
+		err = wn.Repo.DeleteSubscription(ctx, secretID)
+	case eventType == model.WebhookSecretRead && !subscription.Read:
+		subscription.Read = true
+		err = wn.Repo.SaveSubscription(ctx, subscription)
+	}
+	wn.traceError(ctx, err)
+}
+
+// Prune удаляет устаревшие подписки
+func (wn *WebhookNotifier) Prune(ctx context.Context) error {
+	if wn == nil {
+		return nil
+	}
+	return wn.Repo.PruneSubscriptions(ctx)
+}
+
+// traceError отмечает в span ошибку работы с подписками, отсутствие подписки ошибкой не считается
+func (wn *WebhookNotifier) traceError(ctx context.Context, err error) {
+	if err == nil || errors.Is(err, model.ErrSubscriptionNotFound) {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("Webhook subscription is not processed: " + err.Error())
+	span.RecordError(err)
+}
+
+// increment увеличивает счётчик доставки уведомлений
+func (wn *WebhookNotifier) increment(ctx context.Context, key string) {
+	if wn.Counter != nil {
+		wn.Counter.Increment(ctx, key, 1)
+	}
+}
+
+// enqueue готовит подписанное уведомление и ставит его в очередь доставки
+func (wn *WebhookNotifier) enqueue(ctx context.Context, subscription model.WebhookSubscription, eventType model.WebhookEventType, actor string) {
+	wn.init()
+	now := time.Now()
+	payload, err := json.Marshal(webhookEvent{
+		SpecVersion:     "1.0",
+		ID:              misc.UUID(),
+		Source:          wn.Source,
+		Type:            eventType,
+		Subject:         subscription.SecretID,
+		Time:            now.UTC(),
+		DataContentType: "application/json",
+		Data: webhookEventData{
+			SecretID: subscription.SecretID,
+			Owner:    subscription.Owner,
+			Actor:    actor,
+		},
+	})
+	if err != nil {
+		wn.traceError(ctx, err)
+		return
+	}
+	job := webhookJob{delivery: model.WebhookDelivery{
+		SecretID:  subscription.SecretID,
+		URL:       subscription.URL,
+		EventType: eventType,
+		Payload:   payload,
+		CreatedAt: now,
+	}}
+	if wn.push(ctx, job) {
+		wn.increment(ctx, "webhook_queued")
+	}
+}
+
+// Sign возвращает подпись тела уведомления для заголовка WebhookSignatureHeader
+func (wn *WebhookNotifier) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, wn.Secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver делает одну попытку доставки уведомления
+func (wn *WebhookNotifier) deliver(ctx context.Context, delivery model.WebhookDelivery) error {
+	client := wn.Client
+	if client == nil {
+		client = wn.guarded
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set(WebhookSignatureHeader, wn.Sign(delivery.Payload))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// deadLetter сохраняет уведомление как недоставленное
+func (wn *WebhookNotifier) deadLetter(ctx context.Context, delivery model.WebhookDelivery) {
+	wn.increment(ctx, "webhook_dead_letter")
+	_, err := wn.Repo.RecordDeadLetter(ctx, delivery)
+	wn.traceError(ctx, err)
+}
+
+// push ставит уведомление в очередь доставки. Если очередь переполнена или доставка уже остановлена,
+// уведомление сразу сохраняется как недоставленное
+func (wn *WebhookNotifier) push(ctx context.Context, job webhookJob) bool {
+	// под блокировкой только решается, что делать с уведомлением, а недоставленное сохраняется уже без неё,
+	// чтобы запись в репозиторий не задерживала другие уведомления
+	wn.mu.Lock()
+	queued := false
+	if wn.stopped {
+		job.delivery.LastError = "delivery is stopped"
+	} else {
+		select {
+		case wn.queue <- job:
+			queued = true
+		default:
+			job.delivery.LastError = "delivery queue is full"
+		}
+	}
+	wn.mu.Unlock()
+	if !queued {
+		wn.deadLetter(ctx, job.delivery)
+	}
+	return queued
+}
+
+// retryLater ставит уведомление в очередь заново после паузы. Пока пауза не прошла,
+// уведомление числится среди ожидающих повтора, чтобы при остановке сохранить его как недоставленное
+func (wn *WebhookNotifier) retryLater(job webhookJob, pause time.Duration) {
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	var timer *time.Timer
+	timer = time.AfterFunc(pause, func() {
+		wn.mu.Lock()
+		_, pending := wn.retries[timer]
+		delete(wn.retries, timer)
+		wn.mu.Unlock()
+		if pending {
+			wn.push(context.Background(), job)
+		}
+	})
+	wn.retries[timer] = job
+}
+
+// work доставляет уведомления из очереди, пока не будет отменён контекст
+func (wn *WebhookNotifier) work(ctx context.Context, maxAttempts int64, backoff time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-wn.queue:
+			job.delivery.Attempts++
+			err := wn.deliver(ctx, job.delivery)
+			if err == nil {
+				wn.increment(ctx, "webhook_delivered")
+				continue
+			}
+			job.delivery.LastError = err.Error()
+			if job.delivery.Attempts >= maxAttempts || ctx.Err() != nil {
+				// контекст уже отменён, поэтому недоставленное уведомление сохраняется без него
+				wn.deadLetter(context.Background(), job.delivery)
+				continue
+			}
+			wn.increment(ctx, "webhook_retried")
+			wn.retryLater(job, backoff<<(job.delivery.Attempts-1))
+		}
+	}
+}
+
+// stop останавливает доставку и сохраняет как недоставленные уведомления, ожидающие повтора или оставшиеся в очереди
+func (wn *WebhookNotifier) stop(ctx context.Context) {
+	wn.mu.Lock()
+	wn.stopped = true
+	retries := wn.retries
+	wn.retries = nil
+	wn.mu.Unlock()
+	for timer, job := range retries {
+		timer.Stop()
+		wn.deadLetter(ctx, job.delivery)
+	}
+	for {
+		select {
+		case job := <-wn.queue:
+			if job.delivery.LastError == "" {
+				job.delivery.LastError = "delivery is stopped"
+			}
+			wn.deadLetter(ctx, job.delivery)
+		default:
+			return
+		}
+	}
+}
+
+// Start доставляет уведомления из очереди в Workers потоков, пока не будет отменён контекст.
+// Повторные попытки ставятся в очередь заново после паузы, так что ожидание не задерживает другие уведомления,
+// а медленный получатель занимает только один поток. При остановке уведомления, которые ждут повтора или
+// ещё не доставлены, сохраняются как недоставленные, чтобы не потерять их
+func (wn *WebhookNotifier) Start(ctx context.Context) {
+	wn.init()
+	maxAttempts := wn.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookAttempts
+	}
+	backoff := wn.Backoff
+	if backoff <= 0 {
+		backoff = DefaultWebhookBackoff
+	}
+	workers := wn.Workers
+	if workers <= 0 {
+		workers = DefaultWebhookWorkers
+	}
+	wg := sync.WaitGroup{}
+	for i := int64(0); i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wn.work(ctx, maxAttempts, backoff)
+		}()
+	}
+	wg.Wait()
+	wn.stop(context.Background())
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/vodolaz095/purser/internal/repository/memory"
+	"github.com/vodolaz095/purser/model"
+)
+
+func TestParseSubjectWebhooks(t *testing.T) {
+	webhooks, err := ParseSubjectWebhooks(" alice=https://example.org/hook, bob=http://example.com/purser")
+	if err != nil {
+		t.Fatalf("error parsing webhooks: %s", err)
+	}
+	assert.Equal(t, map[string]string{
+		"alice": "https://example.org/hook",
+		"bob":   "http://example.com/purser",
+	}, webhooks)
+	webhooks, err = ParseSubjectWebhooks("")
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+	for _, spec := range []string{"alice", "=https://example.org", "alice=ftp://example.org", "alice=/hook"} {
+		_, err = ParseSubjectWebhooks(spec)
+		assert.Error(t, err, "webhooks %s are accepted", spec)
+	}
+}
+
+func TestParseAllowedNetworks(t *testing.T) {
+	networks, err := ParseAllowedNetworks(" 10.0.0.0/8, fd00::/8")
+	if err != nil {
+		t.Fatalf("error parsing networks: %s", err)
+	}
+	if assert.Len(t, networks, 2) {
+		assert.Equal(t, "10.0.0.0/8", networks[0].String())
+		assert.Equal(t, "fd00::/8", networks[1].String())
+	}
+	networks, err = ParseAllowedNetworks("")
+	assert.NoError(t, err)
+	assert.Empty(t, networks)
+	_, err = ParseAllowedNetworks("10.0.0.1")
+	assert.Error(t, err, "address without mask is accepted")
+}
+
+func TestWebhookNotifier_NonPublicAddresses(t *testing.T) {
+	ctx := context.TODO()
+	notifier := &WebhookNotifier{AllowedNetworks: mustParseNetworks("10.1.0.0/16")}
+	for _, callback := range []string{
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://[::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"http://100.64.0.1/hook",
+	} {
+		err := notifier.checkCallback(ctx, callback)
+		assert.True(t, errors.Is(err, model.ErrInvalidCallbackURL), "callback %s is accepted: %v", callback, err)
+	}
+	for _, callback := range []string{"http://10.1.2.3/hook", "https://8.8.8.8/hook"} {
+		assert.NoError(t, notifier.checkCallback(ctx, callback), "callback %s is rejected", callback)
+	}
+
+	// адрес проверяется и при соединении, даже если при создании секрета он был другим
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	notifier.init()
+	err := notifier.deliver(ctx, model.WebhookDelivery{URL: server.URL, Payload: []byte("{}")})
+	assert.True(t, errors.Is(err, model.ErrInvalidCallbackURL), "loopback is dialed: %v", err)
+	receiver.Lock()
+	assert.Zero(t, receiver.attempts)
+	receiver.Unlock()
+}
+
+// webhookReceiver принимает уведомления, отвечая ошибкой на первые failures попыток
+type webhookReceiver struct {
+	sync.Mutex
+	failures int
+	attempts int
+	events   []webhookEvent
+	signed   bool
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wr.Lock()
+	defer wr.Unlock()
+	wr.attempts++
+	if wr.attempts <= wr.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	payload, _ := io.ReadAll(r.Body)
+	notifier := WebhookNotifier{Secret: []byte("webhook secret")}
+	wr.signed = r.Header.Get(WebhookSignatureHeader) == notifier.Sign(payload)
+	var event webhookEvent
+	_ = json.Unmarshal(payload, &event)
+	wr.events = append(wr.events, event)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// received возвращает типы доставленных уведомлений, дождавшись, пока их будет хотя бы count
+func (wr *webhookReceiver) received(count int) []model.WebhookEventType {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		wr.Lock()
+		if len(wr.events) >= count || time.Now().After(deadline) {
+			ret := make([]model.WebhookEventType, 0, len(wr.events))
+			for i := range wr.events {
+				ret = append(ret, wr.events[i].Type)
+			}
+			wr.Unlock()
+			return ret
+		}
+		wr.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSecretService_Webhooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	receiver := &webhookReceiver{failures: 1}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	notifier := &WebhookNotifier{
+		Repo:            repo,
+		Secret:          []byte("webhook secret"),
+		Source:          "unit_test",
+		Subjects:        map[string]string{"bob": server.URL},
+		AllowedNetworks: mustParseNetworks("127.0.0.0/8,::1/128"),
+		Backoff:         time.Millisecond,
+		Counter:         &CounterService{},
+	}
+	notifier.Counter.Init()
+	go notifier.Start(ctx)
+	ss := SecretService{
+		Tracer:   otel.Tracer("unit_test_service"),
+		Repo:     repo,
+		Webhooks: notifier,
+	}
+
+	_, err = ss.Create(ctx, model.SecretParams{Body: []byte("x"), Owner: "alice", CallbackURL: "ftp://example.org"})
+	assert.True(t, errors.Is(err, model.ErrInvalidCallbackURL), "wrong error %v", err)
+
+	read, err := ss.Create(ctx, model.SecretParams{Body: []byte("read"), Owner: "alice", CallbackURL: server.URL})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, read.ID, ReadOptions{Identity: model.Identity{Subject: "alice"}})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+	deleted, err := ss.Create(ctx, model.SecretParams{Body: []byte("deleted"), Owner: "bob"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	err = ss.DeleteByID(ctx, deleted.ID, model.Identity{Subject: "bob"})
+	if err != nil {
+		t.Fatalf("error deleting secret: %s", err)
+	}
+	unsubscribed, err := ss.Create(ctx, model.SecretParams{Body: []byte("unsubscribed"), Owner: "carol"})
+	if err != nil {
+		t.Fatalf("error creating secret: %s", err)
+	}
+	_, err = ss.FindByID(ctx, unsubscribed.ID, ReadOptions{Identity: model.Identity{Subject: "carol"}})
+	if err != nil {
+		t.Fatalf("error reading secret: %s", err)
+	}
+	// первая попытка доставки не удаётся, поэтому уведомления могут прийти в другом порядке
+	assert.ElementsMatch(t, []model.WebhookEventType{model.WebhookSecretRead, model.WebhookSecretDeleted}, receiver.received(2))
+	receiver.Lock()
+	assert.True(t, receiver.signed, "signature is wrong")
+	for _, event := range receiver.events {
+		assert.Equal(t, "1.0", event.SpecVersion)
+		if event.Type == model.WebhookSecretRead {
+			assert.Equal(t, read.ID, event.Data.SecretID)
+			assert.Equal(t, "alice", event.Data.Actor)
+		} else {
+			assert.Equal(t, deleted.ID, event.Data.SecretID)
+			assert.Equal(t, "bob", event.Data.Owner)
+		}
+	}
+	receiver.Unlock()
+
+	// о прочитанном секрете при устаревании не сообщается, о непрочитанном - сообщается
+	for _, secret := range []model.Secret{read, unsubscribed} {
+		secret.ExpireAt = time.Now().Add(-time.Second)
+		_, err = repo.Update(ctx, secret, secret.Version, 1)
+		if err != nil {
+			t.Fatalf("error expiring secret: %s", err)
+		}
+	}
+	expired, err := repo.Create(ctx, model.Secret{
+		Body:      []byte("expired"),
+		Owner:     "bob",
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpireAt:  time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("error creating expired secret: %s", err)
+	}
+	err = notifier.Subscribe(ctx, expired, "")
+	if err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+	err = ss.Prune(ctx)
+	if err != nil {
+		t.Fatalf("error pruning: %s", err)
+	}
+	assert.ElementsMatch(t, []model.WebhookEventType{model.WebhookSecretRead, model.WebhookSecretDeleted, model.WebhookSecretExpired},
+		receiver.received(3))
+	// уведомление о прочитанном секрете могло бы прийти позже
+	time.Sleep(50 * time.Millisecond)
+	receiver.Lock()
+	if assert.Len(t, receiver.events, 3, "expiration of read secret is notified") {
+		assert.Equal(t, expired.ID, receiver.events[2].Subject)
+	}
+	receiver.Unlock()
+	_, err = repo.FindSubscription(ctx, expired.ID)
+	assert.True(t, errors.Is(err, model.ErrSubscriptionNotFound), "subscription of pruned secret is kept")
+	retried, _ := notifier.Counter.Get(ctx, "webhook_retried")
+	assert.Equal(t, uint64(1), retried, "failed delivery is not retried")
+}
+
+func TestWebhookNotifier_DeadLetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	receiver := &webhookReceiver{failures: 100}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	notifier := &WebhookNotifier{
+		Repo:            repo,
+		Secret:          []byte("webhook secret"),
+		AllowedNetworks: mustParseNetworks("127.0.0.0/8,::1/128"),
+		MaxAttempts:     3,
+		Backoff:         time.Millisecond,
+	}
+	go notifier.Start(ctx)
+	secret := model.Secret{ID: "dead", Owner: "alice", ExpireAt: time.Now().Add(time.Hour)}
+	err = notifier.Subscribe(ctx, secret, server.URL)
+	if err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+	notifier.Notify(ctx, secret.ID, model.WebhookSecretDeleted, "alice", true)
+	deadline := time.Now().Add(5 * time.Second)
+	var deadLetters []model.WebhookDelivery
+	for time.Now().Before(deadline) {
+		deadLetters, err = repo.ListDeadLetters(ctx, secret.ID)
+		if err != nil {
+			t.Fatalf("error listing dead letters: %s", err)
+		}
+		if len(deadLetters) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if assert.Len(t, deadLetters, 1, "undelivered event is not recorded") {
+		assert.Equal(t, int64(3), deadLetters[0].Attempts)
+		assert.Equal(t, model.WebhookSecretDeleted, deadLetters[0].EventType)
+		assert.Contains(t, deadLetters[0].LastError, "503")
+	}
+	receiver.Lock()
+	assert.Equal(t, 3, receiver.attempts)
+	receiver.Unlock()
+}
+
+// brokenWebhookRepo не может сохранить подписку
+type brokenWebhookRepo struct {
+	*memory.Repository
+}
+
+func (br brokenWebhookRepo) SaveSubscription(context.Context, model.WebhookSubscription) error {
+	return errors.New("subscriptions are broken")
+}
+
+func TestSecretService_CreateWithBrokenSubscription(t *testing.T) {
+	ctx := context.TODO()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	ss := SecretService{
+		Tracer: otel.Tracer("unit_test_service"),
+		Repo:   repo,
+		Webhooks: &WebhookNotifier{
+			Repo:            brokenWebhookRepo{repo},
+			Secret:          []byte("webhook secret"),
+			AllowedNetworks: mustParseNetworks("127.0.0.0/8"),
+		},
+	}
+	secret, err := ss.Create(ctx, model.SecretParams{Body: []byte("x"), Owner: "alice", CallbackURL: "http://127.0.0.1/hook"})
+	if err != nil {
+		t.Fatalf("secret is not created because of subscription: %s", err)
+	}
+	assert.NotEmpty(t, secret.ID)
+	assert.NotEmpty(t, secret.ShareToken)
+}
+
+func TestWebhookNotifier_SlowReceiver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer slow.Close()
+	defer close(release)
+	receiver := &webhookReceiver{}
+	fast := httptest.NewServer(receiver)
+	defer fast.Close()
+	notifier := &WebhookNotifier{
+		Repo:            repo,
+		Secret:          []byte("webhook secret"),
+		AllowedNetworks: mustParseNetworks("127.0.0.0/8,::1/128"),
+		Workers:         2,
+	}
+	go notifier.Start(ctx)
+	for id, callback := range map[string]string{"slow": slow.URL, "fast": fast.URL} {
+		err = notifier.Subscribe(ctx, model.Secret{ID: id, Owner: "alice", ExpireAt: time.Now().Add(time.Hour)}, callback)
+		if err != nil {
+			t.Fatalf("error subscribing: %s", err)
+		}
+	}
+	notifier.Notify(ctx, "slow", model.WebhookSecretRead, "alice", false)
+	notifier.Notify(ctx, "fast", model.WebhookSecretRead, "alice", false)
+	assert.Equal(t, []model.WebhookEventType{model.WebhookSecretRead}, receiver.received(1),
+		"slow receiver delays delivery to others")
+}
+
+func TestWebhookNotifier_StopWithPendingRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	repo := &memory.Repository{}
+	err := repo.Init(ctx)
+	if err != nil {
+		t.Fatalf("error initializing repo: %s", err)
+	}
+	receiver := &webhookReceiver{failures: 100}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	notifier := &WebhookNotifier{
+		Repo:            repo,
+		Secret:          []byte("webhook secret"),
+		AllowedNetworks: mustParseNetworks("127.0.0.0/8,::1/128"),
+		Backoff:         time.Hour,
+	}
+	stopped := make(chan struct{})
+	go func() {
+		notifier.Start(ctx)
+		close(stopped)
+	}()
+	secret := model.Secret{ID: "pending", Owner: "alice", ExpireAt: time.Now().Add(time.Hour)}
+	err = notifier.Subscribe(ctx, secret, server.URL)
+	if err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+	notifier.Notify(ctx, secret.ID, model.WebhookSecretDeleted, "alice", true)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		receiver.Lock()
+		attempts := receiver.attempts
+		receiver.Unlock()
+		if attempts > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// первая попытка не удалась, а вторая будет только через час
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-stopped
+	deadLetters, err := repo.ListDeadLetters(context.TODO(), secret.ID)
+	if err != nil {
+		t.Fatalf("error listing dead letters: %s", err)
+	}
+	if assert.Len(t, deadLetters, 1, "pending retry is lost on stop") {
+		assert.Equal(t, int64(1), deadLetters[0].Attempts)
+		assert.Contains(t, deadLetters[0].LastError, "503")
+	}
+}
@@ -1,11 +1,82 @@
 package grpc
 
 import (
+	"errors"
+	"time"
+
 	"github.com/vodolaz095/purser/internal/transport/grpc/proto"
 	"github.com/vodolaz095/purser/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// convertValidationError превращает ошибку политики содержимого в статус InvalidArgument,
+// в деталях которого нарушенные правила перечислены как errdetails.BadRequest, и возвращает nil, если ошибка другая
+func convertValidationError(err error) error {
+	var validationError *model.ValidationError
+	if !errors.As(err, &validationError) {
+		return nil
+	}
+	details := &errdetails.BadRequest{}
+	for i := range validationError.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       validationError.Violations[i].Rule,
+			Description: validationError.Violations[i].Message,
+		})
+	}
+	st, dErr := status.New(codes.InvalidArgument, validationError.Error()).WithDetails(details)
+	if dErr != nil {
+		return status.Error(codes.InvalidArgument, validationError.Error())
+	}
+	return st.Err()
+}
+
+// convertGoneError превращает ошибку об исчезнувшем секрете в статус FailedPrecondition, в деталях которого
+// причина и момент исчезновения переданы как errdetails.ErrorInfo, и возвращает nil, если ошибка другая
+func convertGoneError(err error) error {
+	var gone *model.GoneError
+	if !errors.As(err, &gone) {
+		return nil
+	}
+	details := &errdetails.ErrorInfo{
+		Reason: "SECRET_GONE",
+		Domain: "purser",
+		Metadata: map[string]string{
+			"secretId":  gone.Tombstone.SecretID,
+			"reason":    string(gone.Tombstone.Reason),
+			"deletedAt": gone.Tombstone.DeletedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	st, dErr := status.New(codes.FailedPrecondition, gone.Error()).WithDetails(details)
+	if dErr != nil {
+		return status.Error(codes.FailedPrecondition, gone.Error())
+	}
+	return st.Err()
+}
+
+// convertNotYetAvailableError превращает ошибку о ещё не доступном секрете в статус FailedPrecondition, в деталях которого
+// момент доступности передан как errdetails.ErrorInfo, и возвращает nil, если ошибка другая
+func convertNotYetAvailableError(err error) error {
+	var notYet *model.NotYetAvailableError
+	if !errors.As(err, &notYet) {
+		return nil
+	}
+	details := &errdetails.ErrorInfo{
+		Reason: "SECRET_NOT_YET_AVAILABLE",
+		Domain: "purser",
+		Metadata: map[string]string{
+			"notBefore": notYet.NotBefore.UTC().Format(time.RFC3339),
+		},
+	}
+	st, dErr := status.New(codes.FailedPrecondition, notYet.Error()).WithDetails(details)
+	if dErr != nil {
+		return status.Error(codes.FailedPrecondition, notYet.Error())
+	}
+	return st.Err()
+}
+
 func convertModelToDto(secret model.Secret) *proto.Secret {
 	meta := make([]*proto.Meta, len(secret.Meta))
 	for k := range secret.Meta {
@@ -14,12 +85,140 @@ func convertModelToDto(secret model.Secret) *proto.Secret {
 			Value: secret.Meta[k],
 		})
 	}
+	dto := &proto.Secret{
+		Id:          secret.ID,
+		Body:        secret.Body,
+		Meta:        meta,
+		CreatedAt:   timestamppb.New(secret.CreatedAt),
+		ExpiresAt:   timestamppb.New(secret.ExpireAt),
+		Owner:       secret.Owner,
+		Recipients:  secret.Recipients,
+		Groups:      secret.Groups,
+		Views:       secret.Views,
+		MaxViews:    secret.MaxViews,
+		ViewsLeft:   secret.ViewsLeft(),
+		Version:     secret.Version,
+		ContentType: secret.ContentType,
+		Filename:    secret.Filename,
+		ShareToken:  secret.ShareToken,
+		Opaque:      secret.Opaque,
+	}
+	if !secret.NotBefore.IsZero() {
+		dto.NotBefore = timestamppb.New(secret.NotBefore)
+	}
+	return dto
+}
+
+// convertSharedToDto превращает секрет, прочитанный по ссылке, в ответ без идентификатора, создателя, адресатов и метаданных,
+// так как читатель может быть кем угодно
+func convertSharedToDto(secret model.Secret) *proto.Secret {
 	return &proto.Secret{
-		Id:        secret.ID,
-		Body:      secret.Body,
-		Meta:      meta,
-		CreatedAt: timestamppb.New(secret.CreatedAt),
-		ExpiresAt: timestamppb.New(secret.ExpireAt),
+		Body:        secret.Body,
+		ExpiresAt:   timestamppb.New(secret.ExpireAt),
+		Views:       secret.Views,
+		MaxViews:    secret.MaxViews,
+		ViewsLeft:   secret.ViewsLeft(),
+		ContentType: secret.ContentType,
+		Filename:    secret.Filename,
+		Opaque:      secret.Opaque,
+	}
+}
+
+func convertAuditEventToDto(event model.AuditEvent) *proto.AuditEvent {
+	return &proto.AuditEvent{
+		Id:        event.ID,
+		SecretId:  event.SecretID,
+		Action:    string(event.Action),
+		Subject:   event.Subject,
+		Transport: event.Transport,
+		RemoteIp:  event.RemoteIP,
+		UserAgent: event.UserAgent,
+		TraceId:   event.TraceID,
+		Detail:    event.Detail,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+func convertStatusToDto(secretStatus model.SecretStatus) *proto.SecretStatus {
+	accesses := make([]*proto.SecretAccess, 0, len(secretStatus.Accesses))
+	for i := range secretStatus.Accesses {
+		accesses = append(accesses, &proto.SecretAccess{
+			Subject:    secretStatus.Accesses[i].Subject,
+			AccessedAt: timestamppb.New(secretStatus.Accesses[i].AccessedAt),
+		})
+	}
+	return &proto.SecretStatus{
+		Id:        secretStatus.ID,
+		CreatedAt: timestamppb.New(secretStatus.CreatedAt),
+		ExpireAt:  timestamppb.New(secretStatus.ExpireAt),
+		Remaining: int64(secretStatus.Remaining.Seconds()),
+		Opened:    secretStatus.Opened,
+		Views:     secretStatus.Views,
+		MaxViews:  secretStatus.MaxViews,
+		ViewsLeft: secretStatus.ViewsLeft,
+		Accesses:  accesses,
+	}
+}
+
+// convertNewSecretDTO превращает параметры нового секрета в model.SecretParams без метаданных и создателя,
+// отвечая InvalidArgument на недопустимые значения
+func convertNewSecretDTO(request *proto.NewSecretRequest) (model.SecretParams, error) {
+	if request.GetMaxViews() < 0 {
+		return model.SecretParams{}, status.Errorf(codes.InvalidArgument, "maxViews should not be negative")
+	}
+	if len(request.GetPassphrase()) > 72 {
+		return model.SecretParams{}, status.Errorf(codes.InvalidArgument, "passphrase should not be longer than 72 bytes")
+	}
+	params := model.SecretParams{
+		Body:        request.GetBody(),
+		ContentType: request.GetContentType(),
+		Filename:    request.GetFilename(),
+		Recipients:  request.GetRecipients(),
+		Groups:      request.GetGroups(),
+		TTL:         time.Duration(request.GetTtl()) * time.Second,
+		MaxViews:    request.GetMaxViews(),
+		Passphrase:  request.GetPassphrase(),
+		CallbackURL: request.GetCallbackUrl(),
+		Opaque:      request.GetOpaque(),
+	}
+	if request.GetExpireAt() != nil {
+		params.ExpireAt = request.GetExpireAt().AsTime()
+	}
+	if request.GetNotBefore() != nil {
+		params.NotBefore = request.GetNotBefore().AsTime()
+	}
+	return params, nil
+}
+
+// convertRequestToDto превращает запрос секрета в ответ, моменты, которые не наступили, не заполняются
+func convertRequestToDto(request model.SecretRequest) *proto.SecretRequestInfo {
+	ret := &proto.SecretRequestInfo{
+		Id:          request.ID,
+		Requester:   request.Requester,
+		Description: request.Description,
+		CreatedAt:   timestamppb.New(request.CreatedAt),
+		ExpireAt:    timestamppb.New(request.ExpireAt),
+		SecretId:    request.SecretID,
+		FulfilToken: request.Token,
+	}
+	if !request.FulfilledAt.IsZero() {
+		ret.FulfilledAt = timestamppb.New(request.FulfilledAt)
+	}
+	return ret
+}
+
+func convertSplitToDto(split model.Split) *proto.Split {
+	shares := make([]*proto.SplitShare, 0, len(split.Shares))
+	for i := range split.Shares {
+		shares = append(shares, &proto.SplitShare{
+			Holder:   split.Shares[i].Holder,
+			SecretId: split.Shares[i].SecretID,
+		})
+	}
+	return &proto.Split{
+		Id:        split.ID,
+		Threshold: int32(split.Threshold),
+		Shares:    shares,
 	}
 }
 
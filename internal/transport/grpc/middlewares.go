@@ -2,12 +2,16 @@ package grpc
 
 import (
 	"context"
+	"net"
 	"strings"
 
+	"github.com/vodolaz095/purser/internal/service"
+	"github.com/vodolaz095/purser/model"
 	"github.com/vodolaz095/purser/pkg/jwt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/rs/zerolog/log"
@@ -19,6 +23,16 @@ type TokenSubjectKeyType string
 // TokenSubjectKey задаёт, где в контексте хранится subject из JWT токена
 const TokenSubjectKey = TokenSubjectKeyType("jwt_token_subject")
 
+// TokenGroupsKey задаёт, где в контексте хранятся группы из claim groups JWT токена
+const TokenGroupsKey = TokenSubjectKeyType("jwt_token_groups")
+
+// publicMethods перечисляет методы, которые вызываются без JWT токена
+var publicMethods = map[string]bool{
+	"/purser.Purser/RedeemShareToken":      true,
+	"/purser.Purser/DescribeSecretRequest": true,
+	"/purser.Purser/FulfilSecretRequest":   true,
+}
+
 // ValidateJWTInterceptor валидирует JWT токены во входящих запросах
 type ValidateJWTInterceptor struct {
 	HmacSecret string
@@ -27,6 +41,9 @@ type ValidateJWTInterceptor struct {
 // ServerInterceptor работает с унарными запросами
 func (ji *ValidateJWTInterceptor) ServerInterceptor(ctx context.Context, req interface{},
 	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Internal, "no meta found")
@@ -43,11 +60,34 @@ func (ji *ValidateJWTInterceptor) ServerInterceptor(ctx context.Context, req int
 		return nil, status.Error(codes.InvalidArgument, "wrong authorization strategy")
 	}
 	token = strings.TrimPrefix(token, "Bearer ")
-	subject, err := jwt.ValidateJwtAndExtractSubject(token, ji.HmacSecret)
+	subject, groups, err := jwt.ValidateJwtAndExtractIdentity(token, ji.HmacSecret)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 	log.Debug().Msgf("JWT token subject = %s", subject)
 	ctxWithToken := context.WithValue(ctx, TokenSubjectKey, subject)
+	ctxWithToken = context.WithValue(ctxWithToken, TokenGroupsKey, groups)
 	return handler(ctxWithToken, req)
 }
+
+// IdentifyClientInterceptor сохраняет в контексте адрес и User-Agent клиента, чтобы они попали в журнал аудита
+func IdentifyClientInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	client := model.Client{Transport: "grpc"}
+	p, ok := peer.FromContext(ctx)
+	if ok && p.Addr != nil {
+		client.RemoteIP = p.Addr.String()
+		host, _, err := net.SplitHostPort(client.RemoteIP)
+		if err == nil {
+			client.RemoteIP = host
+		}
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		userAgent := md.Get("user-agent")
+		if len(userAgent) > 0 {
+			client.UserAgent = userAgent[0]
+		}
+	}
+	return handler(service.WithClient(ctx, client), req)
+}
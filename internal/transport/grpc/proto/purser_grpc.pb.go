@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.17.3
+// - protoc             (unknown)
 // source: purser.proto
 
 package proto
@@ -25,6 +25,19 @@ type PurserClient interface {
 	GetSecretByID(ctx context.Context, in *SecretByIDRequest, opts ...grpc.CallOption) (*Secret, error)
 	DeleteSecretByID(ctx context.Context, in *SecretByIDRequest, opts ...grpc.CallOption) (*Nothing, error)
 	CreateSecret(ctx context.Context, in *NewSecretRequest, opts ...grpc.CallOption) (*Secret, error)
+	UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*Secret, error)
+	GetInbox(ctx context.Context, in *Nothing, opts ...grpc.CallOption) (*SecretList, error)
+	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*SecretList, error)
+	QueryAudit(ctx context.Context, in *AuditQueryRequest, opts ...grpc.CallOption) (*AuditEventList, error)
+	GetSecretStatus(ctx context.Context, in *SecretByIDRequest, opts ...grpc.CallOption) (*SecretStatus, error)
+	RedeemShareToken(ctx context.Context, in *ShareTokenRequest, opts ...grpc.CallOption) (*Secret, error)
+	CreateSecretRequest(ctx context.Context, in *NewSecretRequestParams, opts ...grpc.CallOption) (*SecretRequestInfo, error)
+	GetSecretRequest(ctx context.Context, in *SecretRequestByIDRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error)
+	DeleteSecretRequest(ctx context.Context, in *SecretRequestByIDRequest, opts ...grpc.CallOption) (*Nothing, error)
+	DescribeSecretRequest(ctx context.Context, in *SecretRequestTokenRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error)
+	FulfilSecretRequest(ctx context.Context, in *FulfilSecretRequestRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error)
+	CreateSplit(ctx context.Context, in *NewSplitRequest, opts ...grpc.CallOption) (*Split, error)
+	CombineShares(ctx context.Context, in *CombineSharesRequest, opts ...grpc.CallOption) (*CombinedSecret, error)
 }
 
 type purserClient struct {
@@ -62,6 +75,123 @@ func (c *purserClient) CreateSecret(ctx context.Context, in *NewSecretRequest, o
 	return out, nil
 }
 
+func (c *purserClient) UpdateSecret(ctx context.Context, in *UpdateSecretRequest, opts ...grpc.CallOption) (*Secret, error) {
+	out := new(Secret)
+	err := c.cc.Invoke(ctx, "/purser.Purser/UpdateSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) GetInbox(ctx context.Context, in *Nothing, opts ...grpc.CallOption) (*SecretList, error) {
+	out := new(SecretList)
+	err := c.cc.Invoke(ctx, "/purser.Purser/GetInbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*SecretList, error) {
+	out := new(SecretList)
+	err := c.cc.Invoke(ctx, "/purser.Purser/ListSecrets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) QueryAudit(ctx context.Context, in *AuditQueryRequest, opts ...grpc.CallOption) (*AuditEventList, error) {
+	out := new(AuditEventList)
+	err := c.cc.Invoke(ctx, "/purser.Purser/QueryAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) GetSecretStatus(ctx context.Context, in *SecretByIDRequest, opts ...grpc.CallOption) (*SecretStatus, error) {
+	out := new(SecretStatus)
+	err := c.cc.Invoke(ctx, "/purser.Purser/GetSecretStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) RedeemShareToken(ctx context.Context, in *ShareTokenRequest, opts ...grpc.CallOption) (*Secret, error) {
+	out := new(Secret)
+	err := c.cc.Invoke(ctx, "/purser.Purser/RedeemShareToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) CreateSecretRequest(ctx context.Context, in *NewSecretRequestParams, opts ...grpc.CallOption) (*SecretRequestInfo, error) {
+	out := new(SecretRequestInfo)
+	err := c.cc.Invoke(ctx, "/purser.Purser/CreateSecretRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) GetSecretRequest(ctx context.Context, in *SecretRequestByIDRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error) {
+	out := new(SecretRequestInfo)
+	err := c.cc.Invoke(ctx, "/purser.Purser/GetSecretRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) DeleteSecretRequest(ctx context.Context, in *SecretRequestByIDRequest, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, "/purser.Purser/DeleteSecretRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) DescribeSecretRequest(ctx context.Context, in *SecretRequestTokenRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error) {
+	out := new(SecretRequestInfo)
+	err := c.cc.Invoke(ctx, "/purser.Purser/DescribeSecretRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) FulfilSecretRequest(ctx context.Context, in *FulfilSecretRequestRequest, opts ...grpc.CallOption) (*SecretRequestInfo, error) {
+	out := new(SecretRequestInfo)
+	err := c.cc.Invoke(ctx, "/purser.Purser/FulfilSecretRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) CreateSplit(ctx context.Context, in *NewSplitRequest, opts ...grpc.CallOption) (*Split, error) {
+	out := new(Split)
+	err := c.cc.Invoke(ctx, "/purser.Purser/CreateSplit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *purserClient) CombineShares(ctx context.Context, in *CombineSharesRequest, opts ...grpc.CallOption) (*CombinedSecret, error) {
+	out := new(CombinedSecret)
+	err := c.cc.Invoke(ctx, "/purser.Purser/CombineShares", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PurserServer is the server API for Purser service.
 // All implementations must embed UnimplementedPurserServer
 // for forward compatibility
@@ -69,6 +199,19 @@ type PurserServer interface {
 	GetSecretByID(context.Context, *SecretByIDRequest) (*Secret, error)
 	DeleteSecretByID(context.Context, *SecretByIDRequest) (*Nothing, error)
 	CreateSecret(context.Context, *NewSecretRequest) (*Secret, error)
+	UpdateSecret(context.Context, *UpdateSecretRequest) (*Secret, error)
+	GetInbox(context.Context, *Nothing) (*SecretList, error)
+	ListSecrets(context.Context, *ListSecretsRequest) (*SecretList, error)
+	QueryAudit(context.Context, *AuditQueryRequest) (*AuditEventList, error)
+	GetSecretStatus(context.Context, *SecretByIDRequest) (*SecretStatus, error)
+	RedeemShareToken(context.Context, *ShareTokenRequest) (*Secret, error)
+	CreateSecretRequest(context.Context, *NewSecretRequestParams) (*SecretRequestInfo, error)
+	GetSecretRequest(context.Context, *SecretRequestByIDRequest) (*SecretRequestInfo, error)
+	DeleteSecretRequest(context.Context, *SecretRequestByIDRequest) (*Nothing, error)
+	DescribeSecretRequest(context.Context, *SecretRequestTokenRequest) (*SecretRequestInfo, error)
+	FulfilSecretRequest(context.Context, *FulfilSecretRequestRequest) (*SecretRequestInfo, error)
+	CreateSplit(context.Context, *NewSplitRequest) (*Split, error)
+	CombineShares(context.Context, *CombineSharesRequest) (*CombinedSecret, error)
 	mustEmbedUnimplementedPurserServer()
 }
 
@@ -85,6 +228,45 @@ func (UnimplementedPurserServer) DeleteSecretByID(context.Context, *SecretByIDRe
 func (UnimplementedPurserServer) CreateSecret(context.Context, *NewSecretRequest) (*Secret, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
 }
+func (UnimplementedPurserServer) UpdateSecret(context.Context, *UpdateSecretRequest) (*Secret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSecret not implemented")
+}
+func (UnimplementedPurserServer) GetInbox(context.Context, *Nothing) (*SecretList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInbox not implemented")
+}
+func (UnimplementedPurserServer) ListSecrets(context.Context, *ListSecretsRequest) (*SecretList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedPurserServer) QueryAudit(context.Context, *AuditQueryRequest) (*AuditEventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAudit not implemented")
+}
+func (UnimplementedPurserServer) GetSecretStatus(context.Context, *SecretByIDRequest) (*SecretStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretStatus not implemented")
+}
+func (UnimplementedPurserServer) RedeemShareToken(context.Context, *ShareTokenRequest) (*Secret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemShareToken not implemented")
+}
+func (UnimplementedPurserServer) CreateSecretRequest(context.Context, *NewSecretRequestParams) (*SecretRequestInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecretRequest not implemented")
+}
+func (UnimplementedPurserServer) GetSecretRequest(context.Context, *SecretRequestByIDRequest) (*SecretRequestInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretRequest not implemented")
+}
+func (UnimplementedPurserServer) DeleteSecretRequest(context.Context, *SecretRequestByIDRequest) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecretRequest not implemented")
+}
+func (UnimplementedPurserServer) DescribeSecretRequest(context.Context, *SecretRequestTokenRequest) (*SecretRequestInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeSecretRequest not implemented")
+}
+func (UnimplementedPurserServer) FulfilSecretRequest(context.Context, *FulfilSecretRequestRequest) (*SecretRequestInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfilSecretRequest not implemented")
+}
+func (UnimplementedPurserServer) CreateSplit(context.Context, *NewSplitRequest) (*Split, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSplit not implemented")
+}
+func (UnimplementedPurserServer) CombineShares(context.Context, *CombineSharesRequest) (*CombinedSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombineShares not implemented")
+}
 func (UnimplementedPurserServer) mustEmbedUnimplementedPurserServer() {}
 
 // UnsafePurserServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +334,240 @@ func _Purser_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Purser_UpdateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).UpdateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/UpdateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).UpdateSecret(ctx, req.(*UpdateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_GetInbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Nothing)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).GetInbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/GetInbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).GetInbox(ctx, req.(*Nothing))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/ListSecrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).ListSecrets(ctx, req.(*ListSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_QueryAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).QueryAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/QueryAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).QueryAudit(ctx, req.(*AuditQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_GetSecretStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).GetSecretStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/GetSecretStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).GetSecretStatus(ctx, req.(*SecretByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_RedeemShareToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).RedeemShareToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/RedeemShareToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).RedeemShareToken(ctx, req.(*ShareTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_CreateSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSecretRequestParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).CreateSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/CreateSecretRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).CreateSecretRequest(ctx, req.(*NewSecretRequestParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_GetSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequestByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).GetSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/GetSecretRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).GetSecretRequest(ctx, req.(*SecretRequestByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_DeleteSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequestByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).DeleteSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/DeleteSecretRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).DeleteSecretRequest(ctx, req.(*SecretRequestByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_DescribeSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequestTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).DescribeSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/DescribeSecretRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).DescribeSecretRequest(ctx, req.(*SecretRequestTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_FulfilSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfilSecretRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).FulfilSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/FulfilSecretRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).FulfilSecretRequest(ctx, req.(*FulfilSecretRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_CreateSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).CreateSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/CreateSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).CreateSplit(ctx, req.(*NewSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Purser_CombineShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombineSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PurserServer).CombineShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/purser.Purser/CombineShares",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PurserServer).CombineShares(ctx, req.(*CombineSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Purser_ServiceDesc is the grpc.ServiceDesc for Purser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +587,58 @@ var Purser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSecret",
 			Handler:    _Purser_CreateSecret_Handler,
 		},
+		{
+			MethodName: "UpdateSecret",
+			Handler:    _Purser_UpdateSecret_Handler,
+		},
+		{
+			MethodName: "GetInbox",
+			Handler:    _Purser_GetInbox_Handler,
+		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _Purser_ListSecrets_Handler,
+		},
+		{
+			MethodName: "QueryAudit",
+			Handler:    _Purser_QueryAudit_Handler,
+		},
+		{
+			MethodName: "GetSecretStatus",
+			Handler:    _Purser_GetSecretStatus_Handler,
+		},
+		{
+			MethodName: "RedeemShareToken",
+			Handler:    _Purser_RedeemShareToken_Handler,
+		},
+		{
+			MethodName: "CreateSecretRequest",
+			Handler:    _Purser_CreateSecretRequest_Handler,
+		},
+		{
+			MethodName: "GetSecretRequest",
+			Handler:    _Purser_GetSecretRequest_Handler,
+		},
+		{
+			MethodName: "DeleteSecretRequest",
+			Handler:    _Purser_DeleteSecretRequest_Handler,
+		},
+		{
+			MethodName: "DescribeSecretRequest",
+			Handler:    _Purser_DescribeSecretRequest_Handler,
+		},
+		{
+			MethodName: "FulfilSecretRequest",
+			Handler:    _Purser_FulfilSecretRequest_Handler,
+		},
+		{
+			MethodName: "CreateSplit",
+			Handler:    _Purser_CreateSplit_Handler,
+		},
+		{
+			MethodName: "CombineShares",
+			Handler:    _Purser_CombineShares_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "purser.proto",
@@ -17,6 +17,7 @@ type Options struct {
 	HmacSecret     string
 	ListenOn       string
 	SecretService  *service.SecretService
+	AuditService   *service.AuditService
 	CounterService *service.CounterService
 }
 
@@ -33,12 +34,14 @@ func Serve(ctx context.Context, opts Options) error {
 	}
 	grpcTransport := PurserGrpcServer{
 		SecretService:  opts.SecretService,
+		AuditService:   opts.AuditService,
 		CounterService: opts.CounterService,
 	}
 	jwtMiddleware := ValidateJWTInterceptor{HmacSecret: opts.HmacSecret}
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(
 		grpc_middleware.ChainUnaryServer(
 			otelgrpc.UnaryServerInterceptor(),
+			IdentifyClientInterceptor,
 			jwtMiddleware.ServerInterceptor,
 		),
 	))
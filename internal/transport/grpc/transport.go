@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/vodolaz095/purser/internal/repository"
 	"github.com/vodolaz095/purser/internal/service"
 	"github.com/vodolaz095/purser/internal/transport/grpc/proto"
 	"github.com/vodolaz095/purser/model"
@@ -13,12 +15,14 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // PurserGrpcServer реализует grpc сервер
 type PurserGrpcServer struct {
 	proto.UnimplementedPurserServer
 	SecretService  *service.SecretService
+	AuditService   *service.AuditService
 	CounterService *service.CounterService
 }
 
@@ -28,26 +32,52 @@ func (pgs *PurserGrpcServer) extractJwtSubject(ctx context.Context) (string, err
 	raw := ctx.Value(TokenSubjectKey)
 	switch raw.(type) {
 	case string:
+		if raw.(string) == "" {
+			return "", fmt.Errorf("empty %s", TokenSubjectKey)
+		}
 		return raw.(string), nil
 	default:
 		return "", fmt.Errorf("wrong type for %s: %v", TokenSubjectKey, raw)
 	}
 }
 
+// extractIdentity собирает model.Identity из субъекта и групп JWT токена
+func (pgs *PurserGrpcServer) extractIdentity(ctx context.Context) (model.Identity, error) {
+	subject, err := pgs.extractJwtSubject(ctx)
+	if err != nil {
+		return model.Identity{}, err
+	}
+	groups, _ := ctx.Value(TokenGroupsKey).([]string)
+	return model.Identity{Subject: subject, Groups: groups}, nil
+}
+
 // GetSecretByID загружает секрет по его идентификатору
 func (pgs *PurserGrpcServer) GetSecretByID(ctx context.Context, request *proto.SecretByIDRequest) (*proto.Secret, error) {
 	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/GetSecretByID")
 	defer span.End()
 
-	subject, err := pgs.extractJwtSubject(ctx)
+	identity, err := pgs.extractIdentity(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, err.Error())
 	}
+	subject := identity.Subject
 	span.AddEvent("JWT token validated")
 	span.SetAttributes(attribute.String("subject", subject))
 	pgs.CounterService.Increment(ctx2, "grpc_get_secret_called", 1)
-	secret, err := pgs.SecretService.FindByID(ctx2, request.GetId())
+	secret, err := pgs.SecretService.FindByID(ctx2, request.GetId(), service.ReadOptions{
+		Burn:       request.GetBurn(),
+		Passphrase: request.GetPassphrase(),
+		Identity:   identity,
+	})
 	if err != nil {
+		if gErr := convertGoneError(err); gErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_get_secret_gone", 1)
+			return nil, gErr
+		}
+		if nErr := convertNotYetAvailableError(err); nErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_get_secret_not_yet_available", 1)
+			return nil, nErr
+		}
 		if errors.Is(err, model.ErrSecretNotFound) {
 			pgs.CounterService.Increment(ctx2, "grpc_get_secret_not_found", 1)
 			log.Debug().
@@ -59,6 +89,27 @@ func (pgs *PurserGrpcServer) GetSecretByID(ctx context.Context, request *proto.S
 				)
 			return nil, status.Errorf(codes.NotFound, "secret %s is not found", request.GetId())
 		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_get_secret_forbidden", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", request.GetId()).
+				Str("subject", subject).
+				Msgf("Пользователь %s пытался прочитать не адресованный ему секрет %s", subject, request.GetId())
+			return nil, status.Errorf(codes.PermissionDenied, "secret %s is not addressed to %s", request.GetId(), subject)
+		}
+		if errors.Is(err, model.ErrPassphraseRequired) || errors.Is(err, model.ErrWrongPassphrase) {
+			pgs.CounterService.Increment(ctx2, "grpc_get_secret_denied", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", request.GetId()).
+				Str("subject", subject).
+				Msgf("Пользователю %s запрещён доступ к секрету %s: %s", subject, request.GetId(), err)
+			if errors.Is(err, model.ErrPassphraseRequired) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		pgs.CounterService.Increment(ctx2, "grpc_get_secret_error", 1)
 		log.Error().Err(err).
 			Str("trace_id", span.SpanContext().TraceID().String()).
@@ -68,6 +119,9 @@ func (pgs *PurserGrpcServer) GetSecretByID(ctx context.Context, request *proto.S
 		return nil, err
 	}
 	pgs.CounterService.Increment(ctx2, "grpc_get_secret_success", 1)
+	if request.GetBurn() {
+		pgs.CounterService.Increment(ctx2, "grpc_get_secret_burned", 1)
+	}
 	log.Info().
 		Str("trace_id", span.SpanContext().TraceID().String()).
 		Str("secret_id", request.GetId()).
@@ -83,20 +137,34 @@ func (pgs *PurserGrpcServer) DeleteSecretByID(ctx context.Context, request *prot
 	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/DeleteSecretByID")
 	defer span.End()
 
-	subject, err := pgs.extractJwtSubject(ctx)
+	identity, err := pgs.extractIdentity(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, err.Error())
 	}
+	subject := identity.Subject
 	span.AddEvent("JWT token validated")
 	span.SetAttributes(attribute.String("subject", subject))
 	pgs.CounterService.Increment(ctx2, "grpc_delete_secret_called", 1)
 
-	err = pgs.SecretService.DeleteByID(ctx2, request.GetId())
+	err = pgs.SecretService.DeleteByID(ctx2, request.GetId(), identity)
 	if err != nil {
+		if gErr := convertGoneError(err); gErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_delete_secret_gone", 1)
+			return nil, gErr
+		}
 		if errors.Is(err, model.ErrSecretNotFound) {
 			pgs.CounterService.Increment(ctx2, "grpc_delete_secret_not_found", 1)
 			return nil, status.Errorf(codes.NotFound, "secret %s is not found", request.GetId())
 		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_delete_secret_forbidden", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", request.GetId()).
+				Str("subject", subject).
+				Msgf("Пользователь %s пытался удалить не адресованный ему секрет %s", subject, request.GetId())
+			return nil, status.Errorf(codes.PermissionDenied, "secret %s is not addressed to %s", request.GetId(), subject)
+		}
 		pgs.CounterService.Increment(ctx2, "grpc_delete_secret_error", 1)
 		log.Error().Err(err).
 			Str("trace_id", span.SpanContext().TraceID().String()).
@@ -133,8 +201,37 @@ func (pgs *PurserGrpcServer) CreateSecret(ctx context.Context, request *proto.Ne
 	if found {
 		meta["User-Agent"] = md.Get("User-Agent")[0]
 	}
-	secret, err := pgs.SecretService.Create(ctx2, request.Body, meta)
+	params, err := convertNewSecretDTO(request)
 	if err != nil {
+		return nil, err
+	}
+	params.Meta = meta
+	params.Owner = subject
+	secret, err := pgs.SecretService.Create(ctx2, params)
+	if err != nil {
+		if errors.Is(err, model.ErrTooLarge) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_secret_too_large", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if vErr := convertValidationError(err); vErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_create_secret_rejected", 1)
+			log.Info().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("subject", subject).
+				Msgf("Секрет отклонён политикой содержимого: %s", err)
+			return nil, vErr
+		}
+		if errors.Is(err, model.ErrInvalidCallbackURL) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, model.ErrQuotaExceeded) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_secret_quota_exceeded", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("subject", subject).
+				Msgf("Пользователь %s создал слишком много секретов: %s", subject, err)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		pgs.CounterService.Increment(ctx2, "grpc_create_secret_error", 1)
 		log.Error().Err(err).
 			Str("trace_id", span.SpanContext().TraceID().String()).
@@ -147,6 +244,594 @@ func (pgs *PurserGrpcServer) CreateSecret(ctx context.Context, request *proto.Ne
 		Str("trace_id", span.SpanContext().TraceID().String()).
 		Str("secret_id", secret.ID).
 		Str("subject", subject).
+		Interface("meta", pgs.SecretService.Redaction.Meta(secret.Meta)).
 		Msgf("Пользователь %s создал секрет %s", subject, secret.ID)
 	return convertModelToDto(secret), nil
 }
+
+// GetInbox возвращает секреты, адресованные субъекту JWT токена или его группам, без тел
+func (pgs *PurserGrpcServer) GetInbox(ctx context.Context, _ *proto.Nothing) (*proto.SecretList, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/GetInbox")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_inbox_called", 1)
+	secrets, err := pgs.SecretService.Inbox(ctx2, identity)
+	if err != nil {
+		pgs.CounterService.Increment(ctx2, "grpc_inbox_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при поиске входящих секретов пользователя %s: %s", identity.Subject, err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_inbox_success", 1)
+	ret := proto.SecretList{Secrets: make([]*proto.Secret, 0, len(secrets))}
+	for i := range secrets {
+		ret.Secrets = append(ret.Secrets, convertModelToDto(secrets[i]))
+	}
+	return &ret, nil
+}
+
+// ListSecrets постранично возвращает секреты, созданные субъектом JWT токена, без тел
+func (pgs *PurserGrpcServer) ListSecrets(ctx context.Context, request *proto.ListSecretsRequest) (*proto.SecretList, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/ListSecrets")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_list_secrets_called", 1)
+	if request.GetLimit() < 0 || request.GetLimit() > service.MaxListLimit {
+		pgs.CounterService.Increment(ctx2, "grpc_list_secrets_malformed", 1)
+		return nil, status.Errorf(codes.InvalidArgument, "limit should be between 0 and %v", service.MaxListLimit)
+	}
+	filter := repository.ListFilter{
+		Owner:   request.GetOwner(),
+		MetaKey: request.GetMetaKey(),
+		Cursor:  request.GetCursor(),
+		Limit:   request.GetLimit(),
+	}
+	if request.GetCreatedAfter() != nil {
+		filter.CreatedAfter = request.GetCreatedAfter().AsTime()
+	}
+	if request.GetCreatedBefore() != nil {
+		filter.CreatedBefore = request.GetCreatedBefore().AsTime()
+	}
+	secrets, next, err := pgs.SecretService.List(ctx2, identity, filter)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidCursor) {
+			pgs.CounterService.Increment(ctx2, "grpc_list_secrets_malformed", 1)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_list_secrets_forbidden", 1)
+			return nil, status.Errorf(codes.PermissionDenied, "secrets of %s are not visible to %s",
+				request.GetOwner(), identity.Subject)
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_list_secrets_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при получении списка секретов пользователя %s: %s", identity.Subject, err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_list_secrets_success", 1)
+	ret := proto.SecretList{Secrets: make([]*proto.Secret, 0, len(secrets)), Next: next}
+	for i := range secrets {
+		ret.Secrets = append(ret.Secrets, convertModelToDto(secrets[i]))
+	}
+	return &ret, nil
+}
+
+// UpdateSecret изменяет секрет, созданный субъектом JWT токена, и возвращает его без тела.
+// Если задана версия, а секрет был изменён кем-то ещё, возвращается codes.Aborted
+func (pgs *PurserGrpcServer) UpdateSecret(ctx context.Context, request *proto.UpdateSecretRequest) (*proto.Secret, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/UpdateSecret")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_update_secret_called", 1)
+	if request.GetTtl() < 0 || request.GetVersion() < 0 {
+		pgs.CounterService.Increment(ctx2, "grpc_update_secret_malformed", 1)
+		return nil, status.Errorf(codes.InvalidArgument, "ttl and version should not be negative")
+	}
+	meta := convertMetaDTO(request.GetMeta())
+	delete(meta, "body")
+	delete(meta, "Subject")
+	params := service.UpdateParams{
+		Body:        request.Body,
+		ContentType: request.GetContentType(),
+		Filename:    request.GetFilename(),
+		Meta:        meta,
+		ReplaceMeta: request.GetReplaceMeta(),
+		TTL:         time.Duration(request.GetTtl()) * time.Second,
+		Version:     request.GetVersion(),
+		Identity:    identity,
+	}
+	if request.GetExpireAt() != nil {
+		params.ExpireAt = request.GetExpireAt().AsTime()
+	}
+	secret, err := pgs.SecretService.Update(ctx2, request.GetId(), params)
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			pgs.CounterService.Increment(ctx2, "grpc_update_secret_not_found", 1)
+			return nil, status.Errorf(codes.NotFound, "secret %s is not found", request.GetId())
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_update_secret_forbidden", 1)
+			return nil, status.Errorf(codes.PermissionDenied, "secret %s is not created by %s", request.GetId(), identity.Subject)
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			pgs.CounterService.Increment(ctx2, "grpc_update_secret_conflict", 1)
+			return nil, status.Errorf(codes.Aborted, "secret %s is updated concurrently", request.GetId())
+		}
+		if vErr := convertValidationError(err); vErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_update_secret_rejected", 1)
+			return nil, vErr
+		}
+		if errors.Is(err, model.ErrTooLarge) {
+			pgs.CounterService.Increment(ctx2, "grpc_update_secret_too_large", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_update_secret_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", request.GetId()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при изменении секрета %s : %s", request.GetId(), err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_update_secret_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("secret_id", secret.ID).
+		Str("subject", identity.Subject).
+		Int64("version", secret.Version).
+		Msgf("Пользователь %s изменил секрет %s", identity.Subject, secret.ID)
+	return convertModelToDto(secret), nil
+}
+
+// QueryAudit постранично возвращает журнал аудита, он доступен только администраторам
+func (pgs *PurserGrpcServer) QueryAudit(ctx context.Context, request *proto.AuditQueryRequest) (*proto.AuditEventList, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/QueryAudit")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_query_audit_called", 1)
+	if request.GetLimit() < 0 || request.GetLimit() > service.MaxListLimit {
+		pgs.CounterService.Increment(ctx2, "grpc_query_audit_malformed", 1)
+		return nil, status.Errorf(codes.InvalidArgument, "limit should be between 0 and %v", service.MaxListLimit)
+	}
+	filter := repository.AuditFilter{
+		SecretID: request.GetSecretId(),
+		Subject:  request.GetSubject(),
+		Cursor:   request.GetCursor(),
+		Limit:    request.GetLimit(),
+	}
+	if request.GetSince() != nil {
+		filter.Since = request.GetSince().AsTime()
+	}
+	if request.GetUntil() != nil {
+		filter.Until = request.GetUntil().AsTime()
+	}
+	events, next, err := pgs.AuditService.Query(ctx2, identity, filter)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidCursor) {
+			pgs.CounterService.Increment(ctx2, "grpc_query_audit_malformed", 1)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_query_audit_forbidden", 1)
+			return nil, status.Errorf(codes.PermissionDenied, "audit log is not visible to %s", identity.Subject)
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_query_audit_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при получении журнала аудита: %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_query_audit_success", 1)
+	ret := proto.AuditEventList{Events: make([]*proto.AuditEvent, 0, len(events)), Next: next}
+	for i := range events {
+		ret.Events = append(ret.Events, convertAuditEventToDto(events[i]))
+	}
+	return &ret, nil
+}
+
+// GetSecretStatus сообщает создателю секрета, прочитан ли он, когда и кем, не засчитывая прочтение и не возвращая тело
+func (pgs *PurserGrpcServer) GetSecretStatus(ctx context.Context, request *proto.SecretByIDRequest) (*proto.SecretStatus, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/GetSecretStatus")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_get_status_called", 1)
+	secretStatus, err := pgs.SecretService.Status(ctx2, request.GetId(), identity)
+	if err != nil {
+		if gErr := convertGoneError(err); gErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_get_status_gone", 1)
+			return nil, gErr
+		}
+		if errors.Is(err, model.ErrSecretNotFound) {
+			pgs.CounterService.Increment(ctx2, "grpc_get_status_not_found", 1)
+			return nil, status.Errorf(codes.NotFound, "secret %s is not found", request.GetId())
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			pgs.CounterService.Increment(ctx2, "grpc_get_status_denied", 1)
+			return nil, status.Errorf(codes.PermissionDenied, "secret %s is not created by %s", request.GetId(), identity.Subject)
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_get_status_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", request.GetId()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при получении состояния секрета %s : %s", request.GetId(), err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_get_status_success", 1)
+	return convertStatusToDto(secretStatus), nil
+}
+
+// RedeemShareToken читает секрет по токену ссылки, выданному при его создании, JWT токен для этого не нужен
+func (pgs *PurserGrpcServer) RedeemShareToken(ctx context.Context, request *proto.ShareTokenRequest) (*proto.Secret, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/RedeemShareToken")
+	defer span.End()
+	pgs.CounterService.Increment(ctx2, "grpc_redeem_share_called", 1)
+	secret, err := pgs.SecretService.Redeem(ctx2, request.GetToken(), service.ReadOptions{
+		Burn:       request.GetBurn(),
+		Passphrase: request.GetPassphrase(),
+	})
+	if err != nil {
+		if gErr := convertGoneError(err); gErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_redeem_share_gone", 1)
+			return nil, gErr
+		}
+		if nErr := convertNotYetAvailableError(err); nErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_redeem_share_not_yet_available", 1)
+			return nil, nErr
+		}
+		if errors.Is(err, model.ErrSecretNotFound) {
+			pgs.CounterService.Increment(ctx2, "grpc_redeem_share_not_found", 1)
+			return nil, status.Error(codes.NotFound, "secret is not found")
+		}
+		if errors.Is(err, model.ErrPassphraseRequired) || errors.Is(err, model.ErrWrongPassphrase) {
+			pgs.CounterService.Increment(ctx2, "grpc_redeem_share_denied", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Доступ к секрету по ссылке запрещён: %s", err)
+			if errors.Is(err, model.ErrPassphraseRequired) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_redeem_share_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Ошибка при чтении секрета по ссылке: %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_redeem_share_success", 1)
+	if request.GetBurn() {
+		pgs.CounterService.Increment(ctx2, "grpc_redeem_share_burned", 1)
+	}
+	return convertSharedToDto(secret), nil
+}
+
+// convertRequestError превращает ошибки работы с запросом секрета в статусы и увеличивает счётчик с префиксом metric,
+// возвращая nil, если ошибка другая
+func (pgs *PurserGrpcServer) convertRequestError(ctx context.Context, metric string, err error) error {
+	switch {
+	case errors.Is(err, model.ErrRequestNotFound):
+		pgs.CounterService.Increment(ctx, metric+"_not_found", 1)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, model.ErrRequestFulfilled):
+		pgs.CounterService.Increment(ctx, metric+"_gone", 1)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, model.ErrForbidden):
+		pgs.CounterService.Increment(ctx, metric+"_forbidden", 1)
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return nil
+	}
+}
+
+// CreateSecretRequest запрашивает секрет и возвращает токен одноразовой ссылки, по которой его пришлют
+func (pgs *PurserGrpcServer) CreateSecretRequest(ctx context.Context, request *proto.NewSecretRequestParams) (*proto.SecretRequestInfo, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/CreateSecretRequest")
+	defer span.End()
+	subject, err := pgs.extractJwtSubject(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", subject))
+	pgs.CounterService.Increment(ctx2, "grpc_create_request_called", 1)
+	if request.GetDescription() == "" {
+		pgs.CounterService.Increment(ctx2, "grpc_create_request_malformed", 1)
+		return nil, status.Error(codes.InvalidArgument, "description is required")
+	}
+	params := model.SecretRequestParams{
+		Requester:   subject,
+		Description: request.GetDescription(),
+		TTL:         time.Duration(request.GetTtl()) * time.Second,
+	}
+	if request.GetExpireAt() != nil {
+		params.ExpireAt = request.GetExpireAt().AsTime()
+	}
+	secretRequest, err := pgs.SecretService.CreateRequest(ctx2, params)
+	if err != nil {
+		if errors.Is(err, model.ErrTooLarge) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_request_too_large", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_create_request_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", subject).
+			Msgf("Ошибка при создании запроса секрета : %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_create_request_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("request_id", secretRequest.ID).
+		Str("subject", subject).
+		Msgf("Пользователь %s запросил секрет, запрос %s", subject, secretRequest.ID)
+	return convertRequestToDto(secretRequest), nil
+}
+
+// GetSecretRequest возвращает запрос секрета его запрашивающему
+func (pgs *PurserGrpcServer) GetSecretRequest(ctx context.Context, request *proto.SecretRequestByIDRequest) (*proto.SecretRequestInfo, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/GetSecretRequest")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_get_request_called", 1)
+	secretRequest, err := pgs.SecretService.GetRequest(ctx2, request.GetId(), identity)
+	if err != nil {
+		if rErr := pgs.convertRequestError(ctx2, "grpc_get_request", err); rErr != nil {
+			return nil, rErr
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_get_request_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("request_id", request.GetId()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при поиске запроса секрета %s : %s", request.GetId(), err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_get_request_success", 1)
+	return convertRequestToDto(secretRequest), nil
+}
+
+// DeleteSecretRequest удаляет запрос секрета по просьбе запрашивающего
+func (pgs *PurserGrpcServer) DeleteSecretRequest(ctx context.Context, request *proto.SecretRequestByIDRequest) (*proto.Nothing, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/DeleteSecretRequest")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_delete_request_called", 1)
+	err = pgs.SecretService.DeleteRequest(ctx2, request.GetId(), identity)
+	if err != nil {
+		if rErr := pgs.convertRequestError(ctx2, "grpc_delete_request", err); rErr != nil {
+			return nil, rErr
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_delete_request_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("request_id", request.GetId()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при удалении запроса секрета %s : %s", request.GetId(), err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_delete_request_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("request_id", request.GetId()).
+		Str("subject", identity.Subject).
+		Msgf("Пользователь %s удалил запрос секрета %s", identity.Subject, request.GetId())
+	return &proto.Nothing{}, nil
+}
+
+// DescribeSecretRequest возвращает описание и срок запроса секрета по токену одноразовой ссылки,
+// JWT токен для этого не нужен
+func (pgs *PurserGrpcServer) DescribeSecretRequest(ctx context.Context, request *proto.SecretRequestTokenRequest) (*proto.SecretRequestInfo, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/DescribeSecretRequest")
+	defer span.End()
+	pgs.CounterService.Increment(ctx2, "grpc_describe_request_called", 1)
+	secretRequest, err := pgs.SecretService.DescribeRequest(ctx2, request.GetToken())
+	if err != nil {
+		if rErr := pgs.convertRequestError(ctx2, "grpc_describe_request", err); rErr != nil {
+			return nil, rErr
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_describe_request_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Ошибка при поиске запроса секрета по ссылке: %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_describe_request_success", 1)
+	return &proto.SecretRequestInfo{
+		Description: secretRequest.Description,
+		ExpireAt:    timestamppb.New(secretRequest.ExpireAt),
+	}, nil
+}
+
+// FulfilSecretRequest присылает секрет по токену одноразовой ссылки на запрос, JWT токен для этого не нужен
+func (pgs *PurserGrpcServer) FulfilSecretRequest(ctx context.Context, request *proto.FulfilSecretRequestRequest) (*proto.SecretRequestInfo, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/FulfilSecretRequest")
+	defer span.End()
+	pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_called", 1)
+	params, err := convertNewSecretDTO(request.GetSecret())
+	if err != nil {
+		pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_malformed", 1)
+		return nil, err
+	}
+	if len(params.Body) == 0 {
+		pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_malformed", 1)
+		return nil, status.Error(codes.InvalidArgument, "body is required")
+	}
+	params.Meta = convertMetaDTO(request.GetSecret().GetMeta())
+	md, found := metadata.FromIncomingContext(ctx)
+	if found && len(md.Get("User-Agent")) > 0 {
+		params.Meta["User-Agent"] = md.Get("User-Agent")[0]
+	}
+	secretRequest, err := pgs.SecretService.FulfilRequest(ctx2, request.GetToken(), params)
+	if err != nil {
+		if rErr := pgs.convertRequestError(ctx2, "grpc_fulfil_request", err); rErr != nil {
+			return nil, rErr
+		}
+		if errors.Is(err, model.ErrTooLarge) {
+			pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_too_large", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if vErr := convertValidationError(err); vErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_rejected", 1)
+			return nil, vErr
+		}
+		if errors.Is(err, model.ErrQuotaExceeded) {
+			pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_quota_exceeded", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Ошибка при отправке секрета по запросу: %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_fulfil_request_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("request_id", secretRequest.ID).
+		Str("secret_id", secretRequest.SecretID).
+		Interface("meta", pgs.SecretService.Redaction.Meta(params.Meta)).
+		Msgf("По запросу %s прислали секрет %s", secretRequest.ID, secretRequest.SecretID)
+	return &proto.SecretRequestInfo{
+		FulfilledAt: timestamppb.New(secretRequest.FulfilledAt),
+	}, nil
+}
+
+// CreateSplit делит секрет по схеме Шамира на доли и сохраняет каждую отдельным секретом своего хранителя
+func (pgs *PurserGrpcServer) CreateSplit(ctx context.Context, request *proto.NewSplitRequest) (*proto.Split, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/CreateSplit")
+	defer span.End()
+	subject, err := pgs.extractJwtSubject(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", subject))
+	pgs.CounterService.Increment(ctx2, "grpc_create_split_called", 1)
+	params, err := convertNewSecretDTO(request.GetSecret())
+	if err != nil {
+		pgs.CounterService.Increment(ctx2, "grpc_create_split_malformed", 1)
+		return nil, err
+	}
+	params.Meta = convertMetaDTO(request.GetSecret().GetMeta())
+	md, found := metadata.FromIncomingContext(ctx)
+	if found && len(md.Get("User-Agent")) > 0 {
+		params.Meta["User-Agent"] = md.Get("User-Agent")[0]
+	}
+	params.Owner = subject
+	split, err := pgs.SecretService.CreateSplit(ctx2, model.SplitParams{
+		SecretParams: params,
+		Holders:      request.GetHolders(),
+		Threshold:    int(request.GetThreshold()),
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidSplit) || errors.Is(err, model.ErrInvalidCallbackURL) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_split_malformed", 1)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, model.ErrTooLarge) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_split_too_large", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if vErr := convertValidationError(err); vErr != nil {
+			pgs.CounterService.Increment(ctx2, "grpc_create_split_rejected", 1)
+			return nil, vErr
+		}
+		if errors.Is(err, model.ErrQuotaExceeded) {
+			pgs.CounterService.Increment(ctx2, "grpc_create_split_quota_exceeded", 1)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_create_split_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", subject).
+			Msgf("Ошибка при разделении секрета : %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_create_split_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("split_id", split.ID).
+		Str("subject", subject).
+		Interface("meta", pgs.SecretService.Redaction.Meta(params.Meta)).
+		Msgf("Пользователь %s разделил секрет %s на %v долей с порогом %v",
+			subject, split.ID, len(split.Shares), split.Threshold)
+	return convertSplitToDto(split), nil
+}
+
+// CombineShares восстанавливает секрет из долей, которые хранители прочитали из своих секретов
+func (pgs *PurserGrpcServer) CombineShares(ctx context.Context, request *proto.CombineSharesRequest) (*proto.CombinedSecret, error) {
+	ctx2, span := pgs.SecretService.Tracer.Start(ctx, "transport/grpc/CombineShares")
+	defer span.End()
+	identity, err := pgs.extractIdentity(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+	span.AddEvent("JWT token validated")
+	span.SetAttributes(attribute.String("subject", identity.Subject))
+	pgs.CounterService.Increment(ctx2, "grpc_combine_shares_called", 1)
+	body, err := pgs.SecretService.Combine(ctx2, request.GetShares(), identity)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidShares) {
+			pgs.CounterService.Increment(ctx2, "grpc_combine_shares_rejected", 1)
+			log.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("subject", identity.Subject).
+				Msgf("Пользователь %s прислал негодные доли: %s", identity.Subject, err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pgs.CounterService.Increment(ctx2, "grpc_combine_shares_error", 1)
+		log.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("subject", identity.Subject).
+			Msgf("Ошибка при восстановлении секрета : %s", err)
+		return nil, err
+	}
+	pgs.CounterService.Increment(ctx2, "grpc_combine_shares_success", 1)
+	log.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("subject", identity.Subject).
+		Msgf("Пользователь %s восстановил секрет из %v долей", identity.Subject, len(request.GetShares()))
+	return &proto.CombinedSecret{Body: body}, nil
+}
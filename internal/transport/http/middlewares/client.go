@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/service"
+	"github.com/vodolaz095/purser/model"
+)
+
+// IdentifyClient сохраняет в контексте запроса адрес и User-Agent клиента, чтобы они попали в журнал аудита
+func IdentifyClient() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(service.WithClient(c.Request.Context(), model.Client{
+			Transport: "http",
+			RemoteIP:  c.ClientIP(),
+			UserAgent: c.GetHeader("User-Agent"),
+		}))
+		c.Next()
+	}
+}
@@ -26,7 +26,7 @@ func CheckJWT() func(c *gin.Context) {
 		}
 
 		token := strings.TrimPrefix(header, "Bearer ")
-		subject, err := jwt.ValidateJwtAndExtractSubject(token, config.JwtSecret)
+		subject, groups, err := jwt.ValidateJwtAndExtractIdentity(token, config.JwtSecret)
 		if err != nil {
 			c.String(http.StatusBadRequest, "Error parsing token: %s", err)
 			c.Abort()
@@ -35,6 +35,7 @@ func CheckJWT() func(c *gin.Context) {
 		// также тут можно вызывать некое хранилище отозванных токенов, чтобы проверить,
 		// что этот ещё работает, а также можно проверить роли и разрешения пользователя
 		c.Set("subject", subject)
+		c.Set("groups", groups)
 		c.Next()
 	}
 }
@@ -8,10 +8,10 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-// UseTracing adds open telemetry tracing
-func UseTracing() func(c *gin.Context) {
+// UseTracing adds open telemetry tracing, redactPath hides sensitive parts of request path in span names
+func UseTracing(redactPath func(path string) string) func(c *gin.Context) {
 	return otelgin.Middleware("purser_rest",
 		otelgin.WithSpanNameFormatter(func(r *http.Request) string {
-			return r.Method + " " + r.URL.Path
+			return r.Method + " " + redactPath(r.URL.Path)
 		}))
 }
@@ -20,6 +20,7 @@ type Options struct {
 	ListenOn       string
 	Hostname       string
 	SecretService  *service.SecretService
+	AuditService   *service.AuditService
 	CounterService *service.CounterService
 }
 
@@ -53,7 +54,8 @@ func Serve(ctx context.Context, opts Options) error {
 	}()
 	app.Use(
 		middlewares.EmulatePHP(),
-		middlewares.UseTracing(),
+		middlewares.UseTracing(redactPath),
+		middlewares.IdentifyClient(),
 		middlewares.Secure(),
 		middlewares.AddPermissionPolicyHeader(),
 	)
@@ -61,11 +63,19 @@ func Serve(ctx context.Context, opts Options) error {
 		Engine:         app,
 		Hostname:       opts.Hostname,
 		SecretService:  opts.SecretService,
+		AuditService:   opts.AuditService,
 		CounterService: opts.CounterService,
 	}
 
 	tr.ExposeHealthChecks()
 	tr.ExposeSecretAPI()
+	tr.ExposeShareAPI()
+	tr.ExposeRequestAPI()
+	tr.ExposeSplitAPI()
+	tr.ExposeInboxAPI()
+	tr.ExposeVersionsAPI()
+	tr.ExposeStatusAPI()
+	tr.ExposeAuditAPI()
 	tr.ExposeMetrics()
 
 	if !config.IsProduction() {
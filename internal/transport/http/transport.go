@@ -10,5 +10,6 @@ type Transport struct {
 	Engine         *gin.Engine
 	Hostname       string
 	SecretService  *service.SecretService
+	AuditService   *service.AuditService
 	CounterService *service.CounterService
 }
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+	"github.com/vodolaz095/purser/model"
+)
+
+type auditQueryRequest struct {
+	// SecretID - секрет, с которым случились события
+	SecretID string `form:"secretId"`
+	// Subject - субъект, действия которого записаны
+	Subject string `form:"subject"`
+	// Since - события случились не раньше этого момента
+	Since time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Until - события случились раньше этого момента
+	Until time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Cursor - курсор из поля next предыдущей страницы
+	Cursor string `form:"cursor"`
+	// Limit - сколько событий на странице
+	Limit int64 `form:"limit" binding:"gte=0,lte=100"`
+}
+
+type auditQueryResponse struct {
+	Events []model.AuditEvent `json:"events"`
+	// Next - курсор следующей страницы, пустой, если страниц больше нет
+	Next string `json:"next"`
+}
+
+// ExposeAuditAPI включает ответчик с журналом аудита, он доступен только администраторам
+func (tr *Transport) ExposeAuditAPI() {
+	audit := tr.Engine.Group("/api/v1/audit")
+	audit.Use(middlewares.CheckJWT())
+
+	audit.GET("/", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/QueryAudit")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_query_audit_called", 1)
+		var query auditQueryRequest
+		if err := c.ShouldBindQuery(&query); err != nil {
+			tr.CounterService.Increment(ctx2, "http_query_audit_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		events, next, err := tr.AuditService.Query(ctx2, makeIdentity(c), repository.AuditFilter{
+			SecretID: query.SecretID,
+			Subject:  query.Subject,
+			Since:    query.Since,
+			Until:    query.Until,
+			Cursor:   query.Cursor,
+			Limit:    query.Limit,
+		})
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidCursor) {
+				tr.CounterService.Increment(ctx2, "http_query_audit_malformed", 1)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, model.ErrForbidden) {
+				tr.CounterService.Increment(ctx2, "http_query_audit_forbidden", 1)
+				logger.Warn().
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Журнал аудита запрошен не администратором")
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				c.Abort()
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_query_audit_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при получении журнала аудита: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_query_audit_success", 1)
+		c.JSON(http.StatusOK, auditQueryResponse{Events: events, Next: next})
+	})
+}
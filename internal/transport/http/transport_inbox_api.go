@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+)
+
+// ExposeInboxAPI включает ответчик, который показывает секреты, адресованные пользователю или его группам.
+// Тела секретов не выдаются, их надо читать по идентификатору через ExposeSecretAPI
+func (tr *Transport) ExposeInboxAPI() {
+	inbox := tr.Engine.Group("/api/v1/inbox")
+	inbox.Use(middlewares.CheckJWT())
+
+	inbox.GET("/", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/Inbox")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_inbox_called", 1)
+		secrets, err := tr.SecretService.Inbox(ctx2, makeIdentity(c))
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_inbox_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при поиске входящих секретов: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_inbox_success", 1)
+		logger.Debug().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Найдено %v входящих секретов", len(secrets))
+		ret := make([]secretResponse, 0, len(secrets))
+		for i := range secrets {
+			ret = append(ret, makeSecretResponse(secrets[i]))
+		}
+		c.JSON(http.StatusOK, ret)
+	})
+}
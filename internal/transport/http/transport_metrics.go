@@ -14,33 +14,246 @@ import (
 // metricsToExpose задают метрики, которые экспортируются в Prometheus. Я их нашёл
 // такой командой из кода `$ grep "CounterService.Increment" internal/transport/**/*.go`
 var metricsToExpose = []string{
+	"grpc_redeem_share_called",
+	"grpc_redeem_share_not_found",
+	"grpc_redeem_share_gone",
+	"grpc_redeem_share_not_yet_available",
+	"grpc_redeem_share_error",
+	"grpc_redeem_share_success",
+	"grpc_redeem_share_burned",
+	"grpc_redeem_share_denied",
 	"grpc_get_secret_called",
 	"grpc_get_secret_not_found",
+	"grpc_get_secret_gone",
+	"grpc_get_secret_not_yet_available",
 	"grpc_get_secret_error",
 	"grpc_get_secret_success",
+	"grpc_get_secret_burned",
+	"grpc_get_secret_denied",
+	"grpc_get_secret_forbidden",
 	"grpc_delete_secret_called",
 	"grpc_delete_secret_not_found",
+	"grpc_delete_secret_gone",
+	"grpc_delete_secret_forbidden",
 	"grpc_delete_secret_error",
 	"grpc_delete_secret_success",
+	"grpc_inbox_called",
+	"grpc_inbox_error",
+	"grpc_inbox_success",
+	"grpc_list_secrets_called",
+	"grpc_list_secrets_malformed",
+	"grpc_list_secrets_forbidden",
+	"grpc_list_secrets_error",
+	"grpc_list_secrets_success",
+	"grpc_query_audit_called",
+	"grpc_query_audit_malformed",
+	"grpc_query_audit_forbidden",
+	"grpc_query_audit_error",
+	"grpc_query_audit_success",
+	"grpc_get_status_called",
+	"grpc_get_status_not_found",
+	"grpc_get_status_gone",
+	"grpc_get_status_denied",
+	"grpc_get_status_error",
+	"grpc_get_status_success",
 	"grpc_create_secret_called",
 	"grpc_create_secret_error",
+	"grpc_create_secret_too_large",
+	"grpc_create_secret_quota_exceeded",
+	"grpc_create_secret_rejected",
 	"grpc_create_secret_success",
+	"grpc_update_secret_called",
+	"grpc_update_secret_malformed",
+	"grpc_update_secret_not_found",
+	"grpc_update_secret_forbidden",
+	"grpc_update_secret_conflict",
+	"grpc_update_secret_too_large",
+	"grpc_update_secret_rejected",
+	"grpc_update_secret_error",
+	"grpc_update_secret_success",
+	"grpc_create_request_called",
+	"grpc_create_request_malformed",
+	"grpc_create_request_too_large",
+	"grpc_create_request_error",
+	"grpc_create_request_success",
+	"grpc_get_request_called",
+	"grpc_get_request_not_found",
+	"grpc_get_request_gone",
+	"grpc_get_request_forbidden",
+	"grpc_get_request_error",
+	"grpc_get_request_success",
+	"grpc_delete_request_called",
+	"grpc_delete_request_not_found",
+	"grpc_delete_request_gone",
+	"grpc_delete_request_forbidden",
+	"grpc_delete_request_error",
+	"grpc_delete_request_success",
+	"grpc_describe_request_called",
+	"grpc_describe_request_not_found",
+	"grpc_describe_request_gone",
+	"grpc_describe_request_forbidden",
+	"grpc_describe_request_error",
+	"grpc_describe_request_success",
+	"grpc_fulfil_request_called",
+	"grpc_fulfil_request_malformed",
+	"grpc_fulfil_request_too_large",
+	"grpc_fulfil_request_not_found",
+	"grpc_fulfil_request_gone",
+	"grpc_fulfil_request_forbidden",
+	"grpc_fulfil_request_rejected",
+	"grpc_fulfil_request_quota_exceeded",
+	"grpc_fulfil_request_error",
+	"grpc_fulfil_request_success",
+	"grpc_create_split_called",
+	"grpc_create_split_malformed",
+	"grpc_create_split_too_large",
+	"grpc_create_split_rejected",
+	"grpc_create_split_quota_exceeded",
+	"grpc_create_split_error",
+	"grpc_create_split_success",
+	"grpc_combine_shares_called",
+	"grpc_combine_shares_malformed",
+	"grpc_combine_shares_rejected",
+	"grpc_combine_shares_error",
+	"grpc_combine_shares_success",
 	"ping_http",
 	"healthcheck_http_called",
 	"healthcheck_http_failed",
 	"healthcheck_http_ok",
+	"http_redeem_share_called",
+	"http_redeem_share_not_found",
+	"http_redeem_share_gone",
+	"http_redeem_share_not_yet_available",
+	"http_redeem_share_error",
+	"http_redeem_share_success",
+	"http_redeem_share_burned",
+	"http_redeem_share_denied",
 	"http_get_secret_called",
 	"http_get_secret_not_found",
+	"http_get_secret_gone",
+	"http_get_secret_not_yet_available",
 	"http_get_secret_error",
 	"http_get_secret_success",
+	"http_get_secret_burned",
+	"http_get_secret_denied",
+	"http_get_secret_forbidden",
+	"http_download_secret_called",
+	"http_download_secret_not_found",
+	"http_download_secret_gone",
+	"http_download_secret_not_yet_available",
+	"http_download_secret_error",
+	"http_download_secret_success",
+	"http_download_secret_burned",
+	"http_download_secret_denied",
+	"http_download_secret_forbidden",
 	"http_delete_secret_called",
 	"http_delete_secret_not_found",
+	"http_delete_secret_gone",
+	"http_delete_secret_forbidden",
 	"http_delete_secret_error",
 	"http_delete_secret_success",
+	"http_inbox_called",
+	"http_inbox_error",
+	"http_inbox_success",
+	"http_list_secrets_called",
+	"http_list_secrets_malformed",
+	"http_list_secrets_forbidden",
+	"http_list_secrets_error",
+	"http_list_secrets_success",
+	"http_query_audit_called",
+	"http_query_audit_malformed",
+	"http_query_audit_forbidden",
+	"http_query_audit_error",
+	"http_query_audit_success",
 	"http_create_secret_called",
 	"http_create_secret_malformed",
+	"http_create_secret_too_large",
+	"http_create_secret_quota_exceeded",
+	"http_create_secret_rejected",
 	"http_create_secret_error",
 	"http_create_secret_success",
+	"http_update_secret_called",
+	"http_update_secret_malformed",
+	"http_update_secret_not_found",
+	"http_update_secret_forbidden",
+	"http_update_secret_conflict",
+	"http_update_secret_too_large",
+	"http_update_secret_rejected",
+	"http_update_secret_error",
+	"http_update_secret_success",
+	"http_list_versions_called",
+	"http_list_versions_not_found",
+	"http_list_versions_denied",
+	"http_list_versions_error",
+	"http_list_versions_success",
+	"http_get_version_called",
+	"http_get_version_malformed",
+	"http_get_version_not_found",
+	"http_get_version_denied",
+	"http_get_version_error",
+	"http_get_version_success",
+	"http_rollback_called",
+	"http_rollback_malformed",
+	"http_rollback_not_found",
+	"http_rollback_denied",
+	"http_rollback_conflict",
+	"http_rollback_error",
+	"http_rollback_success",
+	"http_get_status_called",
+	"http_get_status_not_found",
+	"http_get_status_gone",
+	"http_get_status_denied",
+	"http_get_status_error",
+	"http_get_status_success",
+	"http_create_request_called",
+	"http_create_request_malformed",
+	"http_create_request_too_large",
+	"http_create_request_error",
+	"http_create_request_success",
+	"http_get_request_called",
+	"http_get_request_not_found",
+	"http_get_request_gone",
+	"http_get_request_forbidden",
+	"http_get_request_error",
+	"http_get_request_success",
+	"http_delete_request_called",
+	"http_delete_request_not_found",
+	"http_delete_request_gone",
+	"http_delete_request_forbidden",
+	"http_delete_request_error",
+	"http_delete_request_success",
+	"http_describe_request_called",
+	"http_describe_request_not_found",
+	"http_describe_request_gone",
+	"http_describe_request_forbidden",
+	"http_describe_request_error",
+	"http_describe_request_success",
+	"http_fulfil_request_called",
+	"http_fulfil_request_malformed",
+	"http_fulfil_request_too_large",
+	"http_fulfil_request_not_found",
+	"http_fulfil_request_gone",
+	"http_fulfil_request_forbidden",
+	"http_fulfil_request_rejected",
+	"http_fulfil_request_quota_exceeded",
+	"http_fulfil_request_error",
+	"http_fulfil_request_success",
+	"http_create_split_called",
+	"http_create_split_malformed",
+	"http_create_split_too_large",
+	"http_create_split_rejected",
+	"http_create_split_quota_exceeded",
+	"http_create_split_error",
+	"http_create_split_success",
+	"http_combine_shares_called",
+	"http_combine_shares_malformed",
+	"http_combine_shares_rejected",
+	"http_combine_shares_error",
+	"http_combine_shares_success",
+	"webhook_queued",
+	"webhook_delivered",
+	"webhook_retried",
+	"webhook_dead_letter",
 }
 
 // ExposeMetrics включает ответчики для получения метрик в формате Prometheus
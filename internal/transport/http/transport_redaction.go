@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/vodolaz095/purser/internal/service"
+)
+
+// redactPath скрывает в пути запроса токен ссылки на секрет, который сам даёт право его прочитать,
+// и токен одноразовой ссылки на запрос секрета, который даёт право прислать секрет
+func redactPath(path string) string {
+	if strings.HasPrefix(path, sharePath) {
+		return sharePath + service.RedactedValue
+	}
+	if strings.HasPrefix(path, requestPath) {
+		return requestPath + service.RedactedValue
+	}
+	return path
+}
+
+// redactEndpoint возвращает адрес запроса для журнала: токен ссылки скрывается, а значения метаданных,
+// переданных в строке запроса как meta[название]=значение, записываются согласно политике скрытия
+func (tr *Transport) redactEndpoint(u *url.URL) string {
+	endpoint := redactPath(u.Path)
+	if u.RawQuery == "" {
+		return endpoint
+	}
+	pairs := strings.Split(u.RawQuery, "&")
+	for i := range pairs {
+		rawKey, rawValue, _ := strings.Cut(pairs[i], "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			pairs[i] = rawKey + "=" + service.RedactedValue
+			continue
+		}
+		if !strings.HasPrefix(key, "meta[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		field := strings.TrimSuffix(strings.TrimPrefix(key, "meta["), "]")
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			pairs[i] = rawKey + "=" + service.RedactedValue
+			continue
+		}
+		redacted := tr.SecretService.Redaction.Value(field, value)
+		if redacted != value {
+			pairs[i] = rawKey + "=" + redacted
+		}
+	}
+	return endpoint + "?" + strings.Join(pairs, "&")
+}
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+	"github.com/vodolaz095/purser/model"
+)
+
+// requestPath задаёт путь, по которому секрет присылают по токену одноразовой ссылки на запрос
+const requestPath = "/r/"
+
+type createRequestRequest struct {
+	// Description - описание того, какой секрет нужен, его увидит тот, кто пришлёт секрет
+	Description string `json:"description" binding:"required"`
+	// TTL - желаемый срок действия запроса в секундах
+	TTL int64 `json:"ttl" binding:"gte=0"`
+	// ExpireAt - желаемый момент устаревания запроса, имеет приоритет перед TTL
+	ExpireAt time.Time `json:"expireAt"`
+}
+
+type createdRequestResponse struct {
+	ID string `json:"id"`
+	// FulfilToken - токен одноразовой ссылки, по которой присылают секрет, больше его нигде не узнать
+	FulfilToken string `json:"fulfilToken"`
+	// FulfilURL - путь одноразовой ссылки, по которой присылают секрет
+	FulfilURL string    `json:"fulfilUrl"`
+	ExpireAt  time.Time `json:"expireAt"`
+}
+
+// describedRequestResponse - запрос секрета, каким его видит тот, кто пришлёт секрет
+type describedRequestResponse struct {
+	Description string    `json:"description"`
+	ExpireAt    time.Time `json:"expireAt"`
+}
+
+type fulfilledRequestResponse struct {
+	FulfilledAt time.Time `json:"fulfilledAt"`
+}
+
+// abortWithRequestError отвечает на ошибки работы с запросом секрета, которые не зависят от ответчика,
+// и увеличивает счётчик с префиксом metric. Если ошибка другая, возвращается ложь
+func (tr *Transport) abortWithRequestError(c *gin.Context, metric string, err error) bool {
+	switch {
+	case errors.Is(err, model.ErrRequestNotFound):
+		tr.CounterService.Increment(c.Request.Context(), metric+"_not_found", 1)
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrRequestFulfilled):
+		tr.CounterService.Increment(c.Request.Context(), metric+"_gone", 1)
+		c.AbortWithStatusJSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errors.Is(err, model.ErrForbidden):
+		tr.CounterService.Increment(c.Request.Context(), metric+"_forbidden", 1)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// ExposeRequestAPI включает ответчики, которыми запрашивающий создаёт запрос секрета с описанием
+// и одноразовой ссылкой, а другая сторона по этой ссылке без JWT токена узнаёт, что нужно, и присылает секрет
+func (tr *Transport) ExposeRequestAPI() {
+	rest := tr.Engine.Group("/api/v1/request")
+	rest.Use(middlewares.CheckJWT())
+
+	rest.POST("/", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/CreateSecretRequest")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_create_request_called", 1)
+		var bdy createRequestRequest
+		if err := c.ShouldBindJSON(&bdy); err != nil {
+			tr.CounterService.Increment(ctx2, "http_create_request_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		identity := makeIdentity(c)
+		request, err := tr.SecretService.CreateRequest(ctx2, model.SecretRequestParams{
+			Requester:   identity.Subject,
+			Description: bdy.Description,
+			TTL:         time.Duration(bdy.TTL) * time.Second,
+			ExpireAt:    bdy.ExpireAt,
+		})
+		if err != nil {
+			if errors.Is(err, model.ErrTooLarge) {
+				tr.CounterService.Increment(ctx2, "http_create_request_too_large", 1)
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_create_request_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при создании запроса секрета: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_create_request_success", 1)
+		logger.Info().
+			Str("request_id", request.ID).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Пользователь %s запросил секрет, запрос %s", identity.Subject, request.ID)
+		c.Header("Location", "/api/v1/request/"+request.ID)
+		c.JSON(http.StatusCreated, createdRequestResponse{
+			ID:          request.ID,
+			FulfilToken: request.Token,
+			FulfilURL:   requestPath + request.Token,
+			ExpireAt:    request.ExpireAt,
+		})
+	})
+	rest.GET("/:id", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/GetSecretRequest")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_get_request_called", 1)
+		request, err := tr.SecretService.GetRequest(ctx2, id, makeIdentity(c))
+		if err != nil {
+			if tr.abortWithRequestError(c, "http_get_request", err) {
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_get_request_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("request_id", id).
+				Msgf("Ошибка при поиске запроса секрета %s : %s", id, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_get_request_success", 1)
+		c.JSON(http.StatusOK, request)
+	})
+	rest.DELETE("/:id", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/DeleteSecretRequest")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_delete_request_called", 1)
+		err := tr.SecretService.DeleteRequest(ctx2, id, makeIdentity(c))
+		if err != nil {
+			if tr.abortWithRequestError(c, "http_delete_request", err) {
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_delete_request_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("request_id", id).
+				Msgf("Ошибка при удалении запроса секрета %s : %s", id, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_delete_request_success", 1)
+		logger.Info().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("request_id", id).
+			Msgf("Запрос секрета %s удалён", id)
+		c.AbortWithStatus(http.StatusNoContent)
+	})
+
+	// по одноразовой ссылке JWT токен не нужен, право даёт сам токен ссылки
+	tr.Engine.GET(requestPath+":token", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/DescribeSecretRequest")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		c.Header("Cache-Control", "no-store")
+		tr.CounterService.Increment(ctx2, "http_describe_request_called", 1)
+		request, err := tr.SecretService.DescribeRequest(ctx2, c.Param("token"))
+		if err != nil {
+			if tr.abortWithRequestError(c, "http_describe_request", err) {
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_describe_request_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при поиске запроса секрета по ссылке: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_describe_request_success", 1)
+		c.JSON(http.StatusOK, describedRequestResponse{
+			Description: request.Description,
+			ExpireAt:    request.ExpireAt,
+		})
+	})
+	tr.Engine.POST(requestPath+":token", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/FulfilSecretRequest")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_fulfil_request_called", 1)
+		tr.limitRequestBody(c)
+		params, err := bindSecret(c)
+		if err != nil && tooLarge(err) {
+			tr.CounterService.Increment(ctx2, "http_fulfil_request_too_large", 1)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_fulfil_request_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if params.Meta == nil {
+			params.Meta = make(map[string]string, 0)
+		}
+		delete(params.Meta, "body")
+		params.Meta["User-Agent"] = c.Request.Header.Get("User-Agent")
+		request, err := tr.SecretService.FulfilRequest(ctx2, c.Param("token"), params)
+		if err != nil {
+			if tr.abortWithRequestError(c, "http_fulfil_request", err) {
+				return
+			}
+			if errors.Is(err, model.ErrTooLarge) {
+				tr.CounterService.Increment(ctx2, "http_fulfil_request_too_large", 1)
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			if policyViolation(c, err) {
+				tr.CounterService.Increment(ctx2, "http_fulfil_request_rejected", 1)
+				return
+			}
+			if errors.Is(err, model.ErrQuotaExceeded) {
+				tr.CounterService.Increment(ctx2, "http_fulfil_request_quota_exceeded", 1)
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_fulfil_request_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при отправке секрета по запросу: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_fulfil_request_success", 1)
+		logger.Info().
+			Str("request_id", request.ID).
+			Str("secret_id", request.SecretID).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Interface("meta", tr.SecretService.Redaction.Meta(params.Meta)).
+			Msgf("По запросу %s прислали секрет %s", request.ID, request.SecretID)
+		c.JSON(http.StatusCreated, fulfilledRequestResponse{
+			FulfilledAt: request.FulfilledAt,
+		})
+	})
+}
@@ -1,14 +1,25 @@
 package http
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/service"
 	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
 	"github.com/vodolaz095/purser/model"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Документация по теме
@@ -17,14 +28,120 @@ import (
 type createSecretRequest struct {
 	Body string            `json:"body" binding:"required"`
 	Meta map[string]string `json:"meta"`
+	// ContentType - MIME тип тела секрета, если не задан - определяется по содержимому
+	ContentType string `json:"contentType"`
+	// Filename - имя файла, под которым тело секрета отдаётся на скачивание
+	Filename string `json:"filename"`
+	// TTL - желаемый срок жизни секрета в секундах
+	TTL int64 `json:"ttl" binding:"gte=0"`
+	// ExpireAt - желаемый момент устаревания секрета, имеет приоритет перед TTL
+	ExpireAt time.Time `json:"expireAt"`
+	// NotBefore - момент, начиная с которого секрет можно прочитать, срок жизни отсчитывается от него
+	NotBefore time.Time `json:"notBefore"`
+	// MaxViews - сколько раз можно прочитать секрет, 0 - без ограничений
+	MaxViews int64 `json:"maxViews" binding:"gte=0"`
+	// Passphrase - кодовая фраза, которую надо будет передать в заголовке PassphraseHeader, чтобы прочитать секрет
+	Passphrase string `json:"passphrase" binding:"max=72"`
+	// Recipients - субъекты JWT токенов, которым, кроме создателя, можно прочитать секрет
+	Recipients []string `json:"recipients"`
+	// Groups - группы из claim groups JWT токенов, участникам которых можно прочитать секрет
+	Groups []string `json:"groups"`
+	// CallbackURL - адрес, на который создателю сообщается о прочтении, удалении или устаревании секрета
+	CallbackURL string `json:"callbackUrl"`
+	// Opaque - тело зашифровано клиентом, сервер хранит его как есть
+	Opaque bool `json:"opaque"`
 }
 
-func makeLogger(c *gin.Context) zerolog.Logger {
+// PassphraseHeader задаёт заголовок запроса, в котором передаётся кодовая фраза для чтения секрета
+const PassphraseHeader = "X-Passphrase"
+
+type secretResponse struct {
+	model.Secret
+	// Body - тело секрета, текстом, если это UTF-8, иначе в base64
+	Body string `json:"body"`
+	// BodyEncoding - base64, если тело закодировано в base64, иначе пустое
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+	// ViewsLeft - сколько раз ещё можно прочитать секрет, -1 - без ограничений
+	ViewsLeft int64 `json:"viewsLeft"`
+}
+
+// makeSecretResponse делает ответ с секретом, кодируя двоичное тело в base64
+func makeSecretResponse(secret model.Secret) secretResponse {
+	body, encoding := encodeBody(secret.Body)
+	return secretResponse{
+		Secret:       secret,
+		Body:         body,
+		BodyEncoding: encoding,
+		ViewsLeft:    secret.ViewsLeft(),
+	}
+}
+
+// encodeBody возвращает тело как есть, если это текст в UTF-8, а иначе кодирует его в base64
+func encodeBody(body []byte) (encoded, encoding string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+type listSecretsRequest struct {
+	// Owner - создатель секретов, можно указать только себя
+	Owner string `form:"owner"`
+	// CreatedAfter - секреты созданы не раньше этого момента
+	CreatedAfter time.Time `form:"createdAfter" time_format:"2006-01-02T15:04:05Z07:00"`
+	// CreatedBefore - секреты созданы раньше этого момента
+	CreatedBefore time.Time `form:"createdBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+	// MetaKey - у секретов есть поле метаданных с таким названием
+	MetaKey string `form:"metaKey"`
+	// Cursor - курсор из поля next предыдущей страницы
+	Cursor string `form:"cursor"`
+	// Limit - сколько секретов на странице
+	Limit int64 `form:"limit" binding:"gte=0,lte=100"`
+}
+
+type listSecretsResponse struct {
+	Secrets []secretResponse `json:"secrets"`
+	// Next - курсор следующей страницы, пустой, если страниц больше нет
+	Next string `json:"next"`
+}
+
+type updateSecretRequest struct {
+	// Body - новое тело секрета, обязательно для PUT, для PATCH отсутствие поля оставляет прежнее тело
+	Body *string           `json:"body"`
+	Meta map[string]string `json:"meta"`
+	// ContentType и Filename - MIME тип и имя файла нового тела, учитываются, только если передано тело
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename"`
+	// TTL - новый срок жизни секрета в секундах, отсчитываемый от момента изменения
+	TTL int64 `json:"ttl" binding:"gte=0"`
+	// ExpireAt - новый момент устаревания секрета, имеет приоритет перед TTL
+	ExpireAt time.Time `json:"expireAt"`
+}
+
+// parseIfMatch разбирает версию секрета из заголовка If-Match, отсутствующий заголовок и * означают любую версию
+func parseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	header = strings.TrimPrefix(header, "W/")
+	return strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+}
+
+// makeETag делает значение заголовка ETag из версии секрета
+func makeETag(secret model.Secret) string {
+	return fmt.Sprintf(`"%v"`, secret.Version)
+}
+
+// makeLogger создаёт журнал запроса. Адрес запроса записывается с учётом политики скрытия, так как
+// в нём могут быть токен ссылки на секрет и метаданные
+func (tr *Transport) makeLogger(c *gin.Context) zerolog.Logger {
+	endpoint := tr.redactEndpoint(c.Request.URL)
 	subj, found := c.Get("subject")
 	if found {
 		return log.With().
 			Str("method", c.Request.Method).
-			Str("endpoint", c.Request.RequestURI).
+			Str("endpoint", endpoint).
 			Str("remote_addr", c.RemoteIP()).
 			Str("subject", subj.(string)).
 			Str("user_agent", c.GetHeader("User-Agent")).
@@ -32,25 +149,84 @@ func makeLogger(c *gin.Context) zerolog.Logger {
 	}
 	return log.With().
 		Str("method", c.Request.Method).
-		Str("endpoint", c.Request.RequestURI).
+		Str("endpoint", endpoint).
 		Str("remote_addr", c.RemoteIP()).
 		Str("user_agent", c.GetHeader("User-Agent")).
 		Logger()
 }
 
+// makeIdentity собирает model.Identity из субъекта и групп JWT токена, которые сохранил middlewares.CheckJWT
+func makeIdentity(c *gin.Context) model.Identity {
+	return model.Identity{
+		Subject: c.GetString("subject"),
+		Groups:  c.GetStringSlice("groups"),
+	}
+}
+
 // ExposeSecretAPI включает ответчики с REST интерфейсом для работы с секретами
 func (tr *Transport) ExposeSecretAPI() {
 	rest := tr.Engine.Group("/api/v1/secret")
 	rest.Use(middlewares.CheckJWT())
 
 	rest.GET("/", func(c *gin.Context) {
-		c.AbortWithStatus(http.StatusNotImplemented)
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/ListSecrets")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_list_secrets_called", 1)
+		var query listSecretsRequest
+		if err := c.ShouldBindQuery(&query); err != nil {
+			tr.CounterService.Increment(ctx2, "http_list_secrets_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		secrets, next, err := tr.SecretService.List(ctx2, makeIdentity(c), repository.ListFilter{
+			Owner:         query.Owner,
+			CreatedAfter:  query.CreatedAfter,
+			CreatedBefore: query.CreatedBefore,
+			MetaKey:       query.MetaKey,
+			Cursor:        query.Cursor,
+			Limit:         query.Limit,
+		})
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidCursor) {
+				tr.CounterService.Increment(ctx2, "http_list_secrets_malformed", 1)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, model.ErrForbidden) {
+				tr.CounterService.Increment(ctx2, "http_list_secrets_forbidden", 1)
+				logger.Warn().
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Запрошен список чужих секретов %s", query.Owner)
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				c.Abort()
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_list_secrets_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при получении списка секретов: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_list_secrets_success", 1)
+		logger.Debug().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Найдено %v секретов", len(secrets))
+		ret := listSecretsResponse{
+			Secrets: make([]secretResponse, 0, len(secrets)),
+			Next:    next,
+		}
+		for i := range secrets {
+			ret.Secrets = append(ret.Secrets, makeSecretResponse(secrets[i]))
+		}
+		c.JSON(http.StatusOK, ret)
 	})
 	rest.PUT("/:id", func(c *gin.Context) {
-		c.AbortWithStatus(http.StatusNotImplemented)
+		tr.updateSecret(c, true)
 	})
 	rest.PATCH("/:id", func(c *gin.Context) {
-		c.AbortWithStatus(http.StatusNotImplemented)
+		tr.updateSecret(c, false)
 	})
 	rest.POST("/:id", func(c *gin.Context) {
 		c.AbortWithStatus(http.StatusNotImplemented)
@@ -58,43 +234,45 @@ func (tr *Transport) ExposeSecretAPI() {
 	rest.GET("/:id", func(c *gin.Context) {
 		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/GetSecretByID")
 		defer span.End()
-		logger := makeLogger(c)
-		id := c.Param("id")
-		tr.CounterService.Increment(ctx2, "http_get_secret_called", 1)
-		secret, err := tr.SecretService.FindByID(ctx2, id)
-		if err != nil {
-			if errors.Is(err, model.ErrSecretNotFound) {
-				tr.CounterService.Increment(ctx2, "http_get_secret_not_found", 1)
-				logger.Info().
-					Str("trace_id", span.SpanContext().TraceID().String()).
-					Str("secret_id", id).
-					Msgf("Секрет %s не найден", id)
-				c.AbortWithStatus(http.StatusNotFound)
-				return
-			}
-			tr.CounterService.Increment(ctx2, "http_get_secret_error", 1)
-			logger.Error().Err(err).
-				Str("trace_id", span.SpanContext().TraceID().String()).
-				Str("secret_id", id).
-				Msgf("Ошибка при поиске секрета %s : %s", id, err)
-			c.AbortWithError(http.StatusInternalServerError, err)
+		secret, ok := tr.readSecret(ctx2, c, span, "http_get_secret")
+		if !ok {
 			return
 		}
-		tr.CounterService.Increment(ctx2, "http_get_secret_success", 1)
-		logger.Info().
-			Str("trace_id", span.SpanContext().TraceID().String()).
-			Str("secret_id", id).
-			Msgf("Секрет %s получен", id)
-		c.JSON(http.StatusOK, secret)
+		c.Header("ETag", makeETag(secret))
+		c.JSON(http.StatusOK, makeSecretResponse(secret))
+	})
+	rest.GET("/:id/download", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/DownloadSecret")
+		defer span.End()
+		secret, ok := tr.readSecret(ctx2, c, span, "http_download_secret")
+		if !ok {
+			return
+		}
+		filename := secret.Filename
+		if filename == "" {
+			filename = secret.ID
+		}
+		contentType := secret.ContentType
+		if contentType == "" {
+			contentType = octetStream
+		}
+		c.Header("ETag", makeETag(secret))
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		// тип содержимого задаёт пользователь, поэтому браузеру запрещается его угадывать
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Data(http.StatusOK, contentType, secret.Body)
 	})
 	rest.DELETE("/:id", func(c *gin.Context) {
 		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/DeleteSecretByID")
 		defer span.End()
-		logger := makeLogger(c)
+		logger := tr.makeLogger(c)
 		id := c.Param("id")
 		tr.CounterService.Increment(ctx2, "http_delete_secret_called", 1)
-		err := tr.SecretService.DeleteByID(ctx2, c.Param("id"))
+		err := tr.SecretService.DeleteByID(ctx2, id, makeIdentity(c))
 		if err != nil {
+			if tr.abortWithGone(c, "http_delete_secret", err) {
+				return
+			}
 			if errors.Is(err, model.ErrSecretNotFound) {
 				tr.CounterService.Increment(ctx2, "http_delete_secret_not_found", 1)
 				logger.Info().
@@ -104,6 +282,16 @@ func (tr *Transport) ExposeSecretAPI() {
 				c.AbortWithStatus(http.StatusNotFound)
 				return
 			}
+			if errors.Is(err, model.ErrForbidden) {
+				tr.CounterService.Increment(ctx2, "http_delete_secret_forbidden", 1)
+				logger.Warn().
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Str("secret_id", id).
+					Msgf("Секрет %s не адресован пользователю", id)
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				c.Abort()
+				return
+			}
 			tr.CounterService.Increment(ctx2, "http_delete_secret_error", 1)
 			logger.Error().Err(err).
 				Str("trace_id", span.SpanContext().TraceID().String()).
@@ -123,14 +311,23 @@ func (tr *Transport) ExposeSecretAPI() {
 		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/CreateSecret")
 		defer span.End()
 		tr.CounterService.Increment(ctx2, "http_create_secret_called", 1)
-		logger := makeLogger(c)
+		logger := tr.makeLogger(c)
 		subject, found := c.Get("subject")
-		if !found {
+		if !found || subject.(string) == "" {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		var bdy createSecretRequest
-		if err := c.ShouldBindJSON(&bdy); err != nil {
+		tr.limitRequestBody(c)
+		params, err := bindSecret(c)
+		if err != nil && tooLarge(err) {
+			tr.CounterService.Increment(ctx2, "http_create_secret_too_large", 1)
+			logger.Info().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Секрет слишком большой: %s", err)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
 			tr.CounterService.Increment(ctx2, "http_create_secret_malformed", 1)
 			logger.Info().Err(err).
 				Str("trace_id", span.SpanContext().TraceID().String()).
@@ -138,17 +335,46 @@ func (tr *Transport) ExposeSecretAPI() {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		if bdy.Meta == nil {
-			bdy.Meta = make(map[string]string, 0)
-		}
-		_, found = bdy.Meta["body"]
-		if found {
-			delete(bdy.Meta, "body")
+		if params.Meta == nil {
+			params.Meta = make(map[string]string, 0)
 		}
-		bdy.Meta["User-Agent"] = c.Request.Header.Get("User-Agent")
-		bdy.Meta["Subject"] = subject.(string)
-		secret, err := tr.SecretService.Create(ctx2, bdy.Body, bdy.Meta)
+		delete(params.Meta, "body")
+		params.Meta["User-Agent"] = c.Request.Header.Get("User-Agent")
+		params.Meta["Subject"] = subject.(string)
+		params.Owner = subject.(string)
+		secret, err := tr.SecretService.Create(ctx2, params)
 		if err != nil {
+			if errors.Is(err, model.ErrTooLarge) {
+				tr.CounterService.Increment(ctx2, "http_create_secret_too_large", 1)
+				logger.Info().Err(err).
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Секрет слишком большой: %s", err)
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			if policyViolation(c, err) {
+				tr.CounterService.Increment(ctx2, "http_create_secret_rejected", 1)
+				logger.Info().
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Секрет отклонён политикой содержимого: %s", err)
+				return
+			}
+			if errors.Is(err, model.ErrInvalidCallbackURL) {
+				tr.CounterService.Increment(ctx2, "http_create_secret_malformed", 1)
+				logger.Info().Err(err).
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Ошибка при валидации секрета: %s", err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, model.ErrQuotaExceeded) {
+				tr.CounterService.Increment(ctx2, "http_create_secret_quota_exceeded", 1)
+				logger.Warn().Err(err).
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Пользователь %s создал слишком много секретов: %s", subject.(string), err)
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+				return
+			}
 			tr.CounterService.Increment(ctx2, "http_create_secret_error", 1)
 			logger.Error().Err(err).
 				Str("trace_id", span.SpanContext().TraceID().String()).
@@ -160,8 +386,276 @@ func (tr *Transport) ExposeSecretAPI() {
 		logger.Info().Err(err).
 			Str("secret_id", secret.ID).
 			Str("trace_id", span.SpanContext().TraceID().String()).
+			Interface("meta", tr.SecretService.Redaction.Meta(secret.Meta)).
 			Msgf("Пользователь %s создал секрет %s", subject.(string), secret.ID)
 		c.Header("Location", "/api/v1/secret/"+secret.ID)
-		c.AbortWithStatus(http.StatusCreated)
+		c.JSON(http.StatusCreated, createdResponse{
+			ID:         secret.ID,
+			ShareToken: secret.ShareToken,
+			ShareURL:   sharePath + secret.ShareToken,
+		})
+	})
+}
+
+type createdResponse struct {
+	ID string `json:"id"`
+	// ShareToken - токен ссылки, по которой секрет можно прочитать без JWT токена, больше его нигде не узнать
+	ShareToken string `json:"shareToken"`
+	// ShareURL - путь ссылки на секрет
+	ShareURL string `json:"shareUrl"`
+}
+
+type goneResponse struct {
+	Error string `json:"error"`
+	model.Tombstone
+}
+
+// abortWithGone отвечает 410 Gone с причиной и моментом исчезновения секрета, если ошибка - *model.GoneError,
+// и увеличивает счётчик с префиксом metric. Если ошибка другая, возвращается ложь
+func (tr *Transport) abortWithGone(c *gin.Context, metric string, err error) bool {
+	var gone *model.GoneError
+	if !errors.As(err, &gone) {
+		return false
+	}
+	tr.CounterService.Increment(c.Request.Context(), metric+"_gone", 1)
+	c.JSON(http.StatusGone, goneResponse{
+		Error:     err.Error(),
+		Tombstone: gone.Tombstone,
 	})
+	c.Abort()
+	return true
+}
+
+type notYetAvailableResponse struct {
+	Error     string    `json:"error"`
+	NotBefore time.Time `json:"notBefore"`
+}
+
+// abortWithNotYetAvailable отвечает 423 Locked с моментом, начиная с которого секрет можно прочитать,
+// если ошибка - *model.NotYetAvailableError, и увеличивает счётчик с префиксом metric. Если ошибка другая, возвращается ложь
+func (tr *Transport) abortWithNotYetAvailable(c *gin.Context, metric string, err error) bool {
+	var notYet *model.NotYetAvailableError
+	if !errors.As(err, &notYet) {
+		return false
+	}
+	tr.CounterService.Increment(c.Request.Context(), metric+"_not_yet_available", 1)
+	wait := time.Until(notYet.NotBefore)
+	if wait > 0 {
+		c.Header("Retry-After", strconv.FormatInt(int64(wait.Seconds())+1, 10))
+	}
+	c.JSON(http.StatusLocked, notYetAvailableResponse{
+		Error:     err.Error(),
+		NotBefore: notYet.NotBefore,
+	})
+	c.Abort()
+	return true
+}
+
+// readSecret читает секрет по идентификатору из пути запроса, засчитывая прочтение, и сжигает его,
+// если передан параметр burn. На ошибки отвечает сам, увеличивая счётчики с префиксом metric,
+// и возвращает ложь, если секрет выдавать нельзя
+func (tr *Transport) readSecret(ctx context.Context, c *gin.Context, span trace.Span, metric string) (model.Secret, bool) {
+	id := c.Param("id")
+	return tr.readSecretWith(ctx, c, span, metric, id, func(opts service.ReadOptions) (model.Secret, error) {
+		return tr.SecretService.FindByID(ctx, id, opts)
+	})
+}
+
+// readSecretWith читает секрет функцией find так же, как readSecret, а id задаёт, как секрет называется в журнале
+func (tr *Transport) readSecretWith(ctx context.Context, c *gin.Context, span trace.Span, metric, id string,
+	find func(opts service.ReadOptions) (model.Secret, error)) (model.Secret, bool) {
+	logger := tr.makeLogger(c)
+	tr.CounterService.Increment(ctx, metric+"_called", 1)
+	burn, err := strconv.ParseBool(c.DefaultQuery("burn", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "burn parameter should be boolean"})
+		return model.Secret{}, false
+	}
+	secret, err := find(service.ReadOptions{
+		Burn:       burn,
+		Passphrase: c.GetHeader(PassphraseHeader),
+		Identity:   makeIdentity(c),
+	})
+	if err != nil {
+		if tr.abortWithGone(c, metric, err) || tr.abortWithNotYetAvailable(c, metric, err) {
+			return model.Secret{}, false
+		}
+		if errors.Is(err, model.ErrSecretNotFound) {
+			tr.CounterService.Increment(ctx, metric+"_not_found", 1)
+			logger.Info().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Секрет %s не найден", id)
+			c.AbortWithStatus(http.StatusNotFound)
+			return model.Secret{}, false
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			tr.CounterService.Increment(ctx, metric+"_forbidden", 1)
+			logger.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Секрет %s не адресован пользователю", id)
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			c.Abort()
+			return model.Secret{}, false
+		}
+		if errors.Is(err, model.ErrPassphraseRequired) || errors.Is(err, model.ErrWrongPassphrase) {
+			tr.CounterService.Increment(ctx, metric+"_denied", 1)
+			logger.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Доступ к секрету %s запрещён: %s", id, err)
+			if errors.Is(err, model.ErrPassphraseRequired) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			}
+			c.Abort()
+			return model.Secret{}, false
+		}
+		tr.CounterService.Increment(ctx, metric+"_error", 1)
+		logger.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", id).
+			Msgf("Ошибка при поиске секрета %s : %s", id, err)
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return model.Secret{}, false
+	}
+	tr.CounterService.Increment(ctx, metric+"_success", 1)
+	if burn {
+		tr.CounterService.Increment(ctx, metric+"_burned", 1)
+	}
+	logger.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("secret_id", id).
+		Bool("burn", burn).
+		Msgf("Секрет %s получен", id)
+	return secret, true
+}
+
+// updateSecret изменяет секрет. PUT (replace) заменяет тело и метаданные целиком, а PATCH
+// меняет только переданные поля и дополняет метаданные. Если передан заголовок If-Match,
+// секрет изменяется, только если его версия совпадает с указанной
+func (tr *Transport) updateSecret(c *gin.Context, replace bool) {
+	ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/UpdateSecret")
+	defer span.End()
+	logger := tr.makeLogger(c)
+	id := c.Param("id")
+	tr.CounterService.Increment(ctx2, "http_update_secret_called", 1)
+	subject, found := c.Get("subject")
+	if !found {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	version, err := parseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		tr.CounterService.Increment(ctx2, "http_update_secret_malformed", 1)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match header should contain version of secret"})
+		return
+	}
+	tr.limitRequestBody(c)
+	var bdy updateSecretRequest
+	err = c.ShouldBindJSON(&bdy)
+	if err == nil && replace && bdy.Body == nil {
+		err = fmt.Errorf("body is required")
+	}
+	if err != nil && tooLarge(err) {
+		tr.CounterService.Increment(ctx2, "http_update_secret_too_large", 1)
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		tr.CounterService.Increment(ctx2, "http_update_secret_malformed", 1)
+		logger.Info().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", id).
+			Msgf("Ошибка при валидации изменений секрета: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if bdy.Meta == nil {
+		bdy.Meta = make(map[string]string, 0)
+	}
+	delete(bdy.Meta, "body")
+	if replace {
+		bdy.Meta["User-Agent"] = c.Request.Header.Get("User-Agent")
+		bdy.Meta["Subject"] = subject.(string)
+	} else {
+		delete(bdy.Meta, "Subject")
+	}
+	var body []byte
+	if bdy.Body != nil {
+		body = []byte(*bdy.Body)
+	}
+	secret, err := tr.SecretService.Update(ctx2, id, service.UpdateParams{
+		Body:        body,
+		ContentType: bdy.ContentType,
+		Filename:    bdy.Filename,
+		Meta:        bdy.Meta,
+		ReplaceMeta: replace,
+		TTL:         time.Duration(bdy.TTL) * time.Second,
+		ExpireAt:    bdy.ExpireAt,
+		Version:     version,
+		Identity:    makeIdentity(c),
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrSecretNotFound) {
+			tr.CounterService.Increment(ctx2, "http_update_secret_not_found", 1)
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, model.ErrForbidden) {
+			tr.CounterService.Increment(ctx2, "http_update_secret_forbidden", 1)
+			logger.Warn().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Секрет %s создан другим пользователем", id)
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			tr.CounterService.Increment(ctx2, "http_update_secret_conflict", 1)
+			logger.Info().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Секрет %s изменён кем-то ещё", id)
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if policyViolation(c, err) {
+			tr.CounterService.Increment(ctx2, "http_update_secret_rejected", 1)
+			logger.Info().
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Изменения секрета %s отклонены политикой содержимого: %s", id, err)
+			return
+		}
+		if errors.Is(err, model.ErrTooLarge) {
+			tr.CounterService.Increment(ctx2, "http_update_secret_too_large", 1)
+			logger.Info().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Изменённый секрет %s слишком большой: %s", id, err)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_update_secret_error", 1)
+		logger.Error().Err(err).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", id).
+			Msgf("Ошибка при изменении секрета %s : %s", id, err)
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	tr.CounterService.Increment(ctx2, "http_update_secret_success", 1)
+	logger.Info().
+		Str("trace_id", span.SpanContext().TraceID().String()).
+		Str("secret_id", id).
+		Int64("version", secret.Version).
+		Msgf("Пользователь %s изменил секрет %s", subject.(string), id)
+	c.Header("ETag", makeETag(secret))
+	c.JSON(http.StatusOK, makeSecretResponse(secret))
 }
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/vodolaz095/purser/model"
+)
+
+// maxPassphraseLength задаёт максимальную длину кодовой фразы, длиннее bcrypt не принимает
+const maxPassphraseLength = 72
+
+// octetStream - MIME тип двоичных данных, тип которых неизвестен
+const octetStream = "application/octet-stream"
+
+// requestOverhead задаёт запас на метаданные, параметры и кодирование тела в запросе на создание или изменение секрета
+const requestOverhead = 1 << 20
+
+// limitRequestBody ограничивает размер тела запроса, чтобы не читать в память запросы,
+// секреты из которых всё равно не уложатся в ограничения сервиса
+func (tr *Transport) limitRequestBody(c *gin.Context) {
+	maxBody := tr.SecretService.Limits.BodyBytes()
+	if maxBody > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 2*maxBody+requestOverhead)
+	}
+}
+
+// tooLarge возвращает истину, если секрет не укладывается в ограничения сервиса или запрос слишком большой
+func tooLarge(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.Is(err, model.ErrTooLarge) || errors.As(err, &maxBytesError)
+}
+
+// policyViolation отвечает на ошибку, если секрет нарушает политику содержимого, перечисляя нарушенные правила,
+// и возвращает ложь, если ошибка другая
+func policyViolation(c *gin.Context, err error) bool {
+	var validationError *model.ValidationError
+	if !errors.As(err, &validationError) {
+		return false
+	}
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+		"error":      model.ErrPolicyViolation.Error(),
+		"violations": validationError.Violations,
+	})
+	return true
+}
+
+// uploadSecretRequest задаёт параметры секрета, тело которого загружается файлом в multipart/form-data
+// или как есть в application/octet-stream. В первом случае параметры передаются полями формы,
+// во втором - в строке запроса. Метаданные передаются как meta[название]=значение,
+// а кодовая фраза - в заголовке PassphraseHeader, чтобы она не попадала в журналы в составе адреса
+type uploadSecretRequest struct {
+	// TTL - желаемый срок жизни секрета в секундах
+	TTL int64 `form:"ttl" binding:"gte=0"`
+	// ExpireAt - желаемый момент устаревания секрета, имеет приоритет перед TTL
+	ExpireAt time.Time `form:"expireAt" time_format:"2006-01-02T15:04:05Z07:00"`
+	// NotBefore - момент, начиная с которого секрет можно прочитать, срок жизни отсчитывается от него
+	NotBefore time.Time `form:"notBefore" time_format:"2006-01-02T15:04:05Z07:00"`
+	// MaxViews - сколько раз можно прочитать секрет, 0 - без ограничений
+	MaxViews int64 `form:"maxViews" binding:"gte=0"`
+	// Recipients - субъекты JWT токенов, которым, кроме создателя, можно прочитать секрет
+	Recipients []string `form:"recipients"`
+	// Groups - группы из claim groups JWT токенов, участникам которых можно прочитать секрет
+	Groups []string `form:"groups"`
+	// ContentType - MIME тип тела секрета, если не задан - берётся из загрузки
+	ContentType string `form:"contentType"`
+	// Filename - имя файла, если не задано - берётся из загрузки
+	Filename string `form:"filename"`
+	// CallbackURL - адрес, на который создателю сообщается о прочтении, удалении или устаревании секрета
+	CallbackURL string `form:"callbackUrl"`
+	// Opaque - тело зашифровано клиентом, сервер хранит его как есть
+	Opaque bool `form:"opaque"`
+}
+
+// params превращает параметры загрузки в model.SecretParams без тела
+func (r uploadSecretRequest) params() model.SecretParams {
+	return model.SecretParams{
+		Recipients:  r.Recipients,
+		Groups:      r.Groups,
+		TTL:         time.Duration(r.TTL) * time.Second,
+		ExpireAt:    r.ExpireAt,
+		NotBefore:   r.NotBefore,
+		MaxViews:    r.MaxViews,
+		ContentType: r.ContentType,
+		Filename:    r.Filename,
+		CallbackURL: r.CallbackURL,
+		Opaque:      r.Opaque,
+	}
+}
+
+// bindSecret разбирает секрет, тело которого можно передать в JSON,
+// файлом в multipart/form-data или как есть в application/octet-stream
+func bindSecret(c *gin.Context) (model.SecretParams, error) {
+	switch c.ContentType() {
+	case binding.MIMEMultipartPOSTForm:
+		return bindMultipartSecret(c)
+	case octetStream:
+		return bindRawSecret(c)
+	default:
+		return bindJSONSecret(c)
+	}
+}
+
+// bindJSONSecret разбирает секрет, переданный в JSON
+func bindJSONSecret(c *gin.Context) (model.SecretParams, error) {
+	var bdy createSecretRequest
+	err := c.ShouldBindJSON(&bdy)
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	return model.SecretParams{
+		Body:        []byte(bdy.Body),
+		Meta:        bdy.Meta,
+		ContentType: bdy.ContentType,
+		Filename:    bdy.Filename,
+		Recipients:  bdy.Recipients,
+		Groups:      bdy.Groups,
+		TTL:         time.Duration(bdy.TTL) * time.Second,
+		ExpireAt:    bdy.ExpireAt,
+		NotBefore:   bdy.NotBefore,
+		MaxViews:    bdy.MaxViews,
+		Passphrase:  bdy.Passphrase,
+		CallbackURL: bdy.CallbackURL,
+		Opaque:      bdy.Opaque,
+	}, nil
+}
+
+// bindMultipartSecret разбирает секрет, тело которого загружено файлом в поле file формы multipart/form-data
+func bindMultipartSecret(c *gin.Context) (model.SecretParams, error) {
+	var form uploadSecretRequest
+	err := c.ShouldBindWith(&form, binding.FormMultipart)
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		return model.SecretParams{}, fmt.Errorf("file is required: %w", err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	defer file.Close()
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	params := form.params()
+	params.Body = body
+	params.Meta = c.PostFormMap("meta")
+	// application/octet-stream клиенты ставят, когда тип файла им неизвестен, тогда его определит сервис
+	if params.ContentType == "" && header.Header.Get("Content-Type") != octetStream {
+		params.ContentType = header.Header.Get("Content-Type")
+	}
+	if params.Filename == "" {
+		params.Filename = header.Filename
+	}
+	params.Passphrase = c.GetHeader(PassphraseHeader)
+	if params.Passphrase == "" {
+		params.Passphrase = c.PostForm("passphrase")
+	}
+	return params, checkUpload(params)
+}
+
+// bindRawSecret разбирает секрет, тело которого передано как есть в application/octet-stream
+func bindRawSecret(c *gin.Context) (model.SecretParams, error) {
+	var query uploadSecretRequest
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		return model.SecretParams{}, err
+	}
+	params := query.params()
+	params.Body = body
+	params.Meta = c.QueryMap("meta")
+	if params.ContentType == "" {
+		params.ContentType = octetStream
+	}
+	params.Passphrase = c.GetHeader(PassphraseHeader)
+	return params, checkUpload(params)
+}
+
+// checkUpload проверяет тело и кодовую фразу загруженного секрета
+func checkUpload(params model.SecretParams) error {
+	if len(params.Body) == 0 {
+		return fmt.Errorf("body is required")
+	}
+	if len(params.Passphrase) > maxPassphraseLength {
+		return fmt.Errorf("passphrase should not be longer than %v bytes", maxPassphraseLength)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/service"
+	"github.com/vodolaz095/purser/model"
+)
+
+// sharePath задаёт путь, по которому секрет читается по токену ссылки
+const sharePath = "/s/"
+
+// sharedSecretResponse - секрет, прочитанный по ссылке. Читатель может быть кем угодно,
+// поэтому в ответе нет ни идентификатора секрета, ни его создателя и адресатов, ни метаданных
+type sharedSecretResponse struct {
+	// Body - тело секрета, текстом, если это UTF-8, иначе в base64
+	Body string `json:"body"`
+	// BodyEncoding - base64, если тело закодировано в base64, иначе пустое
+	BodyEncoding string    `json:"bodyEncoding,omitempty"`
+	ContentType  string    `json:"contentType"`
+	Filename     string    `json:"filename"`
+	ExpireAt     time.Time `json:"expireAt"`
+	// Opaque - тело зашифровано клиентом, ключ передаётся читателю отдельно от ссылки
+	Opaque bool `json:"opaque"`
+	// ViewsLeft - сколько раз ещё можно прочитать секрет, -1 - без ограничений
+	ViewsLeft int64 `json:"viewsLeft"`
+}
+
+// ExposeShareAPI включает ответчик, по которому секрет читается по токену ссылки, выданному при его создании.
+// JWT токен не нужен, так что секрет может получить и тот, у кого его нет
+func (tr *Transport) ExposeShareAPI() {
+	tr.Engine.GET(sharePath+":token", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/RedeemShareToken")
+		defer span.End()
+		// прочитанный по ссылке секрет не должен оседать в кэшах по пути к читателю
+		c.Header("Cache-Control", "no-store")
+		token := c.Param("token")
+		secret, ok := tr.readSecretWith(ctx2, c, span, "http_redeem_share", "по ссылке",
+			func(opts service.ReadOptions) (model.Secret, error) {
+				return tr.SecretService.Redeem(ctx2, token, opts)
+			})
+		if !ok {
+			return
+		}
+		body, encoding := encodeBody(secret.Body)
+		c.JSON(http.StatusOK, sharedSecretResponse{
+			Body:         body,
+			BodyEncoding: encoding,
+			ContentType:  secret.ContentType,
+			Filename:     secret.Filename,
+			ExpireAt:     secret.ExpireAt,
+			Opaque:       secret.Opaque,
+			ViewsLeft:    secret.ViewsLeft(),
+		})
+	})
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+	"github.com/vodolaz095/purser/model"
+)
+
+// createSplitRequest задаёт секрет, который делится на доли по схеме Шамира. Адресаты, группы
+// и ограничение числа прочтений не учитываются: каждую долю один раз прочитает только её хранитель
+type createSplitRequest struct {
+	createSecretRequest
+	// Holders - субъекты JWT токенов хранителей долей, по одной доле на каждого
+	Holders []string `json:"holders" binding:"required,min=2"`
+	// Threshold - сколько долей нужно, чтобы восстановить секрет
+	Threshold int `json:"threshold" binding:"required,gte=2"`
+}
+
+type combineRequest struct {
+	// Shares - тексты долей, которые хранители прочитали из своих секретов
+	Shares []string `json:"shares" binding:"required,min=2"`
+}
+
+type combinedResponse struct {
+	// Body - восстановленное тело секрета, текстом, если это UTF-8, иначе в base64
+	Body string `json:"body"`
+	// BodyEncoding - base64, если тело закодировано в base64, иначе пустое
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+}
+
+// ExposeSplitAPI включает ответчики, которыми секрет делится на доли для разных хранителей
+// и восстанавливается из присланных ими долей
+func (tr *Transport) ExposeSplitAPI() {
+	rest := tr.Engine.Group("/api/v1/split")
+	rest.Use(middlewares.CheckJWT())
+
+	rest.POST("/", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/CreateSplit")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		tr.CounterService.Increment(ctx2, "http_create_split_called", 1)
+		tr.limitRequestBody(c)
+		var bdy createSplitRequest
+		err := c.ShouldBindJSON(&bdy)
+		if err != nil && tooLarge(err) {
+			tr.CounterService.Increment(ctx2, "http_create_split_too_large", 1)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_create_split_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		identity := makeIdentity(c)
+		meta := bdy.Meta
+		if meta == nil {
+			meta = make(map[string]string, 0)
+		}
+		delete(meta, "body")
+		meta["User-Agent"] = c.Request.Header.Get("User-Agent")
+		split, err := tr.SecretService.CreateSplit(ctx2, model.SplitParams{
+			SecretParams: model.SecretParams{
+				Body:        []byte(bdy.Body),
+				Meta:        meta,
+				ContentType: bdy.ContentType,
+				Filename:    bdy.Filename,
+				Owner:       identity.Subject,
+				TTL:         time.Duration(bdy.TTL) * time.Second,
+				ExpireAt:    bdy.ExpireAt,
+				NotBefore:   bdy.NotBefore,
+				Passphrase:  bdy.Passphrase,
+				CallbackURL: bdy.CallbackURL,
+			},
+			Holders:   bdy.Holders,
+			Threshold: bdy.Threshold,
+		})
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidSplit) || errors.Is(err, model.ErrInvalidCallbackURL) {
+				tr.CounterService.Increment(ctx2, "http_create_split_malformed", 1)
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, model.ErrTooLarge) {
+				tr.CounterService.Increment(ctx2, "http_create_split_too_large", 1)
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			if policyViolation(c, err) {
+				tr.CounterService.Increment(ctx2, "http_create_split_rejected", 1)
+				return
+			}
+			if errors.Is(err, model.ErrQuotaExceeded) {
+				tr.CounterService.Increment(ctx2, "http_create_split_quota_exceeded", 1)
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_create_split_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при разделении секрета: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_create_split_success", 1)
+		logger.Info().
+			Str("split_id", split.ID).
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Interface("meta", tr.SecretService.Redaction.Meta(meta)).
+			Msgf("Пользователь %s разделил секрет %s на %v долей с порогом %v",
+				identity.Subject, split.ID, len(split.Shares), split.Threshold)
+		c.JSON(http.StatusCreated, split)
+	})
+	rest.POST("/combine", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/CombineShares")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		// восстановленный секрет не должен оседать в кэшах по пути к читателю
+		c.Header("Cache-Control", "no-store")
+		tr.CounterService.Increment(ctx2, "http_combine_shares_called", 1)
+		var bdy combineRequest
+		err := c.ShouldBindJSON(&bdy)
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_combine_shares_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		identity := makeIdentity(c)
+		body, err := tr.SecretService.Combine(ctx2, bdy.Shares, identity)
+		if err != nil {
+			if errors.Is(err, model.ErrInvalidShares) {
+				tr.CounterService.Increment(ctx2, "http_combine_shares_rejected", 1)
+				logger.Warn().
+					Str("trace_id", span.SpanContext().TraceID().String()).
+					Msgf("Пользователь %s прислал негодные доли: %s", identity.Subject, err)
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+				return
+			}
+			tr.CounterService.Increment(ctx2, "http_combine_shares_error", 1)
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Msgf("Ошибка при восстановлении секрета: %s", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_combine_shares_success", 1)
+		logger.Info().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Msgf("Пользователь %s восстановил секрет из %v долей", identity.Subject, len(bdy.Shares))
+		encoded, encoding := encodeBody(body)
+		c.JSON(http.StatusOK, combinedResponse{
+			Body:         encoded,
+			BodyEncoding: encoding,
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+	"github.com/vodolaz095/purser/model"
+)
+
+type statusResponse struct {
+	model.SecretStatus
+	// RemainingSeconds - сколько секунд секрету осталось жить
+	RemainingSeconds int64 `json:"remainingSeconds"`
+}
+
+// ExposeStatusAPI включает ответчик, по которому создатель секрета узнаёт, прочитан ли секрет, когда и кем,
+// не засчитывая прочтение и не получая тело секрета
+func (tr *Transport) ExposeStatusAPI() {
+	status := tr.Engine.Group("/api/v1/secret/:id/status")
+	status.Use(middlewares.CheckJWT())
+
+	status.GET("", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/GetSecretStatus")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_get_status_called", 1)
+		secretStatus, err := tr.SecretService.Status(ctx2, id, makeIdentity(c))
+		if err != nil {
+			if tr.abortWithGone(c, "http_get_status", err) || tr.abortWithVersionError(c, "http_get_status", err) {
+				return
+			}
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Ошибка при получении состояния секрета %s : %s", id, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_get_status_success", 1)
+		c.JSON(http.StatusOK, statusResponse{
+			SecretStatus:     secretStatus,
+			RemainingSeconds: int64(secretStatus.Remaining.Seconds()),
+		})
+	})
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vodolaz095/purser/internal/service"
+	"github.com/vodolaz095/purser/internal/transport/http/middlewares"
+	"github.com/vodolaz095/purser/model"
+)
+
+type versionResponse struct {
+	model.SecretVersion
+	// Body - тело версии, текстом, если это UTF-8, иначе в base64
+	Body string `json:"body"`
+	// BodyEncoding - base64, если тело закодировано в base64, иначе пустое
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+}
+
+// makeVersionResponse делает ответ с прежней версией секрета, кодируя двоичное тело в base64
+func makeVersionResponse(version model.SecretVersion) versionResponse {
+	body, encoding := encodeBody(version.Body)
+	return versionResponse{
+		SecretVersion: version,
+		Body:          body,
+		BodyEncoding:  encoding,
+	}
+}
+
+// ExposeVersionsAPI включает ответчики для работы с историей версий секрета. Историю видит только создатель секрета
+func (tr *Transport) ExposeVersionsAPI() {
+	versions := tr.Engine.Group("/api/v1/secret/:id/versions")
+	versions.Use(middlewares.CheckJWT())
+
+	versions.GET("/", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/ListVersions")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_list_versions_called", 1)
+		list, err := tr.SecretService.ListVersions(ctx2, id, makeIdentity(c))
+		if err != nil {
+			if tr.abortWithVersionError(c, "http_list_versions", err) {
+				return
+			}
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Ошибка при получении истории секрета %s : %s", id, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_list_versions_success", 1)
+		ret := make([]versionResponse, 0, len(list))
+		for i := range list {
+			ret = append(ret, makeVersionResponse(list[i]))
+		}
+		c.JSON(http.StatusOK, ret)
+	})
+	versions.GET("/:version", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/GetVersion")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_get_version_called", 1)
+		version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_get_version_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "version should be integer"})
+			return
+		}
+		archived, err := tr.SecretService.GetVersion(ctx2, id, version, service.ReadOptions{
+			Passphrase: c.GetHeader(PassphraseHeader),
+			Identity:   makeIdentity(c),
+		})
+		if err != nil {
+			if tr.abortWithVersionError(c, "http_get_version", err) {
+				return
+			}
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Ошибка при получении версии %v секрета %s : %s", version, id, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_get_version_success", 1)
+		logger.Info().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", id).
+			Int64("version", version).
+			Msgf("Получена версия %v секрета %s", version, id)
+		c.JSON(http.StatusOK, makeVersionResponse(archived))
+	})
+	versions.POST("/:version/rollback", func(c *gin.Context) {
+		ctx2, span := tr.SecretService.Tracer.Start(c.Request.Context(), "transport/http/Rollback")
+		defer span.End()
+		logger := tr.makeLogger(c)
+		id := c.Param("id")
+		tr.CounterService.Increment(ctx2, "http_rollback_called", 1)
+		version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_rollback_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "version should be integer"})
+			return
+		}
+		current, err := parseIfMatch(c.GetHeader("If-Match"))
+		if err != nil {
+			tr.CounterService.Increment(ctx2, "http_rollback_malformed", 1)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match header should contain version of secret"})
+			return
+		}
+		secret, err := tr.SecretService.Rollback(ctx2, id, version, current, makeIdentity(c))
+		if err != nil {
+			if tr.abortWithVersionError(c, "http_rollback", err) {
+				return
+			}
+			logger.Error().Err(err).
+				Str("trace_id", span.SpanContext().TraceID().String()).
+				Str("secret_id", id).
+				Msgf("Ошибка при откате секрета %s к версии %v : %s", id, version, err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		tr.CounterService.Increment(ctx2, "http_rollback_success", 1)
+		logger.Info().
+			Str("trace_id", span.SpanContext().TraceID().String()).
+			Str("secret_id", id).
+			Int64("version", secret.Version).
+			Msgf("Секрет %s откачен к версии %v", id, version)
+		c.Header("ETag", makeETag(secret))
+		c.JSON(http.StatusOK, makeSecretResponse(secret))
+	})
+}
+
+// abortWithVersionError отвечает на ожидаемые ошибки работы с версиями секрета и увеличивает счётчик
+// с префиксом metric. Если ошибка неожиданная, увеличивается только счётчик ошибок, и возвращается ложь
+func (tr *Transport) abortWithVersionError(c *gin.Context, metric string, err error) bool {
+	ctx := c.Request.Context()
+	switch {
+	case errors.Is(err, model.ErrSecretNotFound):
+		tr.CounterService.Increment(ctx, metric+"_not_found", 1)
+		c.AbortWithStatus(http.StatusNotFound)
+	case errors.Is(err, model.ErrPassphraseRequired):
+		tr.CounterService.Increment(ctx, metric+"_denied", 1)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Abort()
+	case errors.Is(err, model.ErrForbidden), errors.Is(err, model.ErrWrongPassphrase):
+		tr.CounterService.Increment(ctx, metric+"_denied", 1)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		c.Abort()
+	case errors.Is(err, model.ErrVersionConflict):
+		tr.CounterService.Increment(ctx, metric+"_conflict", 1)
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		c.Abort()
+	default:
+		tr.CounterService.Increment(ctx, metric+"_error", 1)
+		return false
+	}
+	return true
+}
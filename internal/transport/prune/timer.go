@@ -6,6 +6,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/vodolaz095/purser/internal/service"
+	"github.com/vodolaz095/purser/model"
 )
 
 // Timeout задаёт допустимую длительность удаления старых записей
@@ -26,7 +27,7 @@ func (av *Autovacuum) StartPruningExpiredSecrets(ctx context.Context, interval t
 			ticker.Stop()
 			return
 		case <-ticker.C:
-			ctx2, cancel := context.WithTimeout(ctx, Timeout)
+			ctx2, cancel := context.WithTimeout(service.WithClient(ctx, model.Client{Transport: "prune"}), Timeout)
 			err := av.Service.Ping(ctx2)
 			if err != nil {
 				log.Error().Err(err).
@@ -0,0 +1,67 @@
+package rewrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/vodolaz095/purser/internal/service"
+)
+
+// Timeout задаёт допустимую длительность перешифровки одной пачки секретов
+const Timeout = 5 * time.Second
+
+// Pause задаёт паузу между пачками, чтобы перешифровка не мешала HTTP и GRPC транспортам работать с хранилищем
+const Pause = 100 * time.Millisecond
+
+// Job реализует транспорт, который после ротации мастер-ключа перешифровывает ключи данных всех секретов и их прежних версий
+type Job struct {
+	Service *service.KeyRotationService
+}
+
+// StartRewrappingSecrets перешифровывает секреты пачками, начиная с cursor, пока они не кончатся или не будет
+// отменён контекст. Прогресс пишется в лог, и прерванную перешифровку можно продолжить с последнего курсора
+func (j *Job) StartRewrappingSecrets(ctx context.Context, cursor string) {
+	progress := service.RewrapProgress{Cursor: cursor}
+	started := time.Now()
+	log.Info().Msgf("Начинаем перешифровку секретов мастер-ключом %s с курсора '%s'",
+		j.Service.KMS.KeyID(), cursor)
+	for {
+		ctx2, cancel := context.WithTimeout(ctx, Timeout)
+		updated, err := j.Service.Rewrap(ctx2, progress)
+		cancel()
+		if err != nil {
+			log.Error().Err(err).
+				Str("cursor", progress.Cursor).
+				Msgf("Ошибка перешифровки секретов с курсора '%s': %s", progress.Cursor, err)
+		} else {
+			progress = updated
+			log.Debug().
+				Str("cursor", progress.Cursor).
+				Int64("scanned", progress.Scanned).
+				Int64("rewrapped", progress.Rewrapped).
+				Int64("skipped", progress.Skipped).
+				Int64("failed", progress.Failed).
+				Msgf("Перешифровано %v секретов из %v найденных", progress.Rewrapped, progress.Scanned)
+		}
+		if progress.Done {
+			log.Info().
+				Int64("scanned", progress.Scanned).
+				Int64("rewrapped", progress.Rewrapped).
+				Int64("skipped", progress.Skipped).
+				Int64("failed", progress.Failed).
+				Msgf("Перешифровка секретов завершена за %s, перешифровано %v секретов, не удалось перешифровать %v",
+					time.Since(started).String(), progress.Rewrapped, progress.Failed)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			log.Warn().
+				Str("cursor", progress.Cursor).
+				Msgf("Перешифровка секретов прервана, продолжить можно с курсора '%s'", progress.Cursor)
+			return
+		case <-time.After(Pause):
+			continue // вдруг обойдётся
+		}
+	}
+}
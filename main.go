@@ -9,11 +9,13 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/vodolaz095/purser/internal/transport/prune"
+	"github.com/vodolaz095/purser/internal/transport/rewrap"
 	"github.com/vodolaz095/purser/pkg/telemetry"
 	"go.opentelemetry.io/otel"
 
 	"github.com/vodolaz095/purser/config"
 	"github.com/vodolaz095/purser/internal/repository"
+	"github.com/vodolaz095/purser/internal/repository/encrypted"
 	"github.com/vodolaz095/purser/internal/repository/memory"
 	"github.com/vodolaz095/purser/internal/repository/mysql"
 	"github.com/vodolaz095/purser/internal/repository/postgresql"
@@ -22,6 +24,7 @@ import (
 	grpcTransport "github.com/vodolaz095/purser/internal/transport/grpc"
 	httpTransport "github.com/vodolaz095/purser/internal/transport/http"
 	"github.com/vodolaz095/purser/internal/transport/watchdog"
+	"github.com/vodolaz095/purser/pkg/envelope"
 )
 
 // Version содержит версию программы, задаётся при процессе компиляции
@@ -38,7 +41,7 @@ func main() {
 
 	// настраиваем приём сигналов от операционной системы сигналы
 	sigc := make(chan os.Signal, 1)
-	signal.Notify(sigc, syscall.SIGHUP,
+	signal.Notify(sigc,
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT,
@@ -68,22 +71,42 @@ func main() {
 	 * Настраиваем репозиторий для объектов типа model.Secret
 	 */
 	var repo repository.SecretRepo
+	// журнал аудита, подписки на события секретов, записи об исчезнувших секретах и запросы секретов
+	// хранятся в том же хранилище, что и секреты, но не шифруются
+	var auditRepo repository.AuditRepo
+	var webhookRepo repository.WebhookRepo
+	var tombstoneRepo repository.TombstoneRepo
+	var requestRepo repository.RequestRepo
 	switch config.Driver {
 	case "memory":
-		repo = &memory.Repository{}
+		memoryRepo := &memory.Repository{}
+		repo, auditRepo, webhookRepo, tombstoneRepo, requestRepo = memoryRepo, memoryRepo, memoryRepo, memoryRepo, memoryRepo
 		break
 	case "redis":
-		repo = &redis.Repository{RedisConnectionString: config.DatabaseConnectionString}
+		redisRepo := &redis.Repository{RedisConnectionString: config.DatabaseConnectionString}
+		repo, auditRepo, webhookRepo, tombstoneRepo, requestRepo = redisRepo, redisRepo, redisRepo, redisRepo, redisRepo
 		break
 	case "mariadb", "mysql":
-		repo = &mysql.Repository{DatabaseConnectionString: config.DatabaseConnectionString}
+		mysqlRepo := &mysql.Repository{DatabaseConnectionString: config.DatabaseConnectionString}
+		repo, auditRepo, webhookRepo, tombstoneRepo, requestRepo = mysqlRepo, mysqlRepo, mysqlRepo, mysqlRepo, mysqlRepo
 		break
 	case "postgres", "pgx":
-		repo = &postgresql.Repository{DatabaseConnectionString: config.DatabaseConnectionString}
+		postgresqlRepo := &postgresql.Repository{DatabaseConnectionString: config.DatabaseConnectionString}
+		repo, auditRepo, webhookRepo, tombstoneRepo, requestRepo = postgresqlRepo, postgresqlRepo, postgresqlRepo, postgresqlRepo, postgresqlRepo
 		break
 	default:
 		log.Fatal().Msgf("неизвестный драйвер базы данных для репозитория: %s", config.Driver)
 	}
+	// включаем шифрование секретов в хранилище, если задан мастер-ключ
+	var kms *envelope.LocalKMS
+	if config.EncryptionKeyFile != "" {
+		kms, err = envelope.LoadKeyFile(config.EncryptionKeyFile)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("ошибка загрузки мастер-ключа: %s", err)
+		}
+		repo = &encrypted.Repository{Repo: repo, KMS: kms}
+		log.Debug().Msgf("Секреты шифруются мастер-ключом %s", kms.KeyID())
+	}
 	err = repo.Init(mainCtx)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("ошибка инициализации репозитория: %s", err)
@@ -99,13 +122,114 @@ func main() {
 	/*
 	 * Настраиваем сервисы
 	 */
+	validators, err := service.ParseContentPolicy(config.ContentPolicy, config.DeniedClients)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("ошибка разбора политики содержимого: %s", err)
+	}
+	redaction, err := service.ParseRedaction(config.RedactionPolicy)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("ошибка разбора политики скрытия метаданных: %s", err)
+	}
+	var enricher *service.Enricher
+	if config.EnrichmentRulesFile != "" {
+		enricher, err = service.LoadEnricher(config.EnrichmentRulesFile)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("ошибка загрузки правил обогащения метаданных: %s", err)
+		}
+		log.Debug().Msgf("Загружено %v правил обогащения метаданных из %s", enricher.Len(), config.EnrichmentRulesFile)
+		// по сигналу SIGHUP правила перечитываются без перезапуска приложения
+		hupc := make(chan os.Signal, 1)
+		signal.Notify(hupc, syscall.SIGHUP)
+		go func() {
+			for range hupc {
+				rErr := enricher.Reload()
+				if rErr != nil {
+					log.Error().Err(rErr).Msgf("Ошибка перечитывания правил обогащения метаданных, действуют прежние правила: %s", rErr)
+					continue
+				}
+				log.Info().Msgf("Перечитано %v правил обогащения метаданных из %s", enricher.Len(), config.EnrichmentRulesFile)
+			}
+		}()
+	} else {
+		// перечитывать нечего, поэтому SIGHUP, как и раньше, останавливает приложение
+		signal.Notify(sigc, syscall.SIGHUP)
+	}
+
+	as := service.AuditService{
+		Tracer:     otel.Tracer("purser_service_tracer"),
+		Repo:       auditRepo,
+		AdminGroup: config.AdminGroup,
+	}
+	log.Debug().Msgf("Журнал аудита инициализирован, он доступен группе %s", config.AdminGroup)
+
 	cs := service.CounterService{}
 	cs.Init()
 	log.Debug().Msgf("Сервис счетчиков инициализирован!")
 
+	var webhooks *service.WebhookNotifier
+	if config.WebhookSecret != "" {
+		subjectWebhooks, wErr := service.ParseSubjectWebhooks(config.SubjectWebhooks)
+		if wErr != nil {
+			log.Fatal().Err(wErr).Msgf("ошибка разбора адресов для уведомлений: %s", wErr)
+		}
+		allowedNetworks, wErr := service.ParseAllowedNetworks(config.WebhookAllowedNetworks)
+		if wErr != nil {
+			log.Fatal().Err(wErr).Msgf("ошибка разбора сетей для уведомлений: %s", wErr)
+		}
+		webhooks = &service.WebhookNotifier{
+			Repo:            webhookRepo,
+			Secret:          []byte(config.WebhookSecret),
+			Source:          config.Hostname,
+			Subjects:        subjectWebhooks,
+			AllowedNetworks: allowedNetworks,
+			MaxAttempts:     config.WebhookMaxAttempts,
+			Backoff:         config.WebhookBackoff,
+			Workers:         config.WebhookWorkers,
+			Counter:         &cs,
+		}
+		wg.Add(1)
+		go func() {
+			webhooks.Start(mainCtx)
+			wg.Done()
+		}()
+		log.Debug().Msgf("Уведомления о событиях секретов включены")
+	}
+
+	var tombstones *service.TombstoneService
+	if config.TombstoneRetention > 0 {
+		tombstones = &service.TombstoneService{
+			Repo:      tombstoneRepo,
+			Retention: config.TombstoneRetention,
+		}
+		log.Debug().Msgf("Записи об исчезнувших секретах хранятся %s", config.TombstoneRetention)
+	}
+
 	ss := service.SecretService{
 		Tracer: otel.Tracer("purser_service_tracer"),
 		Repo:   repo,
+		TTL: service.TTLPolicy{
+			Default: config.SecretTTL,
+			Min:     config.SecretMinTTL,
+			Max:     config.SecretMaxTTL,
+		},
+		MaxPassphraseAttempts: config.MaxPassphraseAttempts,
+		KeepVersions:          config.KeepVersions,
+		KeepAccesses:          config.KeepAccesses,
+		Limits: service.Limits{
+			MaxBodyBytes:         config.MaxBodyBytes,
+			MaxMetaKeys:          config.MaxMetaKeys,
+			MaxMetaKeyLength:     config.MaxMetaKeyLength,
+			MaxMetaValueLength:   config.MaxMetaValueLength,
+			MaxSecretsPerSubject: config.MaxSecretsPerSubject,
+		},
+		Validators:   validators,
+		RefuseOpaque: !config.AllowOpaqueSecrets,
+		Enricher:     enricher,
+		Redaction:    redaction,
+		Audit:        &as,
+		Webhooks:     webhooks,
+		Tombstones:   tombstones,
+		Requests:     requestRepo,
 	}
 	log.Debug().Msgf("Сервис секретов инициализирован!")
 
@@ -137,6 +261,7 @@ func main() {
 			ListenOn:       config.ListenHTTP,
 			Hostname:       config.Hostname,
 			SecretService:  &ss,
+			AuditService:   &as,
 			CounterService: &cs,
 		})
 		if lErr != nil {
@@ -153,6 +278,7 @@ func main() {
 			HmacSecret:     config.JwtSecret,
 			ListenOn:       config.ListenGRPC,
 			SecretService:  &ss,
+			AuditService:   &as,
 			CounterService: &cs,
 		})
 		if lErr != nil {
@@ -169,6 +295,21 @@ func main() {
 		wg.Done()
 	}()
 
+	// запускаем фоновую перешифровку секретов текущим мастер-ключом после его ротации
+	if kms != nil && config.RewrapBatchSize > 0 {
+		rewrapJob := rewrap.Job{Service: &service.KeyRotationService{
+			Tracer:    otel.Tracer("purser_service_tracer"),
+			Repo:      repo,
+			KMS:       kms,
+			BatchSize: config.RewrapBatchSize,
+		}}
+		wg.Add(1)
+		go func() {
+			rewrapJob.StartRewrappingSecrets(mainCtx, config.RewrapCursor)
+			wg.Done()
+		}()
+	}
+
 	wg.Wait()
 	errCloseRepo := repo.Close(context.Background())
 	if errCloseRepo != nil {
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// KeepAccesses задаёт, сколько последних прочтений секрета хранится по умолчанию
+const KeepAccesses = 100
+
+// SecretAccess - запись журнала прочтений секрета
+type SecretAccess struct {
+	// Subject - субъект JWT токена читателя, пустой, если читатель не представился
+	Subject string `json:"subject"`
+	// AccessedAt - момент прочтения
+	AccessedAt time.Time `json:"accessedAt"`
+}
+
+// SecretStatus - состояние секрета для его создателя, тела и метаданных секрета в нём нет,
+// а его получение не засчитывается как прочтение
+type SecretStatus struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpireAt  time.Time `json:"expireAt"`
+	// Remaining - сколько секрету осталось жить
+	Remaining time.Duration `json:"-"`
+	// Opened - секрет хотя бы раз прочитали
+	Opened bool `json:"opened"`
+	// Views - сколько раз секрет прочитали
+	Views int64 `json:"views"`
+	// MaxViews - сколько раз можно прочитать секрет, 0 - без ограничений
+	MaxViews int64 `json:"maxViews"`
+	// ViewsLeft - сколько раз ещё можно прочитать секрет, -1 - без ограничений
+	ViewsLeft int64 `json:"viewsLeft"`
+	// Accesses - последние прочтения секрета, начиная с самого раннего
+	Accesses []SecretAccess `json:"accesses"`
+}
+
+// Status возвращает состояние секрета на момент now с журналом прочтений accesses
+func (s Secret) Status(now time.Time, accesses []SecretAccess) SecretStatus {
+	remaining := s.ExpireAt.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return SecretStatus{
+		ID:        s.ID,
+		CreatedAt: s.CreatedAt,
+		ExpireAt:  s.ExpireAt,
+		Remaining: remaining,
+		Opened:    s.Views > 0,
+		Views:     s.Views,
+		MaxViews:  s.MaxViews,
+		ViewsLeft: s.ViewsLeft(),
+		Accesses:  accesses,
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// AuditAction - что случилось с секретом
+type AuditAction string
+
+const (
+	// AuditCreate - секрет создан
+	AuditCreate AuditAction = "create"
+	// AuditRead - секрет прочитан
+	AuditRead AuditAction = "read"
+	// AuditUpdate - секрет изменён
+	AuditUpdate AuditAction = "update"
+	// AuditDelete - секрет удалён
+	AuditDelete AuditAction = "delete"
+	// AuditExpire - секрет устарел и удалён при очистке
+	AuditExpire AuditAction = "expire"
+	// AuditDenied - в доступе к секрету отказано
+	AuditDenied AuditAction = "denied"
+)
+
+// AuditEvent - запись журнала аудита о событии в жизни секрета
+type AuditEvent struct {
+	ID       string      `json:"id"`
+	SecretID string      `json:"secretId"`
+	Action   AuditAction `json:"action"`
+	// Subject - субъект JWT токена, пустой для событий, которые случились сами по себе, например, устаревания
+	Subject string `json:"subject"`
+	// Transport - через какой транспорт пришёл запрос - http, grpc или prune
+	Transport string `json:"transport"`
+	// RemoteIP - адрес клиента
+	RemoteIP string `json:"remoteIp"`
+	// UserAgent - клиент, отправивший запрос
+	UserAgent string `json:"userAgent"`
+	// TraceID - идентификатор трейса запроса
+	TraceID string `json:"traceId"`
+	// Detail - подробности события, например, причина отказа в доступе
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Client описывает, откуда пришёл запрос к секретам, транспорты передают его сервису в контексте
+type Client struct {
+	// Transport - название транспорта
+	Transport string
+	// RemoteIP - адрес клиента
+	RemoteIP string
+	// UserAgent - клиент, отправивший запрос
+	UserAgent string
+}
@@ -0,0 +1,17 @@
+package model
+
+// Identity описывает, кто обращается к секретам - субъект JWT токена и группы, в которых он состоит
+type Identity struct {
+	Subject string
+	Groups  []string
+}
+
+// InGroup возвращает истину, если субъект состоит в группе group
+func (i Identity) InGroup(group string) bool {
+	for k := range i.Groups {
+		if i.Groups[k] == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRequestNotFound ошибка, возвращаемая, если запроса секрета нет или он устарел
+var ErrRequestNotFound = errors.New("secret request not found")
+
+// ErrRequestFulfilled ошибка, возвращаемая, если по запросу секрета уже прислали секрет.
+// Ссылка на запрос одноразовая, поэтому прислать секрет второй раз нельзя
+var ErrRequestFulfilled = errors.New("secret request is already fulfilled")
+
+// SecretRequest - запрос секрета. Запрашивающий создаёт его с описанием того, что ему нужно, и передаёт
+// другой стороне одноразовую ссылку, по которой та без JWT токена присылает секрет. Прочитать присланный секрет
+// может только запрашивающий, так как он становится создателем секрета
+type SecretRequest struct {
+	ID string `json:"id"`
+	// Requester - субъект JWT токена запрашивающего
+	Requester string `json:"requester"`
+	// Description - описание того, какой секрет нужен, его видит тот, кто присылает секрет
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// ExpireAt - момент, после которого прислать секрет по запросу уже нельзя
+	ExpireAt time.Time `json:"expireAt"`
+	// SecretID - идентификатор присланного секрета, пустой, пока секрет не прислали
+	SecretID string `json:"secretId"`
+	// FulfilledAt - момент, когда прислали секрет
+	FulfilledAt time.Time `json:"fulfilledAt"`
+	// TokenHash - хэш токена ссылки, по которой присылают секрет, сам токен не хранится
+	TokenHash string `json:"-"`
+	// Token - токен ссылки, по которой присылают секрет, известен только сразу после создания запроса
+	Token string `json:"-"`
+}
+
+// Expired проверяет, устарел ли запрос
+func (r SecretRequest) Expired() bool {
+	return r.ExpireAt.Before(time.Now())
+}
+
+// Fulfilled возвращает истину, если по запросу уже прислали секрет
+func (r SecretRequest) Fulfilled() bool {
+	return r.SecretID != ""
+}
+
+// SecretRequestParams задаёт параметры, с которыми создаётся новый запрос секрета
+type SecretRequestParams struct {
+	// Requester задаёт запрашивающего, только он прочитает присланный секрет
+	Requester string
+	// Description задаёт описание того, какой секрет нужен
+	Description string
+	// TTL задаёт желаемый срок действия запроса, если не задан - используется срок жизни секретов по умолчанию
+	TTL time.Duration
+	// ExpireAt задаёт желаемый момент устаревания запроса, имеет приоритет перед TTL
+	ExpireAt time.Time
+}
@@ -2,25 +2,197 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
-// TTL задаёт срок жизни секрета
+// TTL задаёт срок жизни секрета по умолчанию
 const TTL = 3 * time.Hour
 
+// MinTTL задаёт минимальный срок жизни секрета по умолчанию
+const MinTTL = time.Minute
+
+// MaxTTL задаёт максимальный срок жизни секрета по умолчанию
+const MaxTTL = 7 * 24 * time.Hour
+
+// MaxPassphraseAttempts задаёт число неверных попыток ввода кодовой фразы по умолчанию, после которых секрет уничтожается
+const MaxPassphraseAttempts = 3
+
+// KeepVersions задаёт, сколько прежних версий секрета хранится по умолчанию
+const KeepVersions = 5
+
+// MaxBodyBytes задаёт максимальный размер тела секрета в байтах по умолчанию
+const MaxBodyBytes = 1 << 20
+
+// MaxMetaKeys задаёт максимальное число полей метаданных секрета по умолчанию
+const MaxMetaKeys = 32
+
+// MaxMetaKeyLength задаёт максимальную длину названия поля метаданных в байтах по умолчанию
+const MaxMetaKeyLength = 128
+
+// MaxMetaValueLength задаёт максимальную длину значения поля метаданных в байтах по умолчанию
+const MaxMetaValueLength = 4096
+
+// MaxSecretsPerSubject задаёт, сколько действующих секретов может быть у одного создателя по умолчанию
+const MaxSecretsPerSubject = 1000
+
 // ErrSecretNotFound ошибка, возвращаемая, если секрет не найден в хранилище
 var ErrSecretNotFound = errors.New("secret not found")
 
+// ErrPassphraseRequired ошибка, возвращаемая, если секрет защищён кодовой фразой, а её не передали
+var ErrPassphraseRequired = errors.New("passphrase required")
+
+// ErrWrongPassphrase ошибка, возвращаемая, если передана неверная кодовая фраза
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// ErrForbidden ошибка, возвращаемая, если у субъекта нет прав на секрет
+var ErrForbidden = errors.New("forbidden")
+
+// ErrInvalidCursor ошибка, возвращаемая, если курсор для постраничного списка секретов испорчен
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrVersionConflict ошибка, возвращаемая, если секрет изменён кем-то ещё и его версия не совпадает с ожидаемой
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrTooLarge ошибка, возвращаемая, если тело или метаданные секрета превышают допустимый размер
+var ErrTooLarge = errors.New("secret is too large")
+
+// ErrQuotaExceeded ошибка, возвращаемая, если у создателя слишком много действующих секретов
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ErrNotYetAvailable ошибка, возвращаемая, если секрет ещё не стал доступен для чтения
+var ErrNotYetAvailable = errors.New("secret is not yet available")
+
+// NotYetAvailableError ошибка, возвращаемая, если секрет станет доступен для чтения только в момент NotBefore.
+// Сравнивается через errors.Is с ErrNotYetAvailable
+type NotYetAvailableError struct {
+	NotBefore time.Time
+}
+
+// Error сообщает, когда секрет станет доступен
+func (e *NotYetAvailableError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrNotYetAvailable, e.NotBefore.UTC().Format(time.RFC3339))
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrNotYetAvailable
+func (e *NotYetAvailableError) Unwrap() error {
+	return ErrNotYetAvailable
+}
+
 // Secret - структура данных с которой работает приложение
 type Secret struct {
-	ID        string            `json:"id"`
-	Body      string            `json:"body"`
+	ID string `json:"id"`
+	// Body - тело секрета, может быть как текстом, так и двоичными данными
+	Body      []byte            `json:"body"`
 	Meta      map[string]string `json:"fields"`
 	CreatedAt time.Time         `json:"createdAt"`
 	ExpireAt  time.Time         `json:"expireAt"`
+	// NotBefore - момент, начиная с которого секрет можно прочитать, нулевой, если секрет доступен сразу
+	NotBefore time.Time `json:"notBefore"`
+	// ContentType - MIME тип тела секрета
+	ContentType string `json:"contentType"`
+	// Filename - имя файла, под которым тело секрета отдаётся на скачивание, пустое, если секрет не файл
+	Filename string `json:"filename"`
+	// Opaque - тело секрета зашифровано клиентом, и ключа у сервера нет. Такое тело не разбирается
+	Opaque bool `json:"opaque"`
+	// Owner - субъект JWT токена создателя секрета
+	Owner string `json:"owner"`
+	// Recipients - субъекты JWT токенов, которым адресован секрет
+	Recipients []string `json:"recipients"`
+	// Groups - группы из claim groups JWT токенов, участникам которых адресован секрет
+	Groups []string `json:"groups"`
+	// MaxViews - сколько раз можно прочитать секрет, прежде чем он будет уничтожен, 0 - без ограничений
+	MaxViews int64 `json:"maxViews"`
+	// Views - сколько раз секрет уже был прочитан
+	Views int64 `json:"views"`
+	// Version - версия секрета, у нового секрета - 1, увеличивается при каждом изменении
+	Version int64 `json:"version"`
+	// PassphraseHash - медленный хэш кодовой фразы, без которой секрет не выдаётся, сама фраза не хранится
+	PassphraseHash string `json:"-"`
+	// FailedAttempts - сколько раз была введена неверная кодовая фраза
+	FailedAttempts int64 `json:"-"`
+	// KeyID - идентификатор мастер-ключа, которым обёрнут ключ данных секрета, пустой, если секрет хранится открытым
+	KeyID string `json:"-"`
+	// WrappedKey - ключ данных секрета, зашифрованный мастер-ключом KeyID
+	WrappedKey []byte `json:"-"`
+	// ShareTokenHash - хэш токена ссылки, по которой секрет можно прочитать без JWT токена, сам токен не хранится
+	ShareTokenHash string `json:"-"`
+	// ShareToken - токен ссылки на секрет, известен только сразу после создания секрета
+	ShareToken string `json:"-"`
 }
 
 // Expired проверяет, устарел ни секрет
 func (s Secret) Expired() bool {
 	return s.ExpireAt.Before(time.Now())
 }
+
+// Active проверяет, можно ли уже прочитать секрет в момент now
+func (s Secret) Active(now time.Time) bool {
+	return !now.Before(s.NotBefore)
+}
+
+// Protected возвращает истину, если секрет защищён кодовой фразой
+func (s Secret) Protected() bool {
+	return s.PassphraseHash != ""
+}
+
+// AddressedTo возвращает истину, если секрет адресован субъекту или одной из его групп
+func (s Secret) AddressedTo(identity Identity) bool {
+	for k := range s.Recipients {
+		if s.Recipients[k] == identity.Subject {
+			return true
+		}
+	}
+	for k := range s.Groups {
+		if identity.InGroup(s.Groups[k]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Encrypted возвращает истину, если тело и метаданные секрета зашифрованы
+func (s Secret) Encrypted() bool {
+	return s.KeyID != ""
+}
+
+// ViewsLeft возвращает, сколько раз ещё можно прочитать секрет, или -1, если число прочтений не ограничено
+func (s Secret) ViewsLeft() int64 {
+	if s.MaxViews <= 0 {
+		return -1
+	}
+	if s.Views >= s.MaxViews {
+		return 0
+	}
+	return s.MaxViews - s.Views
+}
+
+// SecretParams задаёт параметры, с которыми создаётся новый секрет
+type SecretParams struct {
+	Body []byte
+	Meta map[string]string
+	// ContentType задаёт MIME тип тела секрета, если не задан - определяется по содержимому
+	ContentType string
+	// Filename задаёт имя файла, под которым тело секрета отдаётся на скачивание
+	Filename string
+	// Opaque задаёт, что тело секрета зашифровано клиентом, сервер хранит его как есть, не обогащая и проверяя только размер
+	Opaque bool
+	// Owner задаёт создателя секрета, прочитать или удалить секрет может только он и адресаты секрета
+	Owner string
+	// Recipients задаёт субъектов, которым адресован секрет
+	Recipients []string
+	// Groups задаёт группы, участникам которых адресован секрет
+	Groups []string
+	// TTL задаёт желаемый срок жизни секрета, если не задан - используется срок жизни по умолчанию
+	TTL time.Duration
+	// ExpireAt задаёт желаемый момент устаревания секрета, имеет приоритет перед TTL
+	ExpireAt time.Time
+	// NotBefore задаёт момент, начиная с которого секрет можно прочитать, если не задан - секрет доступен сразу
+	NotBefore time.Time
+	// MaxViews задаёт, сколько раз можно прочитать секрет, 0 - без ограничений
+	MaxViews int64
+	// Passphrase задаёт кодовую фразу, без которой секрет не будет выдан
+	Passphrase string
+	// CallbackURL задаёт адрес, на который создателю сообщается о прочтении, удалении или устаревании секрета
+	CallbackURL string
+}
@@ -0,0 +1,37 @@
+package model
+
+import "errors"
+
+// ErrInvalidSplit ошибка, возвращаемая, если секрет нельзя разделить с такими хранителями и порогом
+var ErrInvalidSplit = errors.New("invalid split parameters")
+
+// ErrInvalidShares ошибка, возвращаемая, если из присланных долей не удалось восстановить секрет:
+// доли испорчены, относятся к разным секретам или их меньше порога
+var ErrInvalidShares = errors.New("shares are invalid or too few to reconstruct secret")
+
+// SplitParams задаёт параметры секрета, который делится на доли по схеме Шамира.
+// Каждая доля сохраняется отдельным секретом, который прочитает только его хранитель и только один раз,
+// поэтому адресаты, группы и ограничение числа прочтений из SecretParams не учитываются
+type SplitParams struct {
+	SecretParams
+	// Holders задаёт хранителей долей, по одной доле на каждого
+	Holders []string
+	// Threshold задаёт, сколько долей нужно, чтобы восстановить секрет
+	Threshold int
+}
+
+// SplitShare - доля разделённого секрета, сохранённая отдельным секретом
+type SplitShare struct {
+	// Holder - субъект JWT токена хранителя доли
+	Holder string `json:"holder"`
+	// SecretID - идентификатор секрета с долей
+	SecretID string `json:"secretId"`
+}
+
+// Split - секрет, разделённый на доли по схеме Шамира. Сам секрет нигде не хранится
+type Split struct {
+	ID string `json:"id"`
+	// Threshold - сколько долей нужно, чтобы восстановить секрет
+	Threshold int          `json:"threshold"`
+	Shares    []SplitShare `json:"shares"`
+}
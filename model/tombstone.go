@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TombstoneRetention задаёт, сколько по умолчанию хранится запись о том, что секрет был и исчез
+const TombstoneRetention = 7 * 24 * time.Hour
+
+// ErrSecretGone ошибка, возвращаемая, если секрет был, но его уже прочитали, удалили или он устарел
+var ErrSecretGone = errors.New("secret is gone")
+
+// TombstoneReason - почему секрета больше нет
+type TombstoneReason string
+
+const (
+	// TombstoneRead - секрет сожжён при прочтении или прочитан максимальное число раз
+	TombstoneRead TombstoneReason = "read"
+	// TombstoneDeleted - секрет удалён создателем или адресатом
+	TombstoneDeleted TombstoneReason = "deleted"
+	// TombstoneExpired - секрет устарел и удалён при очистке
+	TombstoneExpired TombstoneReason = "expired"
+	// TombstoneDestroyed - секрет уничтожен после слишком многих неверных попыток ввода кодовой фразы
+	TombstoneDestroyed TombstoneReason = "destroyed"
+)
+
+// Tombstone - запись о секрете, которого больше нет, в ней нет ничего, кроме причины и момента исчезновения
+type Tombstone struct {
+	SecretID string          `json:"secretId"`
+	Reason   TombstoneReason `json:"reason"`
+	// DeletedAt - момент, когда секрет исчез
+	DeletedAt time.Time `json:"deletedAt"`
+	// ExpireAt - момент, после которого запись больше не хранится
+	ExpireAt time.Time `json:"-"`
+}
+
+// GoneError ошибка, возвращаемая, если секрет был, но его больше нет.
+// Сравнивается через errors.Is с ErrSecretGone, а также с ErrSecretNotFound,
+// чтобы её можно было обрабатывать как отсутствие секрета
+type GoneError struct {
+	Tombstone Tombstone
+}
+
+// Error сообщает, почему и когда исчез секрет
+func (e *GoneError) Error() string {
+	return fmt.Sprintf("%s: %s at %s", ErrSecretGone, e.Tombstone.Reason, e.Tombstone.DeletedAt.UTC().Format(time.RFC3339))
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrSecretGone
+func (e *GoneError) Unwrap() error {
+	return ErrSecretGone
+}
+
+// Is позволяет сравнивать ошибку с ErrSecretNotFound
+func (e *GoneError) Is(target error) bool {
+	return target == ErrSecretNotFound
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrPolicyViolation ошибка, возвращаемая, если содержимое секрета нарушает политику
+var ErrPolicyViolation = errors.New("secret violates content policy")
+
+// Violation описывает нарушение политики содержимого секрета
+type Violation struct {
+	// Rule - название нарушенного правила
+	Rule string `json:"rule"`
+	// Message - описание нарушения, в котором нет содержимого секрета
+	Message string `json:"message"`
+}
+
+// ValidationError ошибка, возвращаемая, если секрет отклонён правилами политики содержимого.
+// Сравнивается с ErrPolicyViolation через errors.Is
+type ValidationError struct {
+	// Violations - нарушения, из-за которых секрет отклонён
+	Violations []Violation `json:"violations"`
+}
+
+// Error перечисляет нарушенные правила
+func (e *ValidationError) Error() string {
+	rules := make([]string, len(e.Violations))
+	for i := range e.Violations {
+		rules[i] = e.Violations[i].Rule
+	}
+	return ErrPolicyViolation.Error() + ": " + strings.Join(rules, ", ")
+}
+
+// Unwrap позволяет сравнивать ошибку с ErrPolicyViolation
+func (e *ValidationError) Unwrap() error {
+	return ErrPolicyViolation
+}
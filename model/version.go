@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// SecretVersion - прежняя версия секрета, сохранённая при его изменении
+type SecretVersion struct {
+	// SecretID - идентификатор секрета
+	SecretID string `json:"secretId"`
+	// Version - номер версии
+	Version int64             `json:"version"`
+	Body    []byte            `json:"body"`
+	Meta    map[string]string `json:"fields"`
+	// ContentType и Filename - MIME тип и имя файла, которые были у этой версии
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename"`
+	// ExpireAt - момент устаревания секрета, который был у этой версии
+	ExpireAt time.Time `json:"expireAt"`
+	// ReplacedAt - момент, когда эту версию заменила следующая
+	ReplacedAt time.Time `json:"replacedAt"`
+	// KeyID - идентификатор мастер-ключа, которым обёрнут ключ данных версии, пустой, если версия хранится открытой
+	KeyID string `json:"-"`
+	// WrappedKey - ключ данных версии, зашифрованный мастер-ключом KeyID
+	WrappedKey []byte `json:"-"`
+}
+
+// Archive делает из текущего состояния секрета его прежнюю версию
+func (s Secret) Archive(replacedAt time.Time) SecretVersion {
+	return SecretVersion{
+		SecretID:    s.ID,
+		Version:     s.Version,
+		Body:        s.Body,
+		Meta:        s.Meta,
+		ContentType: s.ContentType,
+		Filename:    s.Filename,
+		ExpireAt:    s.ExpireAt,
+		ReplacedAt:  replacedAt,
+		KeyID:       s.KeyID,
+		WrappedKey:  s.WrappedKey,
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// WebhookGrace задаёт, сколько подписка на события секрета хранится после его устаревания,
+// чтобы очистка успела сообщить об устаревании
+const WebhookGrace = 24 * time.Hour
+
+// ErrInvalidCallbackURL ошибка, возвращаемая, если адрес для уведомлений о событиях секрета не годится
+var ErrInvalidCallbackURL = errors.New("invalid callback url")
+
+// ErrSubscriptionNotFound ошибка, возвращаемая, если у секрета нет подписки на события
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// WebhookEventType - тип события, о котором сообщается создателю секрета
+type WebhookEventType string
+
+const (
+	// WebhookSecretRead - секрет прочитан
+	WebhookSecretRead WebhookEventType = "purser.secret.read"
+	// WebhookSecretDeleted - секрет удалён
+	WebhookSecretDeleted WebhookEventType = "purser.secret.deleted"
+	// WebhookSecretExpired - секрет устарел, так и не прочитанным
+	WebhookSecretExpired WebhookEventType = "purser.secret.expired"
+)
+
+// WebhookSubscription - подписка создателя секрета на события с ним
+type WebhookSubscription struct {
+	SecretID string
+	// Owner - создатель секрета
+	Owner string
+	// URL - адрес, на который отправляются уведомления
+	URL string
+	// Read - истина, если секрет уже читали, тогда о его устаревании не сообщается
+	Read bool
+	// ExpireAt - момент, после которого подписка удаляется
+	ExpireAt time.Time
+}
+
+// WebhookDelivery - уведомление, которое не удалось доставить за все попытки
+type WebhookDelivery struct {
+	ID        string           `json:"id"`
+	SecretID  string           `json:"secretId"`
+	URL       string           `json:"url"`
+	EventType WebhookEventType `json:"eventType"`
+	// Payload - тело уведомления, которое отправлялось
+	Payload []byte `json:"payload"`
+	// Attempts - сколько было попыток
+	Attempts int64 `json:"attempts"`
+	// LastError - ошибка последней попытки
+	LastError string    `json:"lastError"`
+	CreatedAt time.Time `json:"createdAt"`
+}
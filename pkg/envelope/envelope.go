@@ -0,0 +1,73 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DataKeySize задаёт размер ключа данных в байтах, используется AES-256
+const DataKeySize = 32
+
+// ErrKeyNotFound ошибка, возвращаемая, если мастер-ключ с таким идентификатором неизвестен
+var ErrKeyNotFound = errors.New("master key not found")
+
+// KMS задаёт интерфейс системы управления ключами, которая оборачивает (шифрует) ключи данных мастер-ключом.
+// Сам мастер-ключ при этом может и не покидать KMS.
+type KMS interface {
+	// KeyID возвращает идентификатор текущего мастер-ключа, которым оборачиваются новые ключи данных
+	KeyID() string
+	// Wrap шифрует ключ данных текущим мастер-ключом и возвращает идентификатор этого мастер-ключа
+	Wrap(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// Unwrap расшифровывает ключ данных мастер-ключом с идентификатором keyID
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// NewDataKey генерирует новый случайный ключ данных
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	_, err := io.ReadFull(rand.Reader, key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal шифрует данные с помощью AES-GCM, случайный nonce записывается перед шифротекстом.
+// Дополнительные данные aad не шифруются, но должны совпасть при расшифровке.
+func Seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// Open расшифровывает данные, зашифрованные Seal
+func Open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
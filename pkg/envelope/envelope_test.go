@@ -0,0 +1,143 @@
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealOpen(t *testing.T) {
+	key, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating data key: %s", err)
+	}
+	sealed, err := Seal(key, []byte("top secret"), []byte("body"))
+	if err != nil {
+		t.Fatalf("error sealing: %s", err)
+	}
+	assert.NotContains(t, string(sealed), "top secret")
+
+	opened, err := Open(key, sealed, []byte("body"))
+	if err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	assert.Equal(t, "top secret", string(opened))
+
+	_, err = Open(key, sealed, []byte("meta"))
+	assert.Error(t, err, "ciphertext opened with wrong additional data")
+
+	otherKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating data key: %s", err)
+	}
+	_, err = Open(otherKey, sealed, []byte("body"))
+	assert.Error(t, err, "ciphertext opened with wrong key")
+
+	_, err = Open(key, sealed[:5], []byte("body"))
+	assert.Error(t, err, "truncated ciphertext opened")
+}
+
+func TestLocalKMS(t *testing.T) {
+	masterKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	err = os.WriteFile(keyFile, []byte(hex.EncodeToString(masterKey)+"\n"), 0600)
+	if err != nil {
+		t.Fatalf("error writing key file: %s", err)
+	}
+	kms, err := LoadKeyFile(keyFile)
+	if err != nil {
+		t.Fatalf("error loading key file: %s", err)
+	}
+	assert.Equal(t, Fingerprint(masterKey), kms.KeyID())
+
+	dataKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating data key: %s", err)
+	}
+	keyID, wrapped, err := kms.Wrap(context.TODO(), dataKey)
+	if err != nil {
+		t.Fatalf("error wrapping data key: %s", err)
+	}
+	assert.Equal(t, kms.KeyID(), keyID)
+	assert.NotEqual(t, dataKey, wrapped)
+
+	unwrapped, err := kms.Unwrap(context.TODO(), keyID, wrapped)
+	if err != nil {
+		t.Fatalf("error unwrapping data key: %s", err)
+	}
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = kms.Unwrap(context.TODO(), "unknown", wrapped)
+	assert.True(t, errors.Is(err, ErrKeyNotFound), "wrong error %v", err)
+
+	_, err = NewLocalKMS([]byte("too short"))
+	assert.Error(t, err, "short master key accepted")
+}
+
+func TestLocalKMS_Keyring(t *testing.T) {
+	oldKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	newKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating master key: %s", err)
+	}
+	oldKMS, err := NewLocalKMS(oldKey)
+	if err != nil {
+		t.Fatalf("error creating kms: %s", err)
+	}
+	dataKey, err := NewDataKey()
+	if err != nil {
+		t.Fatalf("error generating data key: %s", err)
+	}
+	oldKeyID, wrappedByOld, err := oldKMS.Wrap(context.TODO(), dataKey)
+	if err != nil {
+		t.Fatalf("error wrapping data key: %s", err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "keyring")
+	err = os.WriteFile(keyFile, []byte(
+		"# old key\n"+
+			hex.EncodeToString(oldKey)+"\n"+
+			"\n"+
+			"# new key\n"+
+			base64.StdEncoding.EncodeToString(newKey)+"\n",
+	), 0600)
+	if err != nil {
+		t.Fatalf("error writing key file: %s", err)
+	}
+	keyring, err := LoadKeyFile(keyFile)
+	if err != nil {
+		t.Fatalf("error loading keyring: %s", err)
+	}
+	assert.Equal(t, Fingerprint(newKey), keyring.KeyID(), "newest key is not current")
+
+	unwrapped, err := keyring.Unwrap(context.TODO(), oldKeyID, wrappedByOld)
+	if err != nil {
+		t.Fatalf("error unwrapping data key with old key: %s", err)
+	}
+	assert.Equal(t, dataKey, unwrapped)
+
+	newKeyID, _, err := keyring.Wrap(context.TODO(), dataKey)
+	if err != nil {
+		t.Fatalf("error wrapping data key: %s", err)
+	}
+	assert.Equal(t, Fingerprint(newKey), newKeyID, "data key is not wrapped with newest key")
+
+	err = os.WriteFile(keyFile, []byte("# nothing here\n"), 0600)
+	if err != nil {
+		t.Fatalf("error writing key file: %s", err)
+	}
+	_, err = LoadKeyFile(keyFile)
+	assert.Error(t, err, "empty keyring loaded")
+}
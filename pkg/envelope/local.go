@@ -0,0 +1,104 @@
+package envelope
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LocalKMS реализует KMS со связкой мастер-ключей, которая хранится в локальном файле.
+// Новые ключи данных оборачиваются последним ключом связки, а расшифровать можно любым из них,
+// так что старые ключи можно убирать из связки только после перешифровки всех секретов.
+type LocalKMS struct {
+	keyID string
+	keys  map[string][]byte
+}
+
+// NewLocalKMS создаёт LocalKMS со связкой мастер-ключей, последний из них используется для новых секретов.
+// Идентификатор каждого ключа вычисляется из его отпечатка.
+func NewLocalKMS(keys ...[]byte) (*LocalKMS, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("keyring is empty")
+	}
+	ret := LocalKMS{keys: make(map[string][]byte, len(keys))}
+	for i := range keys {
+		if len(keys[i]) != DataKeySize {
+			return nil, fmt.Errorf("master key #%v should be %v bytes long, got %v", i+1, DataKeySize, len(keys[i]))
+		}
+		ret.keyID = Fingerprint(keys[i])
+		ret.keys[ret.keyID] = keys[i]
+	}
+	return &ret, nil
+}
+
+// LoadKeyFile загружает связку мастер-ключей из файла, в котором каждый ключ записан на отдельной строке
+// в виде hex или base64 строки. Пустые строки и строки, начинающиеся с #, пропускаются.
+// Последний ключ в файле используется для новых секретов.
+func LoadKeyFile(path string) (*LocalKMS, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for line := 1; scanner.Scan(); line++ {
+		encoded := strings.TrimSpace(scanner.Text())
+		if encoded == "" || strings.HasPrefix(encoded, "#") {
+			continue
+		}
+		key, pErr := ParseKey(encoded)
+		if pErr != nil {
+			return nil, fmt.Errorf("error parsing master key from %s:%v : %w", path, line, pErr)
+		}
+		keys = append(keys, key)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalKMS(keys...)
+}
+
+// ParseKey разбирает мастер-ключ, записанный в виде hex или base64 строки
+func ParseKey(encoded string) ([]byte, error) {
+	if len(encoded) == hex.EncodedLen(DataKeySize) {
+		return hex.DecodeString(encoded)
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// Fingerprint возвращает идентификатор мастер-ключа - начало его SHA-256 хэша.
+// Сам ключ по идентификатору восстановить нельзя.
+func Fingerprint(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// KeyID возвращает идентификатор мастер-ключа, которым оборачиваются новые ключи данных
+func (l *LocalKMS) KeyID() string {
+	return l.keyID
+}
+
+// Wrap шифрует ключ данных последним мастер-ключом связки
+func (l *LocalKMS) Wrap(_ context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := Seal(l.keys[l.keyID], dataKey, []byte(l.keyID))
+	if err != nil {
+		return "", nil, err
+	}
+	return l.keyID, wrapped, nil
+}
+
+// Unwrap расшифровывает ключ данных мастер-ключом связки с идентификатором keyID
+func (l *LocalKMS) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, found := l.keys[keyID]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	return Open(key, wrapped, []byte(keyID))
+}
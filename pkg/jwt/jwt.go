@@ -9,10 +9,19 @@ import (
 
 type customClaims struct {
 	jwt.RegisteredClaims
+	// Groups - группы, в которых состоит субъект токена
+	Groups []string `json:"groups,omitempty"`
 }
 
 // ValidateJwtAndExtractSubject проверяет JWT токен и извлекает из него subject
 func ValidateJwtAndExtractSubject(raw string, hmacsecret string) (string, error) {
+	subject, _, err := ValidateJwtAndExtractIdentity(raw, hmacsecret)
+	return subject, err
+}
+
+// ValidateJwtAndExtractIdentity проверяет JWT токен и извлекает из него subject и группы из claim groups.
+// Токены без subject не принимаются, так как по subject определяется создатель секрета
+func ValidateJwtAndExtractIdentity(raw string, hmacsecret string) (subject string, groups []string, err error) {
 	// https://github.com/dgrijalva/jwt-go/pull/437
 	token, err := jwt.ParseWithClaims(raw, &customClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -24,34 +33,41 @@ func ValidateJwtAndExtractSubject(raw string, hmacsecret string) (string, error)
 	})
 
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if !token.Valid {
-		return "", fmt.Errorf("invalid token")
+		return "", nil, fmt.Errorf("invalid token")
 	}
 	issuedAt, err := token.Claims.GetIssuedAt()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if issuedAt == nil || issuedAt.IsZero() {
-		return "", fmt.Errorf("token issue time is unknown")
+		return "", nil, fmt.Errorf("token issue time is unknown")
 	}
 	if issuedAt.After(time.Now()) {
-		return "", fmt.Errorf("token issued in future")
+		return "", nil, fmt.Errorf("token issued in future")
 	}
 	expireAt, err := token.Claims.GetExpirationTime()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if expireAt == nil || expireAt.IsZero() {
-		return "", fmt.Errorf("token expiration time is unknown")
+		return "", nil, fmt.Errorf("token expiration time is unknown")
 	}
 	if expireAt.Before(time.Now()) {
-		return "", fmt.Errorf("token expired")
+		return "", nil, fmt.Errorf("token expired")
 	}
-	subj, err := token.Claims.GetSubject()
+	subject, err = token.Claims.GetSubject()
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+	if subject == "" {
+		return "", nil, fmt.Errorf("token subject is unknown")
+	}
+	claims, ok := token.Claims.(*customClaims)
+	if ok {
+		groups = claims.Groups
 	}
-	return subj, nil
+	return subject, groups, nil
 }
@@ -0,0 +1,126 @@
+// Package shamir реализует разделение секрета по схеме Шамира над полем GF(256): секрет делится на n долей так,
+// что любые k из них восстанавливают его, а меньшее число долей ничего о секрете не говорит.
+// Каждый байт секрета делится независимо, доля - это координата x в первом байте и значения многочленов в этой точке.
+// Схема не проверяет, что доли настоящие: из чужих или слишком малого числа долей получится случайный мусор,
+// поэтому проверять результат, например по хэшу, должен вызывающий код
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// MaxShares - наибольшее число долей, у каждой доли своя ненулевая координата x в GF(256)
+const MaxShares = 255
+
+// ErrInvalidShares возвращается, если доли не годятся для восстановления: их меньше двух,
+// они разной длины или у двух долей одна координата
+var ErrInvalidShares = errors.New("invalid shares")
+
+// exp и log - таблицы степеней и логарифмов по основанию 3, порождающему мультипликативную группу GF(256)
+// с многочленом x^8 + x^4 + x^3 + x + 1, как в AES
+var exp, log [256]byte
+
+func init() {
+	var x byte = 1
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		log[x] = byte(i)
+		// умножение на 3 - это умножение на 2 и сложение с самим собой
+		x ^= xtime(x)
+	}
+	exp[255] = exp[0]
+}
+
+// xtime умножает элемент поля на 2
+func xtime(a byte) byte {
+	if a&0x80 != 0 {
+		return a<<1 ^ 0x1b
+	}
+	return a << 1
+}
+
+// mul умножает элементы поля
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return exp[(int(log[a])+int(log[b]))%255]
+}
+
+// div делит элементы поля, b не должен быть нулём
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return exp[(int(log[a])-int(log[b])+255)%255]
+}
+
+// Split делит секрет на n долей, любые k из которых его восстанавливают. Должно быть 2 <= k <= n <= MaxShares
+func Split(secret []byte, n, k int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret is empty")
+	}
+	if k < 2 || k > n || n > MaxShares {
+		return nil, fmt.Errorf("threshold %v of %v shares is not supported", k, n)
+	}
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][0] = byte(i + 1)
+	}
+	coefficients := make([]byte, k)
+	for pos := range secret {
+		coefficients[0] = secret[pos]
+		_, err := rand.Read(coefficients[1:])
+		if err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			x := shares[i][0]
+			// значение многочлена в точке x по схеме Горнера
+			var y byte
+			for j := k - 1; j >= 0; j-- {
+				y = mul(y, x) ^ coefficients[j]
+			}
+			shares[i][pos+1] = y
+		}
+	}
+	return shares, nil
+}
+
+// Combine восстанавливает секрет из долей интерполяцией Лагранжа в нуле.
+// Если долей меньше, чем нужно, результат будет случайным, а не ошибкой
+func Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%w: at least 2 shares are required", ErrInvalidShares)
+	}
+	size := len(shares[0])
+	seen := make(map[byte]bool, len(shares))
+	for i := range shares {
+		if len(shares[i]) != size || size < 2 {
+			return nil, fmt.Errorf("%w: shares have different length", ErrInvalidShares)
+		}
+		x := shares[i][0]
+		if x == 0 || seen[x] {
+			return nil, fmt.Errorf("%w: duplicate share", ErrInvalidShares)
+		}
+		seen[x] = true
+	}
+	secret := make([]byte, size-1)
+	for i := range shares {
+		// базисный многочлен Лагранжа в нуле, вычитание в GF(256) - это xor
+		var basis byte = 1
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			basis = mul(basis, div(shares[j][0], shares[j][0]^shares[i][0]))
+		}
+		for pos := range secret {
+			secret[pos] ^= mul(shares[i][pos+1], basis)
+		}
+	}
+	return secret, nil
+}
@@ -0,0 +1,81 @@
+package shamir
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		assert.Equal(t, byte(1), div(byte(a), byte(a)), "a/a is not 1 for %v", a)
+		for b := 1; b < 256; b++ {
+			if div(mul(byte(a), byte(b)), byte(b)) != byte(a) {
+				t.Fatalf("a*b/b is not a for a=%v b=%v", a, b)
+			}
+		}
+	}
+	// пример из FIPS 197: {57} * {83} = {c1}
+	assert.Equal(t, byte(0xc1), mul(0x57, 0x83))
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("error splitting: %s", err)
+	}
+	assert.Len(t, shares, 5)
+	for i := range shares {
+		assert.Len(t, shares[i], len(secret)+1)
+		assert.NotContains(t, string(shares[i]), "horse", "share %v discloses secret", i)
+	}
+	testCases := [][][]byte{
+		{shares[0], shares[1], shares[2]},
+		{shares[4], shares[2], shares[0]},
+		{shares[1], shares[3], shares[4]},
+		shares,
+	}
+	for i := range testCases {
+		combined, err := Combine(testCases[i])
+		if err != nil {
+			t.Fatalf("error combining case %v: %s", i, err)
+		}
+		assert.Equal(t, string(secret), string(combined), "case %v", i)
+	}
+	combined, err := Combine(shares[:2])
+	if err != nil {
+		t.Fatalf("error combining too few shares: %s", err)
+	}
+	assert.NotEqual(t, string(secret), string(combined), "secret is recovered from 2 of 3 shares")
+}
+
+func TestSplitInvalid(t *testing.T) {
+	_, err := Split(nil, 3, 2)
+	assert.Error(t, err, "empty secret is split")
+	_, err = Split([]byte("secret"), 3, 1)
+	assert.Error(t, err, "threshold 1 is accepted")
+	_, err = Split([]byte("secret"), 2, 3)
+	assert.Error(t, err, "threshold above number of shares is accepted")
+	_, err = Split([]byte("secret"), MaxShares+1, 2)
+	assert.Error(t, err, "too many shares are accepted")
+}
+
+func TestCombineInvalid(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatalf("error splitting: %s", err)
+	}
+	testCases := [][][]byte{
+		nil,
+		{shares[0]},
+		{shares[0], shares[0]},
+		{shares[0], shares[1][:3]},
+		{shares[0], append([]byte{0}, shares[1][1:]...)},
+	}
+	for i := range testCases {
+		_, err = Combine(testCases[i])
+		assert.True(t, errors.Is(err, ErrInvalidShares), "wrong error %v for case %v", err, i)
+	}
+}
@@ -0,0 +1,57 @@
+package zeroknowledge
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/vodolaz095/purser/pkg/envelope"
+)
+
+// additionalData привязывает шифротекст к этому режиму, чтобы его нельзя было выдать за данные, зашифрованные иначе
+var additionalData = []byte("purser/zero-knowledge/v1")
+
+// Overhead - на сколько байт шифротекст Encrypt длиннее открытого текста: nonce и тег аутентификации AES-GCM.
+// Тело короче Overhead не может быть шифротекстом Encrypt
+const Overhead = 12 + 16
+
+// Encrypt шифрует тело секрета новым случайным ключом с помощью AES-256-GCM. На сервер загружается только
+// шифротекст, а ключ, закодированный в base64 без дополнения, пригодном для адресов, передаётся читателю отдельно
+func Encrypt(plaintext []byte) (sealed []byte, key string, err error) {
+	dataKey, err := envelope.NewDataKey()
+	if err != nil {
+		return nil, "", err
+	}
+	sealed, err = envelope.Seal(dataKey, plaintext, additionalData)
+	if err != nil {
+		return nil, "", err
+	}
+	return sealed, base64.RawURLEncoding.EncodeToString(dataKey), nil
+}
+
+// Decrypt расшифровывает тело секрета, зашифрованное Encrypt, ключом key
+func Decrypt(sealed []byte, key string) ([]byte, error) {
+	dataKey, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("malformed key: %w", err)
+	}
+	if len(dataKey) != envelope.DataKeySize {
+		return nil, fmt.Errorf("key should be %v bytes long, got %v", envelope.DataKeySize, len(dataKey))
+	}
+	return envelope.Open(dataKey, sealed, additionalData)
+}
+
+// Link добавляет ключ к ссылке на секрет в качестве фрагмента. Браузеры и HTTP клиенты не отправляют фрагмент
+// на сервер, поэтому ключ остаётся у того, кому передали ссылку
+func Link(shareURL, key string) string {
+	return shareURL + "#" + key
+}
+
+// ParseLink разбирает ссылку, собранную Link, или сам токен ссылки с ключом после #
+// и возвращает токен ссылки и ключ, пустой, если фрагмента нет
+func ParseLink(link string) (token, key string) {
+	link, key, _ = strings.Cut(link, "#")
+	link, _, _ = strings.Cut(link, "?")
+	return path.Base(strings.TrimSuffix(link, "/")), key
+}
@@ -0,0 +1,52 @@
+package zeroknowledge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	sealed, key, err := Encrypt([]byte("top secret"))
+	if err != nil {
+		t.Fatalf("error encrypting: %s", err)
+	}
+	assert.NotContains(t, string(sealed), "top secret")
+	assert.Len(t, sealed, len("top secret")+Overhead, "wrong ciphertext overhead")
+	assert.NotContains(t, key, "=", "key is padded")
+
+	opened, err := Decrypt(sealed, key)
+	if err != nil {
+		t.Fatalf("error decrypting: %s", err)
+	}
+	assert.Equal(t, "top secret", string(opened))
+
+	_, otherKey, err := Encrypt([]byte("other secret"))
+	if err != nil {
+		t.Fatalf("error encrypting: %s", err)
+	}
+	_, err = Decrypt(sealed, otherKey)
+	assert.Error(t, err, "ciphertext decrypted with wrong key")
+	_, err = Decrypt(sealed, "bm90IGEga2V5")
+	assert.Error(t, err, "ciphertext decrypted with short key")
+	_, err = Decrypt(sealed, "%%%")
+	assert.Error(t, err, "malformed key accepted")
+}
+
+func TestParseLink(t *testing.T) {
+	testCases := []struct {
+		link  string
+		token string
+		key   string
+	}{
+		{link: Link("https://purser.example.org/s/abc", "k3y"), token: "abc", key: "k3y"},
+		{link: "/s/abc?burn=true#k3y", token: "abc", key: "k3y"},
+		{link: "abc#k3y", token: "abc", key: "k3y"},
+		{link: "abc", token: "abc", key: ""},
+	}
+	for _, tc := range testCases {
+		token, key := ParseLink(tc.link)
+		assert.Equal(t, tc.token, token, "wrong token for %s", tc.link)
+		assert.Equal(t, tc.key, key, "wrong key for %s", tc.link)
+	}
+}